@@ -41,6 +41,12 @@ const (
 	// AdHocProfileServiceListProcedure is the fully-qualified name of the AdHocProfileService's List
 	// RPC.
 	AdHocProfileServiceListProcedure = "/adhocprofiles.v1.AdHocProfileService/List"
+	// AdHocProfileServiceDiffProcedure is the fully-qualified name of the AdHocProfileService's Diff
+	// RPC.
+	AdHocProfileServiceDiffProcedure = "/adhocprofiles.v1.AdHocProfileService/Diff"
+	// AdHocProfileServiceDeleteProcedure is the fully-qualified name of the AdHocProfileService's
+	// Delete RPC.
+	AdHocProfileServiceDeleteProcedure = "/adhocprofiles.v1.AdHocProfileService/Delete"
 )
 
 // AdHocProfileServiceClient is a client for the adhocprofiles.v1.AdHocProfileService service.
@@ -53,6 +59,11 @@ type AdHocProfileServiceClient interface {
 	Get(context.Context, *connect.Request[v1.AdHocProfilesGetRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error)
 	// Retrieves a list of profiles found in the underlying store.
 	List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error)
+	// Compares two profiles. Each side of the comparison is either an uploaded profile, or a query of the profile
+	// store. The response contains the diff flame graph.
+	Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error)
+	// Deletes a profile from the underlying store by id.
+	Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error)
 }
 
 // NewAdHocProfileServiceClient constructs a client for the adhocprofiles.v1.AdHocProfileService
@@ -84,6 +95,18 @@ func NewAdHocProfileServiceClient(httpClient connect.HTTPClient, baseURL string,
 			connect.WithSchema(adHocProfileServiceMethods.ByName("List")),
 			connect.WithClientOptions(opts...),
 		),
+		diff: connect.NewClient[v1.AdHocProfilesDiffRequest, v1.AdHocProfilesDiffResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceDiffProcedure,
+			connect.WithSchema(adHocProfileServiceMethods.ByName("Diff")),
+			connect.WithClientOptions(opts...),
+		),
+		delete: connect.NewClient[v1.AdHocProfilesDeleteRequest, v1.AdHocProfilesDeleteResponse](
+			httpClient,
+			baseURL+AdHocProfileServiceDeleteProcedure,
+			connect.WithSchema(adHocProfileServiceMethods.ByName("Delete")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
@@ -92,6 +115,8 @@ type adHocProfileServiceClient struct {
 	upload *connect.Client[v1.AdHocProfilesUploadRequest, v1.AdHocProfilesGetResponse]
 	get    *connect.Client[v1.AdHocProfilesGetRequest, v1.AdHocProfilesGetResponse]
 	list   *connect.Client[v1.AdHocProfilesListRequest, v1.AdHocProfilesListResponse]
+	diff   *connect.Client[v1.AdHocProfilesDiffRequest, v1.AdHocProfilesDiffResponse]
+	delete *connect.Client[v1.AdHocProfilesDeleteRequest, v1.AdHocProfilesDeleteResponse]
 }
 
 // Upload calls adhocprofiles.v1.AdHocProfileService.Upload.
@@ -109,6 +134,16 @@ func (c *adHocProfileServiceClient) List(ctx context.Context, req *connect.Reque
 	return c.list.CallUnary(ctx, req)
 }
 
+// Diff calls adhocprofiles.v1.AdHocProfileService.Diff.
+func (c *adHocProfileServiceClient) Diff(ctx context.Context, req *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	return c.diff.CallUnary(ctx, req)
+}
+
+// Delete calls adhocprofiles.v1.AdHocProfileService.Delete.
+func (c *adHocProfileServiceClient) Delete(ctx context.Context, req *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	return c.delete.CallUnary(ctx, req)
+}
+
 // AdHocProfileServiceHandler is an implementation of the adhocprofiles.v1.AdHocProfileService
 // service.
 type AdHocProfileServiceHandler interface {
@@ -120,6 +155,11 @@ type AdHocProfileServiceHandler interface {
 	Get(context.Context, *connect.Request[v1.AdHocProfilesGetRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error)
 	// Retrieves a list of profiles found in the underlying store.
 	List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error)
+	// Compares two profiles. Each side of the comparison is either an uploaded profile, or a query of the profile
+	// store. The response contains the diff flame graph.
+	Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error)
+	// Deletes a profile from the underlying store by id.
+	Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error)
 }
 
 // NewAdHocProfileServiceHandler builds an HTTP handler from the service implementation. It returns
@@ -147,6 +187,18 @@ func NewAdHocProfileServiceHandler(svc AdHocProfileServiceHandler, opts ...conne
 		connect.WithSchema(adHocProfileServiceMethods.ByName("List")),
 		connect.WithHandlerOptions(opts...),
 	)
+	adHocProfileServiceDiffHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceDiffProcedure,
+		svc.Diff,
+		connect.WithSchema(adHocProfileServiceMethods.ByName("Diff")),
+		connect.WithHandlerOptions(opts...),
+	)
+	adHocProfileServiceDeleteHandler := connect.NewUnaryHandler(
+		AdHocProfileServiceDeleteProcedure,
+		svc.Delete,
+		connect.WithSchema(adHocProfileServiceMethods.ByName("Delete")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/adhocprofiles.v1.AdHocProfileService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case AdHocProfileServiceUploadProcedure:
@@ -155,6 +207,10 @@ func NewAdHocProfileServiceHandler(svc AdHocProfileServiceHandler, opts ...conne
 			adHocProfileServiceGetHandler.ServeHTTP(w, r)
 		case AdHocProfileServiceListProcedure:
 			adHocProfileServiceListHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceDiffProcedure:
+			adHocProfileServiceDiffHandler.ServeHTTP(w, r)
+		case AdHocProfileServiceDeleteProcedure:
+			adHocProfileServiceDeleteHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -175,3 +231,11 @@ func (UnimplementedAdHocProfileServiceHandler) Get(context.Context, *connect.Req
 func (UnimplementedAdHocProfileServiceHandler) List(context.Context, *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.List is not implemented"))
 }
+
+func (UnimplementedAdHocProfileServiceHandler) Diff(context.Context, *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Diff is not implemented"))
+}
+
+func (UnimplementedAdHocProfileServiceHandler) Delete(context.Context, *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("adhocprofiles.v1.AdHocProfileService.Delete is not implemented"))
+}
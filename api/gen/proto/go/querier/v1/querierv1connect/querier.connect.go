@@ -58,8 +58,23 @@ const (
 	// QuerierServiceSelectSeriesProcedure is the fully-qualified name of the QuerierService's
 	// SelectSeries RPC.
 	QuerierServiceSelectSeriesProcedure = "/querier.v1.QuerierService/SelectSeries"
+	// QuerierServiceSelectTopFunctionsProcedure is the fully-qualified name of the QuerierService's
+	// SelectTopFunctions RPC.
+	QuerierServiceSelectTopFunctionsProcedure = "/querier.v1.QuerierService/SelectTopFunctions"
+	// QuerierServiceSelectHeatmapProcedure is the fully-qualified name of the QuerierService's
+	// SelectHeatmap RPC.
+	QuerierServiceSelectHeatmapProcedure = "/querier.v1.QuerierService/SelectHeatmap"
+	// QuerierServiceSelectExemplarsProcedure is the fully-qualified name of the QuerierService's
+	// SelectExemplars RPC.
+	QuerierServiceSelectExemplarsProcedure = "/querier.v1.QuerierService/SelectExemplars"
+	// QuerierServiceSelectProfileByIDProcedure is the fully-qualified name of the QuerierService's
+	// SelectProfileByID RPC.
+	QuerierServiceSelectProfileByIDProcedure = "/querier.v1.QuerierService/SelectProfileByID"
 	// QuerierServiceDiffProcedure is the fully-qualified name of the QuerierService's Diff RPC.
 	QuerierServiceDiffProcedure = "/querier.v1.QuerierService/Diff"
+	// QuerierServiceSelectGraphProcedure is the fully-qualified name of the QuerierService's
+	// SelectGraph RPC.
+	QuerierServiceSelectGraphProcedure = "/querier.v1.QuerierService/SelectGraph"
 	// QuerierServiceGetProfileStatsProcedure is the fully-qualified name of the QuerierService's
 	// GetProfileStats RPC.
 	QuerierServiceGetProfileStatsProcedure = "/querier.v1.QuerierService/GetProfileStats"
@@ -86,8 +101,20 @@ type QuerierServiceClient interface {
 	SelectMergeProfile(context.Context, *connect.Request[v1.SelectMergeProfileRequest]) (*connect.Response[v12.Profile], error)
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
+	// SelectTopFunctions returns the functions of the matching profiles with the biggest self value, along with their total value.
+	SelectTopFunctions(context.Context, *connect.Request[v1.SelectTopFunctionsRequest]) (*connect.Response[v1.SelectTopFunctionsResponse], error)
+	// SelectHeatmap returns the distribution of the total values of the requested profiles over time:
+	// for each step, the number of profiles which total value falls into each of the value buckets.
+	SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error)
+	// SelectExemplars returns the individual profiles with the biggest total value within each step.
+	SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error)
+	// SelectProfileByID returns the individual profile in pprof format.
+	SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error)
 	// Diff returns a diff of two profiles
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
+	// SelectGraph returns the call graph of the matching profiles in DOT format. If the base
+	// profile selector is specified, the graph shows the difference between the two profiles.
+	SelectGraph(context.Context, *connect.Request[v1.SelectGraphRequest]) (*connect.Response[v1.SelectGraphResponse], error)
 	// GetProfileStats returns profile stats for the current tenant.
 	GetProfileStats(context.Context, *connect.Request[v11.GetProfileStatsRequest]) (*connect.Response[v11.GetProfileStatsResponse], error)
 	AnalyzeQuery(context.Context, *connect.Request[v1.AnalyzeQueryRequest]) (*connect.Response[v1.AnalyzeQueryResponse], error)
@@ -152,12 +179,42 @@ func NewQuerierServiceClient(httpClient connect.HTTPClient, baseURL string, opts
 			connect.WithSchema(querierServiceMethods.ByName("SelectSeries")),
 			connect.WithClientOptions(opts...),
 		),
+		selectTopFunctions: connect.NewClient[v1.SelectTopFunctionsRequest, v1.SelectTopFunctionsResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectTopFunctionsProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectTopFunctions")),
+			connect.WithClientOptions(opts...),
+		),
+		selectHeatmap: connect.NewClient[v1.SelectHeatmapRequest, v1.SelectHeatmapResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectHeatmapProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectHeatmap")),
+			connect.WithClientOptions(opts...),
+		),
+		selectExemplars: connect.NewClient[v1.SelectExemplarsRequest, v1.SelectExemplarsResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectExemplarsProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectExemplars")),
+			connect.WithClientOptions(opts...),
+		),
+		selectProfileByID: connect.NewClient[v1.SelectProfileByIDRequest, v12.Profile](
+			httpClient,
+			baseURL+QuerierServiceSelectProfileByIDProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectProfileByID")),
+			connect.WithClientOptions(opts...),
+		),
 		diff: connect.NewClient[v1.DiffRequest, v1.DiffResponse](
 			httpClient,
 			baseURL+QuerierServiceDiffProcedure,
 			connect.WithSchema(querierServiceMethods.ByName("Diff")),
 			connect.WithClientOptions(opts...),
 		),
+		selectGraph: connect.NewClient[v1.SelectGraphRequest, v1.SelectGraphResponse](
+			httpClient,
+			baseURL+QuerierServiceSelectGraphProcedure,
+			connect.WithSchema(querierServiceMethods.ByName("SelectGraph")),
+			connect.WithClientOptions(opts...),
+		),
 		getProfileStats: connect.NewClient[v11.GetProfileStatsRequest, v11.GetProfileStatsResponse](
 			httpClient,
 			baseURL+QuerierServiceGetProfileStatsProcedure,
@@ -183,7 +240,12 @@ type querierServiceClient struct {
 	selectMergeSpanProfile *connect.Client[v1.SelectMergeSpanProfileRequest, v1.SelectMergeSpanProfileResponse]
 	selectMergeProfile     *connect.Client[v1.SelectMergeProfileRequest, v12.Profile]
 	selectSeries           *connect.Client[v1.SelectSeriesRequest, v1.SelectSeriesResponse]
+	selectTopFunctions     *connect.Client[v1.SelectTopFunctionsRequest, v1.SelectTopFunctionsResponse]
+	selectHeatmap          *connect.Client[v1.SelectHeatmapRequest, v1.SelectHeatmapResponse]
+	selectExemplars        *connect.Client[v1.SelectExemplarsRequest, v1.SelectExemplarsResponse]
+	selectProfileByID      *connect.Client[v1.SelectProfileByIDRequest, v12.Profile]
 	diff                   *connect.Client[v1.DiffRequest, v1.DiffResponse]
+	selectGraph            *connect.Client[v1.SelectGraphRequest, v1.SelectGraphResponse]
 	getProfileStats        *connect.Client[v11.GetProfileStatsRequest, v11.GetProfileStatsResponse]
 	analyzeQuery           *connect.Client[v1.AnalyzeQueryRequest, v1.AnalyzeQueryResponse]
 }
@@ -228,11 +290,36 @@ func (c *querierServiceClient) SelectSeries(ctx context.Context, req *connect.Re
 	return c.selectSeries.CallUnary(ctx, req)
 }
 
+// SelectTopFunctions calls querier.v1.QuerierService.SelectTopFunctions.
+func (c *querierServiceClient) SelectTopFunctions(ctx context.Context, req *connect.Request[v1.SelectTopFunctionsRequest]) (*connect.Response[v1.SelectTopFunctionsResponse], error) {
+	return c.selectTopFunctions.CallUnary(ctx, req)
+}
+
+// SelectHeatmap calls querier.v1.QuerierService.SelectHeatmap.
+func (c *querierServiceClient) SelectHeatmap(ctx context.Context, req *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error) {
+	return c.selectHeatmap.CallUnary(ctx, req)
+}
+
+// SelectExemplars calls querier.v1.QuerierService.SelectExemplars.
+func (c *querierServiceClient) SelectExemplars(ctx context.Context, req *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error) {
+	return c.selectExemplars.CallUnary(ctx, req)
+}
+
+// SelectProfileByID calls querier.v1.QuerierService.SelectProfileByID.
+func (c *querierServiceClient) SelectProfileByID(ctx context.Context, req *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error) {
+	return c.selectProfileByID.CallUnary(ctx, req)
+}
+
 // Diff calls querier.v1.QuerierService.Diff.
 func (c *querierServiceClient) Diff(ctx context.Context, req *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error) {
 	return c.diff.CallUnary(ctx, req)
 }
 
+// SelectGraph calls querier.v1.QuerierService.SelectGraph.
+func (c *querierServiceClient) SelectGraph(ctx context.Context, req *connect.Request[v1.SelectGraphRequest]) (*connect.Response[v1.SelectGraphResponse], error) {
+	return c.selectGraph.CallUnary(ctx, req)
+}
+
 // GetProfileStats calls querier.v1.QuerierService.GetProfileStats.
 func (c *querierServiceClient) GetProfileStats(ctx context.Context, req *connect.Request[v11.GetProfileStatsRequest]) (*connect.Response[v11.GetProfileStatsResponse], error) {
 	return c.getProfileStats.CallUnary(ctx, req)
@@ -261,8 +348,20 @@ type QuerierServiceHandler interface {
 	SelectMergeProfile(context.Context, *connect.Request[v1.SelectMergeProfileRequest]) (*connect.Response[v12.Profile], error)
 	// SelectSeries returns a time series for the total sum of the requested profiles.
 	SelectSeries(context.Context, *connect.Request[v1.SelectSeriesRequest]) (*connect.Response[v1.SelectSeriesResponse], error)
+	// SelectTopFunctions returns the functions of the matching profiles with the biggest self value, along with their total value.
+	SelectTopFunctions(context.Context, *connect.Request[v1.SelectTopFunctionsRequest]) (*connect.Response[v1.SelectTopFunctionsResponse], error)
+	// SelectHeatmap returns the distribution of the total values of the requested profiles over time:
+	// for each step, the number of profiles which total value falls into each of the value buckets.
+	SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error)
+	// SelectExemplars returns the individual profiles with the biggest total value within each step.
+	SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error)
+	// SelectProfileByID returns the individual profile in pprof format.
+	SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error)
 	// Diff returns a diff of two profiles
 	Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error)
+	// SelectGraph returns the call graph of the matching profiles in DOT format. If the base
+	// profile selector is specified, the graph shows the difference between the two profiles.
+	SelectGraph(context.Context, *connect.Request[v1.SelectGraphRequest]) (*connect.Response[v1.SelectGraphResponse], error)
 	// GetProfileStats returns profile stats for the current tenant.
 	GetProfileStats(context.Context, *connect.Request[v11.GetProfileStatsRequest]) (*connect.Response[v11.GetProfileStatsResponse], error)
 	AnalyzeQuery(context.Context, *connect.Request[v1.AnalyzeQueryRequest]) (*connect.Response[v1.AnalyzeQueryResponse], error)
@@ -323,12 +422,42 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 		connect.WithSchema(querierServiceMethods.ByName("SelectSeries")),
 		connect.WithHandlerOptions(opts...),
 	)
+	querierServiceSelectTopFunctionsHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectTopFunctionsProcedure,
+		svc.SelectTopFunctions,
+		connect.WithSchema(querierServiceMethods.ByName("SelectTopFunctions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectHeatmapHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectHeatmapProcedure,
+		svc.SelectHeatmap,
+		connect.WithSchema(querierServiceMethods.ByName("SelectHeatmap")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectExemplarsHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectExemplarsProcedure,
+		svc.SelectExemplars,
+		connect.WithSchema(querierServiceMethods.ByName("SelectExemplars")),
+		connect.WithHandlerOptions(opts...),
+	)
+	querierServiceSelectProfileByIDHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectProfileByIDProcedure,
+		svc.SelectProfileByID,
+		connect.WithSchema(querierServiceMethods.ByName("SelectProfileByID")),
+		connect.WithHandlerOptions(opts...),
+	)
 	querierServiceDiffHandler := connect.NewUnaryHandler(
 		QuerierServiceDiffProcedure,
 		svc.Diff,
 		connect.WithSchema(querierServiceMethods.ByName("Diff")),
 		connect.WithHandlerOptions(opts...),
 	)
+	querierServiceSelectGraphHandler := connect.NewUnaryHandler(
+		QuerierServiceSelectGraphProcedure,
+		svc.SelectGraph,
+		connect.WithSchema(querierServiceMethods.ByName("SelectGraph")),
+		connect.WithHandlerOptions(opts...),
+	)
 	querierServiceGetProfileStatsHandler := connect.NewUnaryHandler(
 		QuerierServiceGetProfileStatsProcedure,
 		svc.GetProfileStats,
@@ -359,8 +488,18 @@ func NewQuerierServiceHandler(svc QuerierServiceHandler, opts ...connect.Handler
 			querierServiceSelectMergeProfileHandler.ServeHTTP(w, r)
 		case QuerierServiceSelectSeriesProcedure:
 			querierServiceSelectSeriesHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectTopFunctionsProcedure:
+			querierServiceSelectTopFunctionsHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectHeatmapProcedure:
+			querierServiceSelectHeatmapHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectExemplarsProcedure:
+			querierServiceSelectExemplarsHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectProfileByIDProcedure:
+			querierServiceSelectProfileByIDHandler.ServeHTTP(w, r)
 		case QuerierServiceDiffProcedure:
 			querierServiceDiffHandler.ServeHTTP(w, r)
+		case QuerierServiceSelectGraphProcedure:
+			querierServiceSelectGraphHandler.ServeHTTP(w, r)
 		case QuerierServiceGetProfileStatsProcedure:
 			querierServiceGetProfileStatsHandler.ServeHTTP(w, r)
 		case QuerierServiceAnalyzeQueryProcedure:
@@ -406,10 +545,30 @@ func (UnimplementedQuerierServiceHandler) SelectSeries(context.Context, *connect
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectSeries is not implemented"))
 }
 
+func (UnimplementedQuerierServiceHandler) SelectTopFunctions(context.Context, *connect.Request[v1.SelectTopFunctionsRequest]) (*connect.Response[v1.SelectTopFunctionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectTopFunctions is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectHeatmap(context.Context, *connect.Request[v1.SelectHeatmapRequest]) (*connect.Response[v1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectHeatmap is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectExemplars(context.Context, *connect.Request[v1.SelectExemplarsRequest]) (*connect.Response[v1.SelectExemplarsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectExemplars is not implemented"))
+}
+
+func (UnimplementedQuerierServiceHandler) SelectProfileByID(context.Context, *connect.Request[v1.SelectProfileByIDRequest]) (*connect.Response[v12.Profile], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectProfileByID is not implemented"))
+}
+
 func (UnimplementedQuerierServiceHandler) Diff(context.Context, *connect.Request[v1.DiffRequest]) (*connect.Response[v1.DiffResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.Diff is not implemented"))
 }
 
+func (UnimplementedQuerierServiceHandler) SelectGraph(context.Context, *connect.Request[v1.SelectGraphRequest]) (*connect.Response[v1.SelectGraphResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.SelectGraph is not implemented"))
+}
+
 func (UnimplementedQuerierServiceHandler) GetProfileStats(context.Context, *connect.Request[v11.GetProfileStatsRequest]) (*connect.Response[v11.GetProfileStatsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("querier.v1.QuerierService.GetProfileStats is not implemented"))
 }
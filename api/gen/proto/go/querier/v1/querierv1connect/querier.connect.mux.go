@@ -59,11 +59,36 @@ func RegisterQuerierServiceHandler(mux *mux.Router, svc QuerierServiceHandler, o
 		svc.SelectSeries,
 		opts...,
 	))
+	mux.Handle("/querier.v1.QuerierService/SelectTopFunctions", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectTopFunctions",
+		svc.SelectTopFunctions,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectHeatmap", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectHeatmap",
+		svc.SelectHeatmap,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectExemplars", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectExemplars",
+		svc.SelectExemplars,
+		opts...,
+	))
+	mux.Handle("/querier.v1.QuerierService/SelectProfileByID", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectProfileByID",
+		svc.SelectProfileByID,
+		opts...,
+	))
 	mux.Handle("/querier.v1.QuerierService/Diff", connect.NewUnaryHandler(
 		"/querier.v1.QuerierService/Diff",
 		svc.Diff,
 		opts...,
 	))
+	mux.Handle("/querier.v1.QuerierService/SelectGraph", connect.NewUnaryHandler(
+		"/querier.v1.QuerierService/SelectGraph",
+		svc.SelectGraph,
+		opts...,
+	))
 	mux.Handle("/querier.v1.QuerierService/GetProfileStats", connect.NewUnaryHandler(
 		"/querier.v1.QuerierService/GetProfileStats",
 		svc.GetProfileStats,
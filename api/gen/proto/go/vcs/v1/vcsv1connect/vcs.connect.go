@@ -46,6 +46,14 @@ const (
 	VCSServiceGetCommitProcedure = "/vcs.v1.VCSService/GetCommit"
 	// VCSServiceGetCommitsProcedure is the fully-qualified name of the VCSService's GetCommits RPC.
 	VCSServiceGetCommitsProcedure = "/vcs.v1.VCSService/GetCommits"
+	// VCSServiceProviderAppProcedure is the fully-qualified name of the VCSService's ProviderApp RPC.
+	VCSServiceProviderAppProcedure = "/vcs.v1.VCSService/ProviderApp"
+	// VCSServiceProviderLoginProcedure is the fully-qualified name of the VCSService's ProviderLogin
+	// RPC.
+	VCSServiceProviderLoginProcedure = "/vcs.v1.VCSService/ProviderLogin"
+	// VCSServiceProviderRefreshProcedure is the fully-qualified name of the VCSService's
+	// ProviderRefresh RPC.
+	VCSServiceProviderRefreshProcedure = "/vcs.v1.VCSService/ProviderRefresh"
 )
 
 // VCSServiceClient is a client for the vcs.v1.VCSService service.
@@ -56,6 +64,11 @@ type VCSServiceClient interface {
 	GetFile(context.Context, *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error)
 	GetCommit(context.Context, *connect.Request[v1.GetCommitRequest]) (*connect.Response[v1.GetCommitResponse], error)
 	GetCommits(context.Context, *connect.Request[v1.GetCommitsRequest]) (*connect.Response[v1.GetCommitsResponse], error)
+	// Provider* methods implement the OAuth flow for the VCS
+	// provider of the repository: GitHub, GitLab, or Gitea.
+	ProviderApp(context.Context, *connect.Request[v1.ProviderAppRequest]) (*connect.Response[v1.ProviderAppResponse], error)
+	ProviderLogin(context.Context, *connect.Request[v1.ProviderLoginRequest]) (*connect.Response[v1.ProviderLoginResponse], error)
+	ProviderRefresh(context.Context, *connect.Request[v1.ProviderRefreshRequest]) (*connect.Response[v1.ProviderRefreshResponse], error)
 }
 
 // NewVCSServiceClient constructs a client for the vcs.v1.VCSService service. By default, it uses
@@ -105,17 +118,38 @@ func NewVCSServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...
 			connect.WithSchema(vCSServiceMethods.ByName("GetCommits")),
 			connect.WithClientOptions(opts...),
 		),
+		providerApp: connect.NewClient[v1.ProviderAppRequest, v1.ProviderAppResponse](
+			httpClient,
+			baseURL+VCSServiceProviderAppProcedure,
+			connect.WithSchema(vCSServiceMethods.ByName("ProviderApp")),
+			connect.WithClientOptions(opts...),
+		),
+		providerLogin: connect.NewClient[v1.ProviderLoginRequest, v1.ProviderLoginResponse](
+			httpClient,
+			baseURL+VCSServiceProviderLoginProcedure,
+			connect.WithSchema(vCSServiceMethods.ByName("ProviderLogin")),
+			connect.WithClientOptions(opts...),
+		),
+		providerRefresh: connect.NewClient[v1.ProviderRefreshRequest, v1.ProviderRefreshResponse](
+			httpClient,
+			baseURL+VCSServiceProviderRefreshProcedure,
+			connect.WithSchema(vCSServiceMethods.ByName("ProviderRefresh")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // vCSServiceClient implements VCSServiceClient.
 type vCSServiceClient struct {
-	githubApp     *connect.Client[v1.GithubAppRequest, v1.GithubAppResponse]
-	githubLogin   *connect.Client[v1.GithubLoginRequest, v1.GithubLoginResponse]
-	githubRefresh *connect.Client[v1.GithubRefreshRequest, v1.GithubRefreshResponse]
-	getFile       *connect.Client[v1.GetFileRequest, v1.GetFileResponse]
-	getCommit     *connect.Client[v1.GetCommitRequest, v1.GetCommitResponse]
-	getCommits    *connect.Client[v1.GetCommitsRequest, v1.GetCommitsResponse]
+	githubApp       *connect.Client[v1.GithubAppRequest, v1.GithubAppResponse]
+	githubLogin     *connect.Client[v1.GithubLoginRequest, v1.GithubLoginResponse]
+	githubRefresh   *connect.Client[v1.GithubRefreshRequest, v1.GithubRefreshResponse]
+	getFile         *connect.Client[v1.GetFileRequest, v1.GetFileResponse]
+	getCommit       *connect.Client[v1.GetCommitRequest, v1.GetCommitResponse]
+	getCommits      *connect.Client[v1.GetCommitsRequest, v1.GetCommitsResponse]
+	providerApp     *connect.Client[v1.ProviderAppRequest, v1.ProviderAppResponse]
+	providerLogin   *connect.Client[v1.ProviderLoginRequest, v1.ProviderLoginResponse]
+	providerRefresh *connect.Client[v1.ProviderRefreshRequest, v1.ProviderRefreshResponse]
 }
 
 // GithubApp calls vcs.v1.VCSService.GithubApp.
@@ -148,6 +182,21 @@ func (c *vCSServiceClient) GetCommits(ctx context.Context, req *connect.Request[
 	return c.getCommits.CallUnary(ctx, req)
 }
 
+// ProviderApp calls vcs.v1.VCSService.ProviderApp.
+func (c *vCSServiceClient) ProviderApp(ctx context.Context, req *connect.Request[v1.ProviderAppRequest]) (*connect.Response[v1.ProviderAppResponse], error) {
+	return c.providerApp.CallUnary(ctx, req)
+}
+
+// ProviderLogin calls vcs.v1.VCSService.ProviderLogin.
+func (c *vCSServiceClient) ProviderLogin(ctx context.Context, req *connect.Request[v1.ProviderLoginRequest]) (*connect.Response[v1.ProviderLoginResponse], error) {
+	return c.providerLogin.CallUnary(ctx, req)
+}
+
+// ProviderRefresh calls vcs.v1.VCSService.ProviderRefresh.
+func (c *vCSServiceClient) ProviderRefresh(ctx context.Context, req *connect.Request[v1.ProviderRefreshRequest]) (*connect.Response[v1.ProviderRefreshResponse], error) {
+	return c.providerRefresh.CallUnary(ctx, req)
+}
+
 // VCSServiceHandler is an implementation of the vcs.v1.VCSService service.
 type VCSServiceHandler interface {
 	GithubApp(context.Context, *connect.Request[v1.GithubAppRequest]) (*connect.Response[v1.GithubAppResponse], error)
@@ -156,6 +205,11 @@ type VCSServiceHandler interface {
 	GetFile(context.Context, *connect.Request[v1.GetFileRequest]) (*connect.Response[v1.GetFileResponse], error)
 	GetCommit(context.Context, *connect.Request[v1.GetCommitRequest]) (*connect.Response[v1.GetCommitResponse], error)
 	GetCommits(context.Context, *connect.Request[v1.GetCommitsRequest]) (*connect.Response[v1.GetCommitsResponse], error)
+	// Provider* methods implement the OAuth flow for the VCS
+	// provider of the repository: GitHub, GitLab, or Gitea.
+	ProviderApp(context.Context, *connect.Request[v1.ProviderAppRequest]) (*connect.Response[v1.ProviderAppResponse], error)
+	ProviderLogin(context.Context, *connect.Request[v1.ProviderLoginRequest]) (*connect.Response[v1.ProviderLoginResponse], error)
+	ProviderRefresh(context.Context, *connect.Request[v1.ProviderRefreshRequest]) (*connect.Response[v1.ProviderRefreshResponse], error)
 }
 
 // NewVCSServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -201,6 +255,24 @@ func NewVCSServiceHandler(svc VCSServiceHandler, opts ...connect.HandlerOption)
 		connect.WithSchema(vCSServiceMethods.ByName("GetCommits")),
 		connect.WithHandlerOptions(opts...),
 	)
+	vCSServiceProviderAppHandler := connect.NewUnaryHandler(
+		VCSServiceProviderAppProcedure,
+		svc.ProviderApp,
+		connect.WithSchema(vCSServiceMethods.ByName("ProviderApp")),
+		connect.WithHandlerOptions(opts...),
+	)
+	vCSServiceProviderLoginHandler := connect.NewUnaryHandler(
+		VCSServiceProviderLoginProcedure,
+		svc.ProviderLogin,
+		connect.WithSchema(vCSServiceMethods.ByName("ProviderLogin")),
+		connect.WithHandlerOptions(opts...),
+	)
+	vCSServiceProviderRefreshHandler := connect.NewUnaryHandler(
+		VCSServiceProviderRefreshProcedure,
+		svc.ProviderRefresh,
+		connect.WithSchema(vCSServiceMethods.ByName("ProviderRefresh")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/vcs.v1.VCSService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case VCSServiceGithubAppProcedure:
@@ -215,6 +287,12 @@ func NewVCSServiceHandler(svc VCSServiceHandler, opts ...connect.HandlerOption)
 			vCSServiceGetCommitHandler.ServeHTTP(w, r)
 		case VCSServiceGetCommitsProcedure:
 			vCSServiceGetCommitsHandler.ServeHTTP(w, r)
+		case VCSServiceProviderAppProcedure:
+			vCSServiceProviderAppHandler.ServeHTTP(w, r)
+		case VCSServiceProviderLoginProcedure:
+			vCSServiceProviderLoginHandler.ServeHTTP(w, r)
+		case VCSServiceProviderRefreshProcedure:
+			vCSServiceProviderRefreshHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -247,3 +325,15 @@ func (UnimplementedVCSServiceHandler) GetCommit(context.Context, *connect.Reques
 func (UnimplementedVCSServiceHandler) GetCommits(context.Context, *connect.Request[v1.GetCommitsRequest]) (*connect.Response[v1.GetCommitsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.GetCommits is not implemented"))
 }
+
+func (UnimplementedVCSServiceHandler) ProviderApp(context.Context, *connect.Request[v1.ProviderAppRequest]) (*connect.Response[v1.ProviderAppResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.ProviderApp is not implemented"))
+}
+
+func (UnimplementedVCSServiceHandler) ProviderLogin(context.Context, *connect.Request[v1.ProviderLoginRequest]) (*connect.Response[v1.ProviderLoginResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.ProviderLogin is not implemented"))
+}
+
+func (UnimplementedVCSServiceHandler) ProviderRefresh(context.Context, *connect.Request[v1.ProviderRefreshRequest]) (*connect.Response[v1.ProviderRefreshResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("vcs.v1.VCSService.ProviderRefresh is not implemented"))
+}
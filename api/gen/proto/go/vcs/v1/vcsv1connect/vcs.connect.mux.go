@@ -49,4 +49,19 @@ func RegisterVCSServiceHandler(mux *mux.Router, svc VCSServiceHandler, opts ...c
 		svc.GetCommits,
 		opts...,
 	))
+	mux.Handle("/vcs.v1.VCSService/ProviderApp", connect.NewUnaryHandler(
+		"/vcs.v1.VCSService/ProviderApp",
+		svc.ProviderApp,
+		opts...,
+	))
+	mux.Handle("/vcs.v1.VCSService/ProviderLogin", connect.NewUnaryHandler(
+		"/vcs.v1.VCSService/ProviderLogin",
+		svc.ProviderLogin,
+		opts...,
+	))
+	mux.Handle("/vcs.v1.VCSService/ProviderRefresh", connect.NewUnaryHandler(
+		"/vcs.v1.VCSService/ProviderRefresh",
+		svc.ProviderRefresh,
+		opts...,
+	))
 }
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/grafana/pyroscope/pkg/symbolizer"
+)
+
+type debuginfoUploadParams struct {
+	*phlareClient
+	Path    string
+	BuildID string
+}
+
+func addDebuginfoUploadParams(cmd commander) *debuginfoUploadParams {
+	params := &debuginfoUploadParams{}
+	params.phlareClient = addPhlareClient(cmd)
+
+	cmd.Arg("file", "Path to an unstripped ELF binary, a separate debug file, or a lidia file.").Required().ExistingFileVar(&params.Path)
+	cmd.Flag("build-id", "The build ID of the file. Required for lidia files, otherwise it is read from the ELF file.").StringVar(&params.BuildID)
+	return params
+}
+
+func debuginfoUpload(ctx context.Context, params *debuginfoUploadParams) error {
+	data, err := os.ReadFile(params.Path)
+	if err != nil {
+		return err
+	}
+	buildID := params.BuildID
+	if buildID == "" {
+		if buildID, err = readBuildID(data); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut,
+		fmt.Sprintf("%s/symbolizer/debuginfo/%s", params.URL, buildID),
+		bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	level.Info(logger).Log("msg", "uploading debug info", "path", params.Path, "build_id", buildID, "size", len(data))
+	res, err := params.phlareClient.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("upload failed: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+	level.Info(logger).Log("msg", "debug info uploaded", "build_id", buildID)
+	return nil
+}
+
+func readBuildID(data []byte) (string, error) {
+	f, err := elf.NewFile(bytes.NewReader(data))
+	if err != nil {
+		return "", errors.Wrap(err, "build ID must be specified for non-ELF files")
+	}
+	defer f.Close()
+	buildID, err := symbolizer.ELFBuildID(f)
+	if err != nil {
+		return "", err
+	}
+	if buildID == "" {
+		return "", errors.New("the ELF file has no build ID, it must be specified explicitly")
+	}
+	return buildID, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1/metastorev1connect"
+	connectapi "github.com/grafana/pyroscope/pkg/api/connect"
+	"github.com/grafana/pyroscope/pkg/operations"
+)
+
+func (c *phlareClient) tenantServiceClient() metastorev1connect.TenantServiceClient {
+	return metastorev1connect.NewTenantServiceClient(
+		c.httpClient(),
+		c.URL,
+		append(
+			connectapi.DefaultClientOptions(),
+			c.protocolOption(),
+		)...,
+	)
+}
+
+type deleteSeriesParams struct {
+	*phlareClient
+	Selector string
+	From     string
+	To       string
+}
+
+func addDeleteSeriesParams(cmd commander) *deleteSeriesParams {
+	params := &deleteSeriesParams{}
+	params.phlareClient = addPhlareClient(cmd)
+
+	cmd.Flag("selector", "Label selector of the series to delete.").Required().StringVar(&params.Selector)
+	cmd.Flag("from", "Beginning of the time range to delete the series profiles in.").Required().StringVar(&params.From)
+	cmd.Flag("to", "End of the time range to delete the series profiles in.").Default("now").StringVar(&params.To)
+	return params
+}
+
+func deleteSeries(ctx context.Context, params *deleteSeriesParams) error {
+	if params.TenantID == "" {
+		return errors.New("tenant ID is required")
+	}
+	from, err := operations.ParseTime(params.From)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from")
+	}
+	to, err := operations.ParseTime(params.To)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to")
+	}
+
+	level.Info(logger).Log("msg", "deleting series", "selector", params.Selector, "from", from, "to", to)
+	client := params.phlareClient.tenantServiceClient()
+	res, err := client.DeleteSeries(ctx, connect.NewRequest(&metastorev1.DeleteSeriesRequest{
+		TenantId:      params.TenantID,
+		LabelSelector: params.Selector,
+		StartTime:     from.UnixMilli(),
+		EndTime:       to.UnixMilli(),
+	}))
+	if err != nil {
+		return err
+	}
+
+	s, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(res.Msg.Tombstone)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(output(ctx), string(s))
+	return err
+}
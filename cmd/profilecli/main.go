@@ -72,8 +72,8 @@ func main() {
 
 	queryCmd := app.Command("query", "Query profile store.")
 	queryProfileCmd := queryCmd.Command("profile", "Request merged profile.").Alias("merge")
-	queryProfileOutput := queryProfileCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof").Default("console").String()
-	queryProfileParams := addQueryProfileParams(queryProfileCmd)
+	queryProfileOutput := queryProfileCmd.Flag("output", "How to output the result, examples: console, raw, dot, pprof=./my.pprof").Default("console").String()
+	queryProfileParams := addQueryMergeProfileParams(queryProfileCmd)
 	queryGoPGOCmd := queryCmd.Command("go-pgo", "Request profile for Go PGO.")
 	queryGoPGOOutput := queryGoPGOCmd.Flag("output", "How to output the result, examples: console, raw, pprof=./my.pprof").Default("pprof=./default.pgo").String()
 	queryGoPGOParams := addQueryGoPGOParams(queryGoPGOCmd)
@@ -81,6 +81,8 @@ func main() {
 	querySeriesParams := addQuerySeriesParams(querySeriesCmd)
 	queryLabelValuesCardinalityCmd := queryCmd.Command("label-values-cardinality", "Request label values cardinality.")
 	queryLabelValuesCardinalityParams := addQueryLabelValuesCardinalityParams(queryLabelValuesCardinalityCmd)
+	queryTopFunctionsCmd := queryCmd.Command("top-functions", "Request functions with the biggest self value.")
+	queryTopFunctionsParams := addQueryTopFunctionsParams(queryTopFunctionsCmd)
 
 	queryTracerCmd := app.Command("query-tracer", "Analyze query traces.")
 	queryTracerParams := addQueryTracerParams(queryTracerCmd)
@@ -102,6 +104,17 @@ func main() {
 	raftInfoCmd := raftCmd.Command("info", "Print info about a Raft node.")
 	raftInfoParams := addRaftInfoParams(raftInfoCmd)
 
+	deleteSeriesCmd := adminCmd.Command("delete-series", "Delete series matching the selector within the time range.")
+	deleteSeriesParams := addDeleteSeriesParams(deleteSeriesCmd)
+
+	recordingRulesCmd := adminCmd.Command("recording-rules", "Operate on recording rules.")
+	recordingRulesBackfillCmd := recordingRulesCmd.Command("backfill", "Evaluate a recording rule over the existing blocks.")
+	recordingRulesBackfillParams := addRecordingRulesBackfillParams(recordingRulesBackfillCmd)
+
+	debuginfoCmd := app.Command("debuginfo", "Operate on debug info used by the symbolizer.")
+	debuginfoUploadCmd := debuginfoCmd.Command("upload", "Upload debug info of a build ID.")
+	debuginfoUploadParams := addDebuginfoUploadParams(debuginfoUploadCmd)
+
 	// parse command line arguments
 	parsedCmd := kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -138,6 +151,11 @@ func main() {
 			os.Exit(checkError(err))
 		}
 
+	case queryTopFunctionsCmd.FullCommand():
+		if err := queryTopFunctions(ctx, queryTopFunctionsParams); err != nil {
+			os.Exit(checkError(err))
+		}
+
 	case blocksQuerySeriesCmd.FullCommand():
 		if err := blocksQuerySeries(ctx, blocksQuerySeriesParams); err != nil {
 			os.Exit(checkError(err))
@@ -181,6 +199,18 @@ func main() {
 		if err := raftInfo(ctx, raftInfoParams); err != nil {
 			os.Exit(checkError(err))
 		}
+	case deleteSeriesCmd.FullCommand():
+		if err := deleteSeries(ctx, deleteSeriesParams); err != nil {
+			os.Exit(checkError(err))
+		}
+	case recordingRulesBackfillCmd.FullCommand():
+		if err := recordingRulesBackfill(ctx, recordingRulesBackfillParams); err != nil {
+			os.Exit(checkError(err))
+		}
+	case debuginfoUploadCmd.FullCommand():
+		if err := debuginfoUpload(ctx, debuginfoUploadParams); err != nil {
+			os.Exit(checkError(err))
+		}
 	default:
 		level.Error(logger).Log("msg", "unknown command", "cmd", parsedCmd)
 	}
@@ -23,6 +23,7 @@ import (
 const (
 	outputConsole = "console"
 	outputRaw     = "raw"
+	outputDot     = "dot"
 	outputPprof   = "pprof="
 )
 
@@ -104,7 +104,67 @@ func addQueryProfileParams(queryCmd commander) *queryProfileParams {
 	return params
 }
 
-func queryProfile(ctx context.Context, params *queryProfileParams, outputFlag string) (err error) {
+type queryMergeProfileParams struct {
+	*queryProfileParams
+	BaseFrom  string
+	BaseTo    string
+	BaseQuery string
+	Graph     graphParams
+}
+
+type graphParams struct {
+	MaxNodes     int64
+	NodeFraction float64
+	EdgeFraction float64
+	Focus        string
+	Ignore       string
+}
+
+func addQueryMergeProfileParams(queryCmd commander) *queryMergeProfileParams {
+	params := new(queryMergeProfileParams)
+	params.queryProfileParams = addQueryProfileParams(queryCmd)
+	queryCmd.Flag("base-from", "Beginning of the base profile query, the graph output shows the difference to the base. Defaults to --from.").StringVar(&params.BaseFrom)
+	queryCmd.Flag("base-to", "End of the base profile query. Defaults to --to.").StringVar(&params.BaseTo)
+	queryCmd.Flag("base-query", "Label selector of the base profile query. Defaults to --query.").StringVar(&params.BaseQuery)
+	queryCmd.Flag("graph-max-nodes", "Maximum number of nodes in the graph output.").Default("100").Int64Var(&params.Graph.MaxNodes)
+	queryCmd.Flag("graph-node-fraction", "Hide graph nodes below the fraction of the total.").Default("0").Float64Var(&params.Graph.NodeFraction)
+	queryCmd.Flag("graph-edge-fraction", "Hide graph edges below the fraction of the total.").Default("0").Float64Var(&params.Graph.EdgeFraction)
+	queryCmd.Flag("graph-focus", "Only show samples with a function matching the regular expression in the graph output.").StringVar(&params.Graph.Focus)
+	queryCmd.Flag("graph-ignore", "Hide samples with a function matching the regular expression in the graph output.").StringVar(&params.Graph.Ignore)
+	return params
+}
+
+// hasBase reports whether the base profile is specified.
+func (p *queryMergeProfileParams) hasBase() bool {
+	return p.BaseFrom != "" || p.BaseTo != "" || p.BaseQuery != ""
+}
+
+func (p *queryMergeProfileParams) baseRequest(req *querierv1.SelectMergeProfileRequest) (*querierv1.SelectMergeProfileRequest, error) {
+	base := req.CloneVT()
+	if p.BaseQuery != "" {
+		base.LabelSelector = p.BaseQuery
+	}
+	if p.BaseFrom != "" {
+		from, err := operations.ParseTime(p.BaseFrom)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse base-from")
+		}
+		base.Start = from.UnixMilli()
+	}
+	if p.BaseTo != "" {
+		to, err := operations.ParseTime(p.BaseTo)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse base-to")
+		}
+		base.End = to.UnixMilli()
+	}
+	if base.End < base.Start {
+		return nil, errors.New("base-from cannot be after base-to")
+	}
+	return base, nil
+}
+
+func queryProfile(ctx context.Context, params *queryMergeProfileParams, outputFlag string) (err error) {
 	from, to, err := params.parseFromTo()
 	if err != nil {
 		return err
@@ -131,7 +191,40 @@ func queryProfile(ctx context.Context, params *queryProfileParams, outputFlag st
 		level.Info(logger).Log("msg", "selecting with stackstrace selector", "call-site", fmt.Sprintf("%#+v", params.StacktraceSelector))
 	}
 
-	return selectMergeProfile(ctx, params.phlareClient, outputFlag, req)
+	if outputFlag != outputDot {
+		if params.hasBase() {
+			return errors.New("base profile is only supported with the dot output")
+		}
+		return selectMergeProfile(ctx, params.phlareClient, outputFlag, req)
+	}
+
+	graphReq := &querierv1.SelectGraphRequest{
+		Profile: req,
+		Options: &querierv1.GraphOptions{
+			MaxNodes:     params.Graph.MaxNodes,
+			NodeFraction: params.Graph.NodeFraction,
+			EdgeFraction: params.Graph.EdgeFraction,
+			Focus:        params.Graph.Focus,
+			Ignore:       params.Graph.Ignore,
+		},
+	}
+	if params.hasBase() {
+		if graphReq.Base, err = params.baseRequest(req); err != nil {
+			return err
+		}
+		level.Info(logger).Log("msg", "query base profile", "from", time.UnixMilli(graphReq.Base.Start), "to", time.UnixMilli(graphReq.Base.End), "query", graphReq.Base.LabelSelector)
+	}
+	return selectGraph(ctx, params.phlareClient, graphReq)
+}
+
+func selectGraph(ctx context.Context, client *phlareClient, req *querierv1.SelectGraphRequest) error {
+	qc := client.queryClient()
+	resp, err := qc.SelectGraph(ctx, connect.NewRequest(req))
+	if err != nil {
+		return errors.Wrap(err, "failed to query")
+	}
+	_, err = fmt.Fprint(output(ctx), resp.Msg.Dot)
+	return err
 }
 
 func selectMergeProfile(ctx context.Context, client *phlareClient, outputFlag string, req *querierv1.SelectMergeProfileRequest) error {
@@ -336,3 +429,58 @@ func queryLabelValuesCardinality(ctx context.Context, params *queryLabelValuesCa
 
 	return nil
 }
+
+type queryTopFunctionsParams struct {
+	*queryParams
+	ProfileType string
+	Limit       int64
+}
+
+func addQueryTopFunctionsParams(queryCmd commander) *queryTopFunctionsParams {
+	params := new(queryTopFunctionsParams)
+	params.queryParams = addQueryParams(queryCmd)
+	queryCmd.Flag("profile-type", "Profile type to query.").Default("process_cpu:cpu:nanoseconds:cpu:nanoseconds").StringVar(&params.ProfileType)
+	queryCmd.Flag("limit", "Show the top N functions by self value.").Default("20").Int64Var(&params.Limit)
+	return params
+}
+
+func queryTopFunctions(ctx context.Context, params *queryTopFunctionsParams) (err error) {
+	from, to, err := params.parseFromTo()
+	if err != nil {
+		return err
+	}
+
+	level.Info(logger).Log("msg", "query top functions", "url", params.URL, "from", from, "to", to, "query", params.Query, "type", params.ProfileType)
+
+	qc := params.phlareClient.queryClient()
+	resp, err := qc.SelectTopFunctions(ctx, connect.NewRequest(&querierv1.SelectTopFunctionsRequest{
+		ProfileTypeID: params.ProfileType,
+		Start:         from.UnixMilli(),
+		End:           to.UnixMilli(),
+		LabelSelector: params.Query,
+		Limit:         &params.Limit,
+	}))
+	if err != nil {
+		return errors.Wrap(err, "failed to query")
+	}
+
+	percent := func(v int64) string {
+		if resp.Msg.Total == 0 {
+			return "0.00%"
+		}
+		return fmt.Sprintf("%.2f%%", float64(v)*100/float64(resp.Msg.Total))
+	}
+
+	table := tablewriter.NewWriter(output(ctx))
+	table.SetHeader([]string{"Function", "Self", "Self %", "Total", "Total %"})
+	for _, fn := range resp.Msg.Functions {
+		table.Append([]string{
+			fn.Name,
+			humanize.FormatInteger("#,###.", int(fn.Self)), percent(fn.Self),
+			humanize.FormatInteger("#,###.", int(fn.Total)), percent(fn.Total),
+		})
+	}
+	table.Render()
+
+	return nil
+}
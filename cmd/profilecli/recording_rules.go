@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+	"github.com/pkg/errors"
+
+	"github.com/grafana/pyroscope/pkg/metrics"
+	"github.com/grafana/pyroscope/pkg/operations"
+)
+
+type recordingRulesBackfillParams struct {
+	*phlareClient
+	MetricName string
+	From       string
+	To         string
+	Step       time.Duration
+}
+
+func addRecordingRulesBackfillParams(cmd commander) *recordingRulesBackfillParams {
+	params := &recordingRulesBackfillParams{}
+	params.phlareClient = addPhlareClient(cmd)
+
+	cmd.Flag("metric-name", "The metric name of the recording rule to backfill.").Required().StringVar(&params.MetricName)
+	cmd.Flag("from", "Beginning of the backfill time range.").Default("now-24h").StringVar(&params.From)
+	cmd.Flag("to", "End of the backfill time range. It must not overlap with the period when the rule is in effect.").Default("now-1h").StringVar(&params.To)
+	cmd.Flag("step", "Resolution of the backfilled series.").Default("1m").DurationVar(&params.Step)
+	return params
+}
+
+func recordingRulesBackfill(ctx context.Context, params *recordingRulesBackfillParams) error {
+	from, err := operations.ParseTime(params.From)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse from")
+	}
+	to, err := operations.ParseTime(params.To)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse to")
+	}
+
+	if params.Step < time.Millisecond {
+		return errors.New("step must be at least 1ms")
+	}
+	// The server limits the time range of a single request. The range
+	// is split into windows aligned to the step: the server extends the
+	// time range to the step boundaries, and unaligned windows would
+	// overlap, resulting in profiles accounted twice.
+	window := max(metrics.MaxBackfillRange/params.Step, 1) * params.Step
+	start := time.UnixMilli(from.UnixMilli() - from.UnixMilli()%params.Step.Milliseconds())
+	for start.Before(to) {
+		end := start.Add(window)
+		if end.After(to) {
+			end = to
+		}
+		if err = recordingRulesBackfillRange(ctx, params, start, end); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+func recordingRulesBackfillRange(ctx context.Context, params *recordingRulesBackfillParams, from, to time.Time) error {
+	form := url.Values{}
+	form.Set("metric_name", params.MetricName)
+	form.Set("start", strconv.FormatInt(from.UnixMilli(), 10))
+	form.Set("end", strconv.FormatInt(to.UnixMilli(), 10))
+	form.Set("step", params.Step.String())
+
+	req, err := http.NewRequestWithContext(ctx, "POST",
+		fmt.Sprintf("%s/compaction-worker/recording-rules/backfill", params.URL),
+		strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	level.Info(logger).Log("msg", "backfilling recording rule", "metric_name", params.MetricName, "from", from, "to", to, "step", params.Step)
+	res, err := params.phlareClient.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("backfill failed: %s: %s", res.Status, strings.TrimSpace(string(body)))
+	}
+
+	_, err = fmt.Fprintln(output(ctx), string(body))
+	return err
+}
@@ -111,6 +111,15 @@ type rangeCollector struct {
 	opt options
 }
 
+func newRangeCollector(opt options) *rangeCollector {
+	return &rangeCollector{
+		sb:  newStringBuilder(),
+		rb:  newRangesBuilder(),
+		lb:  newLineTableBuilder(),
+		opt: opt,
+	}
+}
+
 func (rc *rangeCollector) VisitRange(r *Range) {
 	lt := lineTableRef{}
 	funcOffset := rc.sb.add(r.Function)
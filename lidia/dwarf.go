@@ -0,0 +1,393 @@
+package lidia
+
+import (
+	"debug/dwarf"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// dwarfReader collects function ranges from the DWARF debug info:
+// every subprogram produces a range of depth 0, and every inlined
+// subroutine produces a range of its nesting depth.
+type dwarfReader struct {
+	data *dwarf.Data
+	rc   *rangeCollector
+
+	// Names and declaration files of the abstract origins
+	// and specifications, referenced by inlined subroutines.
+	origins map[dwarf.Offset]dwarfFunction
+	// Compilation units sorted by offset, and their file tables:
+	// an abstract origin may reside in another unit (e.g., LTO).
+	units     []*dwarf.Entry
+	unitFiles map[dwarf.Offset][]*dwarf.LineFile
+
+	// Line table rows of the current compilation unit.
+	lines []dwarfLine
+	files []*dwarf.LineFile
+
+	// Ranges of the subprograms (depth 0).
+	covered [][2]uint64
+	// Ranges of the current compilation unit: the line tables
+	// are built once the nested inline ranges are known.
+	pending []Range
+}
+
+type dwarfFunction struct {
+	name string
+	file string
+}
+
+type dwarfLine struct {
+	address uint64
+	line    uint32
+}
+
+// dwarfScope is an entry of the DIE tree walk stack.
+type dwarfScope struct {
+	// Depth of the innermost function range the DIE belongs to;
+	// -1 for DIEs outside of subprograms.
+	depth int
+}
+
+func newDWARFReader(data *dwarf.Data, rc *rangeCollector) *dwarfReader {
+	return &dwarfReader{
+		data:      data,
+		rc:        rc,
+		origins:   make(map[dwarf.Offset]dwarfFunction),
+		unitFiles: make(map[dwarf.Offset][]*dwarf.LineFile),
+	}
+}
+
+func (dr *dwarfReader) read() error {
+	r := dr.data.Reader()
+	for {
+		cu, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if cu == nil {
+			break
+		}
+		dr.units = append(dr.units, cu)
+		r.SkipChildren()
+	}
+	r.Seek(0)
+	for {
+		cu, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if cu == nil {
+			break
+		}
+		if cu.Tag != dwarf.TagCompileUnit && cu.Tag != dwarf.TagPartialUnit {
+			r.SkipChildren()
+			continue
+		}
+		if err = dr.readLines(cu); err != nil {
+			return fmt.Errorf("reading line table: %w", err)
+		}
+		if !cu.Children {
+			continue
+		}
+		if err = dr.readUnit(r); err != nil {
+			return err
+		}
+		dr.flush()
+	}
+	sort.Slice(dr.covered, func(i, j int) bool {
+		return dr.covered[i][0] < dr.covered[j][0]
+	})
+	return nil
+}
+
+// readUnit walks the DIE tree of the compilation unit.
+func (dr *dwarfReader) readUnit(r *dwarf.Reader) error {
+	stack := []dwarfScope{{depth: -1}}
+	for len(stack) > 0 {
+		e, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return io.ErrUnexpectedEOF
+		}
+		if e.Tag == 0 {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		scope := stack[len(stack)-1]
+		switch e.Tag {
+		case dwarf.TagSubprogram:
+			scope.depth = -1
+			if dr.visitFunction(e, 0) {
+				scope.depth = 0
+			}
+		case dwarf.TagInlinedSubroutine:
+			if scope.depth >= 0 && dr.visitFunction(e, scope.depth+1) {
+				scope.depth++
+			}
+		}
+		if e.Children {
+			stack = append(stack, scope)
+		}
+	}
+	return nil
+}
+
+// visitFunction adds the ranges of the subprogram or inlined
+// subroutine, and reports whether the DIE has any code ranges.
+func (dr *dwarfReader) visitFunction(e *dwarf.Entry, depth int) bool {
+	ranges, err := dr.data.Ranges(e)
+	if err != nil || len(ranges) == 0 {
+		return false
+	}
+	fn := dr.function(e, dr.files)
+	var callFile string
+	var callLine uint32
+	if depth > 0 {
+		if v, ok := e.Val(dwarf.AttrCallFile).(int64); ok {
+			callFile = fileName(dr.files, v)
+		}
+		if v, ok := e.Val(dwarf.AttrCallLine).(int64); ok {
+			callLine = uint32(v)
+		}
+	}
+	for _, rng := range ranges {
+		if rng[1] <= rng[0] {
+			continue
+		}
+		dr.pending = append(dr.pending, Range{
+			VA:       rng[0],
+			Length:   uint32(rng[1] - rng[0]),
+			Function: fn.name,
+			File:     fn.file,
+			CallFile: callFile,
+			CallLine: callLine,
+			Depth:    uint32(depth),
+		})
+		if depth == 0 {
+			dr.covered = append(dr.covered, rng)
+		}
+	}
+	return true
+}
+
+// function returns the name and the declaration file of the function,
+// following the abstract origin and specification references. The
+// file table must be of the compilation unit the entry belongs to.
+func (dr *dwarfReader) function(e *dwarf.Entry, files []*dwarf.LineFile) dwarfFunction {
+	fn := dwarfFunction{name: entryName(e)}
+	if v, ok := e.Val(dwarf.AttrDeclFile).(int64); ok {
+		fn.file = fileName(files, v)
+	}
+	if fn.name != "" && fn.file != "" {
+		return fn
+	}
+	for _, attr := range []dwarf.Attr{dwarf.AttrAbstractOrigin, dwarf.AttrSpecification} {
+		off, ok := e.Val(attr).(dwarf.Offset)
+		if !ok {
+			continue
+		}
+		ref := dr.origin(off)
+		if fn.name == "" {
+			fn.name = ref.name
+		}
+		if fn.file == "" {
+			fn.file = ref.file
+		}
+		break
+	}
+	return fn
+}
+
+func (dr *dwarfReader) origin(off dwarf.Offset) dwarfFunction {
+	if fn, ok := dr.origins[off]; ok {
+		return fn
+	}
+	// Prevent infinite recursion on malformed references.
+	dr.origins[off] = dwarfFunction{}
+	r := dr.data.Reader()
+	r.Seek(off)
+	e, err := r.Next()
+	if err != nil || e == nil {
+		return dr.origins[off]
+	}
+	fn := dr.function(e, dr.filesAt(off))
+	dr.origins[off] = fn
+	return fn
+}
+
+// filesAt returns the file table of the compilation
+// unit the entry at the given offset belongs to.
+func (dr *dwarfReader) filesAt(off dwarf.Offset) []*dwarf.LineFile {
+	i := sort.Search(len(dr.units), func(i int) bool {
+		return dr.units[i].Offset > off
+	})
+	if i == 0 {
+		return nil
+	}
+	cu := dr.units[i-1]
+	files, ok := dr.unitFiles[cu.Offset]
+	if !ok {
+		if lr, err := dr.data.LineReader(cu); err == nil && lr != nil {
+			files = lr.Files()
+		}
+		dr.unitFiles[cu.Offset] = files
+	}
+	return files
+}
+
+// entryName prefers the linkage name that matches the symbol table.
+func entryName(e *dwarf.Entry) string {
+	for _, attr := range []dwarf.Attr{dwarf.AttrLinkageName, dwarf.AttrName} {
+		if v, ok := e.Val(attr).(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func fileName(files []*dwarf.LineFile, i int64) string {
+	if i < 0 || i >= int64(len(files)) || files[i] == nil {
+		return ""
+	}
+	return files[i].Name
+}
+
+func (dr *dwarfReader) readLines(cu *dwarf.Entry) error {
+	dr.lines = dr.lines[:0]
+	dr.files = nil
+	lr, err := dr.data.LineReader(cu)
+	if err != nil || lr == nil {
+		return err
+	}
+	dr.files = lr.Files()
+	dr.unitFiles[cu.Offset] = dr.files
+	var row dwarf.LineEntry
+	for {
+		if err = lr.Next(&row); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+		if row.EndSequence {
+			continue
+		}
+		dr.lines = append(dr.lines, dwarfLine{address: row.Address, line: uint32(row.Line)})
+	}
+	sort.SliceStable(dr.lines, func(i, j int) bool {
+		return dr.lines[i].address < dr.lines[j].address
+	})
+	return nil
+}
+
+// flush adds the pending ranges of the compilation unit.
+func (dr *dwarfReader) flush() {
+	sort.SliceStable(dr.pending, func(i, j int) bool {
+		if dr.pending[i].VA == dr.pending[j].VA {
+			return dr.pending[i].Depth < dr.pending[j].Depth
+		}
+		return dr.pending[i].VA < dr.pending[j].VA
+	})
+	for i := range dr.pending {
+		r := &dr.pending[i]
+		r.LineTable = dr.lineTable(r, dr.pending[i+1:])
+		dr.rc.VisitRange(r)
+	}
+	dr.pending = dr.pending[:0]
+}
+
+// lineTable returns the line table of the range: the lines of the
+// innermost frames, keyed by the offset from the range start. The
+// ranges that follow are sorted by VA: the rows of the inline ranges
+// nested in the range are omitted, as their own line tables are used.
+func (dr *dwarfReader) lineTable(r *Range, next []Range) LineTable {
+	if !dr.rc.opt.lines {
+		return nil
+	}
+	start, end := r.VA, r.VA+uint64(r.Length)
+	i := sort.Search(len(dr.lines), func(i int) bool {
+		return dr.lines[i].address > start
+	})
+	// The row preceding the range start may cover it.
+	if i > 0 {
+		i--
+	}
+	var lt LineTable
+	for ; i < len(dr.lines) && dr.lines[i].address < end; i++ {
+		var offset uint32
+		if a := dr.lines[i].address; a > start {
+			offset = uint32(a - start)
+		}
+		if n := len(lt); n > 0 && lt[n-1].Offset == offset {
+			// The last row at the address wins.
+			lt[n-1].LineNumber = dr.lines[i].line
+			continue
+		}
+		lt = append(lt, LineTableEntry{Offset: offset, LineNumber: dr.lines[i].line})
+	}
+	var inline [][2]uint32
+	for _, c := range next {
+		if c.VA >= end {
+			break
+		}
+		if c.Depth == r.Depth+1 && c.VA >= start {
+			inline = append(inline, [2]uint32{uint32(c.VA - start), uint32(min(c.VA+uint64(c.Length), end) - start)})
+		}
+	}
+	return excludeLines(lt, inline, r.Length)
+}
+
+// excludeLines removes the rows within the ranges, sorted and
+// non-overlapping, and the rows that repeat the previous line.
+// The line in effect at the end of a range is restored, unless
+// the range ends at the length of the table.
+func excludeLines(lt LineTable, ranges [][2]uint32, length uint32) LineTable {
+	lineAt := func(offset uint32) uint32 {
+		i := sort.Search(len(lt), func(i int) bool {
+			return lt[i].Offset > offset
+		})
+		if i == 0 {
+			return 0
+		}
+		return lt[i-1].LineNumber
+	}
+	res := make(LineTable, 0, len(lt))
+	add := func(e LineTableEntry) {
+		if n := len(res); n > 0 && res[n-1].LineNumber == e.LineNumber {
+			return
+		}
+		res = append(res, e)
+	}
+	var j int
+	for _, e := range lt {
+		for ; j < len(ranges) && ranges[j][1] <= e.Offset; j++ {
+			if ranges[j][1] < e.Offset && ranges[j][1] < length {
+				add(LineTableEntry{Offset: ranges[j][1], LineNumber: lineAt(ranges[j][1])})
+			}
+		}
+		if j < len(ranges) && ranges[j][0] <= e.Offset {
+			continue
+		}
+		add(e)
+	}
+	for ; j < len(ranges); j++ {
+		if e := ranges[j][1]; e < length {
+			add(LineTableEntry{Offset: e, LineNumber: lineAt(e)})
+		}
+	}
+	return res
+}
+
+// covers reports whether the address belongs to any of the subprograms.
+func (dr *dwarfReader) covers(addr uint64) bool {
+	i := sort.Search(len(dr.covered), func(i int) bool {
+		return dr.covered[i][0] > addr
+	})
+	// Subprograms do not overlap.
+	return i > 0 && addr < dr.covered[i-1][1]
+}
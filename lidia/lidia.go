@@ -7,6 +7,7 @@ package lidia
 
 import (
 	"debug/elf"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -26,7 +27,8 @@ type Table struct {
 
 	vaTable []byte
 
-	fieldsBuffer []byte
+	fieldsBuffer    []byte
+	lineTableBuffer []byte
 }
 
 // SourceInfoFrame represents a single frame of symbolized profiling information.
@@ -153,21 +155,37 @@ func CreateLidia(executablePath, outputPath string, opts ...Option) error {
 // CreateLidiaFromELF generates a lidia format file from an already opened ELF file.
 // This allows more control over the ELF file handling.
 func CreateLidiaFromELF(elfFile *elf.File, output io.WriteSeeker, opts ...Option) error {
-	sb := newStringBuilder()
-	rb := newRangesBuilder()
-	lb := newLineTableBuilder()
-	rc := &rangeCollector{sb: sb, rb: rb, lb: lb}
-
+	var opt options
 	for _, o := range opts {
-		o(&rc.opt)
+		o(&opt)
+	}
+	rc := newRangeCollector(opt)
+
+	// Functions described in the debug info carry inline frames,
+	// source files and lines. Symbols are only used for the code
+	// the debug info does not cover.
+	var dr *dwarfReader
+	if data, err := elfFile.DWARF(); err == nil {
+		dr = newDWARFReader(data, rc)
+		if err = dr.read(); err != nil {
+			// Fall back to the symbol table, as if there was no debug info.
+			if opt.logger != nil {
+				_ = opt.logger.Log("msg", "failed to read DWARF, using ELF symbols", "err", err)
+			}
+			rc = newRangeCollector(opt)
+			dr = nil
+		}
 	}
 
 	symbols, err := elfFile.Symbols()
-	if err != nil {
+	if err != nil && (dr == nil || !errors.Is(err, elf.ErrNoSymbols)) {
 		return fmt.Errorf("failed to read symbols from ELF file: %w", err)
 	}
 
 	for _, symbol := range symbols {
+		if dr != nil && dr.covers(symbol.Value) {
+			continue
+		}
 		rc.VisitRange(&Range{
 			VA:        symbol.Value,
 			Length:    uint32(symbol.Size),
@@ -180,7 +198,7 @@ func CreateLidiaFromELF(elfFile *elf.File, output io.WriteSeeker, opts ...Option
 		})
 	}
 
-	rb.sort()
+	rc.rb.sort()
 
 	err = rc.write(output)
 	if err != nil {
@@ -204,6 +222,10 @@ func (st *Table) Lookup(dst []SourceInfoFrame, addr uint64) ([]SourceInfoFrame,
 	})
 	idx--
 
+	// Frames are collected from the innermost one: the line of an
+	// outer frame is the call site of the frame it calls.
+	var callFile stringOffset
+	var callLine uint64
 	for idx >= 0 {
 		it, err := st.getEntry(idx)
 		if err != nil {
@@ -212,19 +234,21 @@ func (st *Table) Lookup(dst []SourceInfoFrame, addr uint64) ([]SourceInfoFrame,
 
 		covered := it.va <= addr && addr < it.va+it.length
 		if covered {
-			name := st.str(it.funcOffset)
-			file := st.str(it.fileOffset)
-
 			res := SourceInfoFrame{
-				FunctionName: name,
-				FilePath:     file,
+				FunctionName: st.str(it.funcOffset),
+				FilePath:     st.str(it.fileOffset),
 			}
-
-			// Add line number information if available
-			//if it.lineTable.count > 0 {
-			// Line number could be extracted here if implemented
-			//}
-
+			if len(dst) == 0 {
+				if res.LineNumber, err = st.lineNumber(it.lineTable, addr-it.va); err != nil {
+					return dst, fmt.Errorf("failed to read line table at index %d: %w", idx, err)
+				}
+			} else {
+				res.LineNumber = callLine
+				if callFile != 0 {
+					res.FilePath = st.str(callFile)
+				}
+			}
+			callFile, callLine = it.callFile, it.callLine
 			dst = append(dst, res)
 		}
 
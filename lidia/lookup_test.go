@@ -0,0 +1,122 @@
+package lidia
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeSeeker implements io.WriteSeeker for testing.
+type writeSeeker struct {
+	buf []byte
+	off int
+}
+
+func (w *writeSeeker) Write(p []byte) (int, error) {
+	if n := w.off + len(p); n > len(w.buf) {
+		w.buf = append(w.buf, make([]byte, n-len(w.buf))...)
+	}
+	copy(w.buf[w.off:], p)
+	w.off += len(p)
+	return len(p), nil
+}
+
+func (w *writeSeeker) Seek(offset int64, _ int) (int64, error) {
+	w.off = int(offset)
+	return offset, nil
+}
+
+type readerAtCloser struct{ buf []byte }
+
+func (r *readerAtCloser) Read(p []byte) (int, error) { return copy(p, r.buf), nil }
+
+func (r *readerAtCloser) ReadAt(p []byte, off int64) (int, error) {
+	return copy(p, r.buf[off:]), nil
+}
+
+func (r *readerAtCloser) Close() error { return nil }
+
+func TestTable_LookupInlineFrames(t *testing.T) {
+	rc := &rangeCollector{
+		sb:  newStringBuilder(),
+		rb:  newRangesBuilder(),
+		lb:  newLineTableBuilder(),
+		opt: options{files: true, lines: true},
+	}
+	// main [0x1000, 0x1100) calls foo inlined at [0x1010, 0x1040),
+	// which calls bar inlined at [0x1020, 0x1030).
+	for _, r := range []*Range{
+		{
+			VA: 0x1000, Length: 0x100, Function: "main", File: "main.c",
+			LineTable: LineTable{{0, 10}, {0x10, 11}, {0x40, 12}},
+		},
+		{
+			VA: 0x1010, Length: 0x30, Function: "foo", File: "foo.h",
+			CallFile: "main.c", CallLine: 11, Depth: 1,
+			LineTable: LineTable{{0, 20}, {0x10, 21}, {0x20, 22}},
+		},
+		{
+			VA: 0x1020, Length: 0x10, Function: "bar", File: "bar.h",
+			CallFile: "foo.h", CallLine: 21, Depth: 2,
+			LineTable: LineTable{{0, 30}, {0x8, 31}},
+		},
+		{VA: 0x1100, Length: 0x10, Function: "baz"},
+	} {
+		rc.VisitRange(r)
+	}
+	rc.rb.sort()
+	w := new(writeSeeker)
+	require.NoError(t, rc.write(w))
+
+	table, err := OpenReader(&readerAtCloser{buf: w.buf}, WithCRC())
+	require.NoError(t, err)
+	defer table.Close()
+
+	for _, tc := range []struct {
+		addr     uint64
+		expected []SourceInfoFrame
+	}{
+		{addr: 0x1004, expected: []SourceInfoFrame{
+			{FunctionName: "main", FilePath: "main.c", LineNumber: 10},
+		}},
+		{addr: 0x1014, expected: []SourceInfoFrame{
+			{FunctionName: "foo", FilePath: "foo.h", LineNumber: 20},
+			{FunctionName: "main", FilePath: "main.c", LineNumber: 11},
+		}},
+		{addr: 0x1028, expected: []SourceInfoFrame{
+			{FunctionName: "bar", FilePath: "bar.h", LineNumber: 31},
+			{FunctionName: "foo", FilePath: "foo.h", LineNumber: 21},
+			{FunctionName: "main", FilePath: "main.c", LineNumber: 11},
+		}},
+		{addr: 0x1034, expected: []SourceInfoFrame{
+			{FunctionName: "foo", FilePath: "foo.h", LineNumber: 22},
+			{FunctionName: "main", FilePath: "main.c", LineNumber: 11},
+		}},
+		{addr: 0x1050, expected: []SourceInfoFrame{
+			{FunctionName: "main", FilePath: "main.c", LineNumber: 12},
+		}},
+		{addr: 0x1104, expected: []SourceInfoFrame{
+			{FunctionName: "baz"},
+		}},
+		{addr: 0x2000, expected: []SourceInfoFrame{}},
+	} {
+		frames, err := table.Lookup(nil, tc.addr)
+		require.NoError(t, err)
+		if len(tc.expected) == 0 {
+			require.Empty(t, frames, "0x%x", tc.addr)
+			continue
+		}
+		require.Equal(t, tc.expected, frames, "0x%x", tc.addr)
+	}
+}
+
+func TestExcludeLines(t *testing.T) {
+	// main [0, 0x100) calls foo inlined at [0x10, 0x40) and [0x80, 0x100).
+	lt := LineTable{{0, 10}, {0x8, 10}, {0x10, 20}, {0x20, 21}, {0x50, 12}, {0x80, 22}}
+	require.Equal(t,
+		LineTable{{0, 10}, {0x40, 21}, {0x50, 12}},
+		excludeLines(lt, [][2]uint32{{0x10, 0x40}, {0x80, 0x100}}, 0x100))
+	require.Equal(t,
+		LineTable{{0, 10}, {0x10, 20}, {0x20, 21}, {0x50, 12}, {0x80, 22}},
+		excludeLines(lt, nil, 0x100))
+}
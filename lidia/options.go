@@ -9,6 +9,14 @@ type options struct {
 	crc   bool // Enable CRC checking
 	lines bool // Include line number information
 	files bool // Include file path information
+
+	logger Logger
+}
+
+// Logger is the interface of the logger used to report warnings,
+// compatible with github.com/go-kit/log.
+type Logger interface {
+	Log(keyvals ...interface{}) error
 }
 
 // WithCRC enables CRC checking when opening lidia files.
@@ -31,3 +39,11 @@ func WithFiles() Option {
 		o.files = true
 	}
 }
+
+// WithLogger sets the logger used to report warnings, such as
+// malformed debug info ignored when creating lidia files.
+func WithLogger(l Logger) Option {
+	return func(o *options) {
+		o.logger = l
+	}
+}
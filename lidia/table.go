@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"sort"
 )
 
 type entry struct {
@@ -80,6 +81,40 @@ func (st *Table) getEntryVA(i int) uint64 {
 	return binary.LittleEndian.Uint64(it)
 }
 
+// lineNumber returns the line number of the instruction at the offset
+// from the range start: the line of the last entry at or before it.
+func (st *Table) lineNumber(ref lineTableRef, offset uint64) (uint64, error) {
+	if ref.count == 0 || ref.idx+ref.count > st.hdr.lineTablesHeader.count {
+		return 0, nil
+	}
+	fieldSize := int(st.hdr.lineTablesHeader.fieldSize)
+	entrySize := fieldSize * lineTableFieldsCount
+	size := entrySize * int(ref.count)
+	if cap(st.lineTableBuffer) < size {
+		st.lineTableBuffer = make([]byte, size)
+	}
+	buf := st.lineTableBuffer[:size]
+	o := int64(st.hdr.lineTablesHeader.offset) + int64(ref.idx)*int64(entrySize)
+	if _, err := st.file.ReadAt(buf, o); err != nil && err != io.EOF {
+		return 0, err
+	}
+	field := func(i, j int) uint64 {
+		b := buf[i*entrySize+j*fieldSize:]
+		if fieldSize == 2 {
+			return uint64(binary.LittleEndian.Uint16(b))
+		}
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	n := int(ref.count)
+	i := sort.Search(n, func(i int) bool {
+		return field(i, 0) > offset
+	})
+	if i == 0 {
+		return 0, nil
+	}
+	return field(i-1, 1), nil
+}
+
 func (st *Table) str(offset stringOffset) string {
 	if offset == 0 {
 		return ""
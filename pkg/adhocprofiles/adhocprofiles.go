@@ -6,6 +6,7 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io"
 	"slices"
@@ -21,6 +22,7 @@ import (
 	"github.com/pkg/errors"
 
 	v1 "github.com/grafana/pyroscope/api/gen/proto/go/adhocprofiles/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
 	"github.com/grafana/pyroscope/pkg/frontend"
 	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
@@ -28,12 +30,32 @@ import (
 	"github.com/grafana/pyroscope/pkg/validation"
 )
 
+type Config struct {
+	QueryURL        string        `yaml:"query_url"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval" category:"advanced"`
+}
+
+func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
+	f.StringVar(&cfg.QueryURL, "adhoc-profiles.query-url", "", "URL of the query API used to compare uploaded profiles with the profiles in the store. If empty, the HTTP server of this instance is used.")
+	f.DurationVar(&cfg.CleanupInterval, "adhoc-profiles.cleanup-interval", time.Hour, "How frequently uploaded profiles exceeding the retention period are deleted.")
+}
+
+type Limits interface {
+	frontend.Limits
+	AdHocProfilesRetentionPeriod(tenantID string) time.Duration
+}
+
 type AdHocProfiles struct {
 	services.Service
 
-	logger log.Logger
-	limits frontend.Limits
-	bucket objstore.Bucket
+	config  Config
+	logger  log.Logger
+	limits  Limits
+	bucket  objstore.Bucket
+	querier querierv1connect.QuerierServiceClient
+	// Identifies the instance as the cleanup lease owner.
+	id  string
+	now func() time.Time
 }
 
 type AdHocProfile struct {
@@ -59,6 +81,26 @@ func validID(id string) bool {
 	return true
 }
 
+func checkID(id string) error {
+	if !validID(id) {
+		return connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("id '%s' is invalid: can only contain [a-zA-Z0-9_-.]", id))
+	}
+	return nil
+}
+
+// parseID splits the id into the ULID and the profile name.
+func parseID(id string) (ulid.ULID, string, error) {
+	separatorIndex := strings.IndexRune(id, '-')
+	if separatorIndex < 0 {
+		return ulid.ULID{}, "", fmt.Errorf("missing name separator")
+	}
+	uid, err := ulid.Parse(id[0:separatorIndex])
+	if err != nil {
+		return ulid.ULID{}, "", err
+	}
+	return uid, id[separatorIndex+1:], nil
+}
+
 // replaces invalid runes in the id with underscores
 func replaceInvalidRunes(id string) string {
 	return strings.Map(func(r rune) rune {
@@ -69,19 +111,44 @@ func replaceInvalidRunes(id string) string {
 	}, id)
 }
 
-func NewAdHocProfiles(bucket objstore.Bucket, logger log.Logger, limits frontend.Limits) *AdHocProfiles {
+func NewAdHocProfiles(
+	config Config,
+	bucket objstore.Bucket,
+	logger log.Logger,
+	limits Limits,
+	querier querierv1connect.QuerierServiceClient,
+) *AdHocProfiles {
 	a := &AdHocProfiles{
-		logger: logger,
-		bucket: bucket,
-		limits: limits,
+		config:  config,
+		logger:  logger,
+		bucket:  bucket,
+		limits:  limits,
+		querier: querier,
+		id:      ulid.MustNew(ulid.Now(), rand.Reader).String(),
+		now:     time.Now,
 	}
 	a.Service = services.NewBasicService(nil, a.running, nil)
 	return a
 }
 
 func (a *AdHocProfiles) running(ctx context.Context) error {
-	<-ctx.Done()
-	return nil
+	if a.config.CleanupInterval <= 0 {
+		<-ctx.Done()
+		return nil
+	}
+	ticker := time.NewTicker(a.config.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		// Only one instance deletes the expired profiles at a time.
+		if a.acquireCleanupLease(ctx) {
+			a.cleanup(ctx)
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
 }
 
 func (a *AdHocProfiles) Upload(ctx context.Context, c *connect.Request[v1.AdHocProfilesUploadRequest]) (*connect.Response[v1.AdHocProfilesGetResponse], error) {
@@ -147,28 +214,7 @@ func (a *AdHocProfiles) Get(ctx context.Context, c *connect.Request[v1.AdHocProf
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	bucket := a.getBucket(tenantID)
-
-	id := c.Msg.GetId()
-	if !validID(id) {
-		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("id '%s' is invalid: can only contain [a-zA-Z0-9_-.]", id))
-	}
-
-	reader, err := bucket.Get(ctx, id)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to get profile")
-	}
-	defer func() {
-		_ = reader.Close()
-	}()
-
-	adHocProfileBytes, err := io.ReadAll(reader)
-	if err != nil {
-		return nil, err
-	}
-
-	var adHocProfile AdHocProfile
-	err = json.Unmarshal(adHocProfileBytes, &adHocProfile)
+	adHocProfile, err := a.load(ctx, tenantID, c.Msg.GetId())
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +224,7 @@ func (a *AdHocProfiles) Get(ctx context.Context, c *connect.Request[v1.AdHocProf
 		return nil, errors.Wrapf(err, "could not determine max nodes")
 	}
 
-	profile, profileTypes, err := parse(&adHocProfile, c.Msg.ProfileType, maxNodes)
+	profile, profileTypes, err := parse(adHocProfile, c.Msg.ProfileType, maxNodes)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to parse profile")
 	}
@@ -198,6 +244,30 @@ func (a *AdHocProfiles) Get(ctx context.Context, c *connect.Request[v1.AdHocProf
 	}), nil
 }
 
+func (a *AdHocProfiles) Delete(ctx context.Context, c *connect.Request[v1.AdHocProfilesDeleteRequest]) (*connect.Response[v1.AdHocProfilesDeleteResponse], error) {
+	bucket, err := a.getBucketFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id := c.Msg.GetId()
+	if err = checkID(id); err != nil {
+		return nil, err
+	}
+
+	exists, err := bucket.Exists(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete profile")
+	}
+	if !exists {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile '%s' not found", id))
+	}
+	if err = bucket.Delete(ctx, id); err != nil {
+		return nil, errors.Wrapf(err, "failed to delete profile")
+	}
+	return connect.NewResponse(&v1.AdHocProfilesDeleteResponse{}), nil
+}
+
 func (a *AdHocProfiles) List(ctx context.Context, c *connect.Request[v1.AdHocProfilesListRequest]) (*connect.Response[v1.AdHocProfilesListResponse], error) {
 	bucket, err := a.getBucketFromContext(ctx)
 	if err != nil {
@@ -211,13 +281,11 @@ func (a *AdHocProfiles) List(ctx context.Context, c *connect.Request[v1.AdHocPro
 			return nil
 		}
 
-		separatorIndex := strings.IndexRune(s, '-')
-		id, err := ulid.Parse(s[0:separatorIndex])
+		id, name, err := parseID(s)
 		if err != nil {
 			level.Warn(a.logger).Log("msg", "cannot parse ad hoc profile", "key", s, "err", err)
 			return nil
 		}
-		name := s[separatorIndex+1:]
 		profiles = append(profiles, &v1.AdHocProfilesProfileMetadata{
 			Id:         s,
 			Name:       name,
@@ -241,6 +309,33 @@ func (a *AdHocProfiles) List(ctx context.Context, c *connect.Request[v1.AdHocPro
 	return connect.NewResponse(&v1.AdHocProfilesListResponse{Profiles: profiles}), nil
 }
 
+// load reads the profile from the tenant bucket.
+func (a *AdHocProfiles) load(ctx context.Context, tenantID, id string) (*AdHocProfile, error) {
+	if err := checkID(id); err != nil {
+		return nil, err
+	}
+
+	reader, err := a.getBucket(tenantID).Get(ctx, id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get profile")
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+
+	adHocProfileBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	var adHocProfile AdHocProfile
+	err = json.Unmarshal(adHocProfileBytes, &adHocProfile)
+	if err != nil {
+		return nil, err
+	}
+	return &adHocProfile, nil
+}
+
 func (a *AdHocProfiles) getBucketFromContext(ctx context.Context) (objstore.Bucket, error) {
 	tenantID, err := tenant.TenantID(ctx)
 	if err != nil {
@@ -3,19 +3,26 @@ package adhocprofiles
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"connectrpc.com/connect"
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	thanosobjstore "github.com/thanos-io/objstore"
 
 	v1 "github.com/grafana/pyroscope/api/gen/proto/go/adhocprofiles/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	phlareobjstore "github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/tenant"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mockquerierv1connect"
 	"github.com/grafana/pyroscope/pkg/util"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
@@ -202,3 +209,136 @@ func TestAdHocProfiles_Upload(t *testing.T) {
 		})
 	}
 }
+
+func TestAdHocProfiles_Delete(t *testing.T) {
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	_ = bucket.Upload(context.Background(), "tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof", bytes.NewReader([]byte{1}))
+	a := &AdHocProfiles{
+		logger: util.Logger,
+		bucket: bucket,
+	}
+	ctx := tenant.InjectTenantID(context.Background(), "tenant")
+
+	_, err := a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: "01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof"}))
+	require.NoError(t, err)
+	exists, err := bucket.Exists(ctx, "tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: "01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof"}))
+	require.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+
+	_, err = a.Delete(ctx, connect.NewRequest(&v1.AdHocProfilesDeleteRequest{Id: "../other-tenant"}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAdHocProfiles_Diff(t *testing.T) {
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	rawProfile, err := os.ReadFile("testdata/cpu.pprof")
+	require.NoError(t, err)
+	jsonProfile, _ := json.Marshal(&AdHocProfile{
+		Name: "cpu.pprof",
+		Data: base64.StdEncoding.EncodeToString(rawProfile),
+	})
+	_ = bucket.Upload(context.Background(), "tenant/adhoc/01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof", bytes.NewReader(jsonProfile))
+
+	querier := mockquerierv1connect.NewMockQuerierServiceClient(t)
+	var live phlaremodel.Tree
+	live.InsertStack(100, "main", "foo")
+	querier.On("SelectMergeStacktraces", mock.Anything, mock.Anything).
+		Return(connect.NewResponse(&querierv1.SelectMergeStacktracesResponse{Tree: live.Bytes(-1)}), nil)
+
+	a := &AdHocProfiles{
+		logger:  util.Logger,
+		limits:  validation.MockLimits{MaxFlameGraphNodesDefaultValue: 8192},
+		bucket:  bucket,
+		querier: querier,
+	}
+	ctx := tenant.InjectTenantID(context.Background(), "tenant")
+	uploaded := &v1.AdHocProfilesDiffSource{Id: "01HMXV8BF4EH71NBYZNPPVGJ2X-cpu.pprof"}
+
+	resp, err := a.Diff(ctx, connect.NewRequest(&v1.AdHocProfilesDiffRequest{Left: uploaded, Right: uploaded}))
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Msg.Flamegraph.Names)
+	require.Equal(t, resp.Msg.Flamegraph.LeftTicks, resp.Msg.Flamegraph.RightTicks)
+
+	resp, err = a.Diff(ctx, connect.NewRequest(&v1.AdHocProfilesDiffRequest{
+		Left:  uploaded,
+		Right: &v1.AdHocProfilesDiffSource{Query: &querierv1.SelectMergeStacktracesRequest{LabelSelector: "{}"}},
+	}))
+	require.NoError(t, err)
+	require.Contains(t, resp.Msg.Flamegraph.Names, "foo")
+	require.Equal(t, int64(100), resp.Msg.Flamegraph.RightTicks)
+
+	_, err = a.Diff(ctx, connect.NewRequest(&v1.AdHocProfilesDiffRequest{Left: uploaded}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestAdHocProfiles_cleanup(t *testing.T) {
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	now := time.Now()
+	old := ulid.MustNew(ulid.Timestamp(now.Add(-48*time.Hour)), rand.Reader).String() + "-old.pprof"
+	recent := ulid.MustNew(ulid.Timestamp(now), rand.Reader).String() + "-recent.pprof"
+	for _, tenantID := range []string{"tenant-a", "tenant-b"} {
+		_ = bucket.Upload(context.Background(), tenantID+"/adhoc/"+old, bytes.NewReader([]byte{1}))
+		_ = bucket.Upload(context.Background(), tenantID+"/adhoc/"+recent, bytes.NewReader([]byte{1}))
+	}
+
+	a := &AdHocProfiles{
+		logger: util.Logger,
+		limits: retentionLimits{retention: map[string]time.Duration{"tenant-a": 24 * time.Hour}},
+		bucket: bucket,
+	}
+	a.cleanup(context.Background())
+
+	for _, tc := range []struct {
+		key    string
+		exists bool
+	}{
+		{key: "tenant-a/adhoc/" + old, exists: false},
+		{key: "tenant-a/adhoc/" + recent, exists: true},
+		{key: "tenant-b/adhoc/" + old, exists: true},
+		{key: "tenant-b/adhoc/" + recent, exists: true},
+	} {
+		exists, err := bucket.Exists(context.Background(), tc.key)
+		require.NoError(t, err)
+		require.Equal(t, tc.exists, exists, tc.key)
+	}
+}
+
+type retentionLimits struct {
+	validation.MockLimits
+	retention map[string]time.Duration
+}
+
+func (l retentionLimits) AdHocProfilesRetentionPeriod(tenantID string) time.Duration {
+	return l.retention[tenantID]
+}
+
+func TestAdHocProfiles_acquireCleanupLease(t *testing.T) {
+	bucket := phlareobjstore.NewBucket(thanosobjstore.NewInMemBucket())
+	now := time.Now()
+	clock := func() time.Time { return now }
+	newInstance := func(id string) *AdHocProfiles {
+		return &AdHocProfiles{
+			config: Config{CleanupInterval: time.Hour},
+			logger: util.Logger,
+			bucket: bucket,
+			id:     id,
+			now:    clock,
+		}
+	}
+	a, b := newInstance("a"), newInstance("b")
+	ctx := context.Background()
+
+	require.True(t, a.acquireCleanupLease(ctx))
+	require.False(t, b.acquireCleanupLease(ctx))
+	// The owner renews the lease.
+	now = now.Add(time.Hour)
+	require.True(t, a.acquireCleanupLease(ctx))
+	require.False(t, b.acquireCleanupLease(ctx))
+	// The lease expires, if not renewed.
+	now = now.Add(2*time.Hour + time.Second)
+	require.True(t, b.acquireCleanupLease(ctx))
+	require.False(t, a.acquireCleanupLease(ctx))
+}
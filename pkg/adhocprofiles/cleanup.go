@@ -0,0 +1,124 @@
+package adhocprofiles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log/level"
+)
+
+// cleanupLeaseName is the name of the object in the bucket root that
+// identifies the instance responsible for the cleanup.
+const cleanupLeaseName = "adhoc_profiles_cleanup_lease.json"
+
+type cleanupLease struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// acquireCleanupLease reports whether the instance owns the cleanup lease.
+// The lease is acquired if it is not held by another instance, or has
+// expired, and is renewed by the owner on every cleanup. The lease expires
+// after two cleanup intervals, if the owner stops renewing it.
+//
+// Object storage does not provide conditional writes: instances acquiring
+// the lease concurrently read it back, and only the last writer proceeds.
+// Although the check is best-effort, deleting expired profiles is safe to
+// be done by multiple instances.
+func (a *AdHocProfiles) acquireCleanupLease(ctx context.Context) bool {
+	now := a.now()
+	if lease, ok := a.readCleanupLease(ctx); ok && lease.Owner != a.id && now.Before(lease.ExpiresAt) {
+		return false
+	}
+	b, err := json.Marshal(cleanupLease{
+		Owner:     a.id,
+		ExpiresAt: now.Add(2 * a.config.CleanupInterval),
+	})
+	if err != nil {
+		return false
+	}
+	if err = a.bucket.Upload(ctx, cleanupLeaseName, bytes.NewReader(b)); err != nil {
+		level.Warn(a.logger).Log("msg", "failed to acquire ad hoc profiles cleanup lease", "err", err)
+		return false
+	}
+	lease, ok := a.readCleanupLease(ctx)
+	return ok && lease.Owner == a.id
+}
+
+func (a *AdHocProfiles) readCleanupLease(ctx context.Context) (lease cleanupLease, ok bool) {
+	r, err := a.bucket.Get(ctx, cleanupLeaseName)
+	if err != nil {
+		if !a.bucket.IsObjNotFoundErr(err) {
+			level.Warn(a.logger).Log("msg", "failed to read ad hoc profiles cleanup lease", "err", err)
+		}
+		return lease, false
+	}
+	defer r.Close()
+	if err = json.NewDecoder(r).Decode(&lease); err != nil {
+		level.Warn(a.logger).Log("msg", "failed to decode ad hoc profiles cleanup lease", "err", err)
+		return lease, false
+	}
+	return lease, true
+}
+
+// cleanup deletes uploaded profiles exceeding the tenant retention period.
+func (a *AdHocProfiles) cleanup(ctx context.Context) {
+	var tenants []string
+	err := a.bucket.Iter(ctx, "", func(s string) error {
+		if tenantID, ok := strings.CutSuffix(s, "/"); ok {
+			tenants = append(tenants, tenantID)
+		}
+		return nil
+	})
+	if err != nil {
+		level.Warn(a.logger).Log("msg", "failed to list tenants", "err", err)
+		return
+	}
+	now := time.Now()
+	for _, tenantID := range tenants {
+		if ctx.Err() != nil {
+			return
+		}
+		retention := a.limits.AdHocProfilesRetentionPeriod(tenantID)
+		if retention <= 0 {
+			continue
+		}
+		if err = a.deleteExpired(ctx, tenantID, now.Add(-retention)); err != nil {
+			level.Warn(a.logger).Log("msg", "failed to delete expired ad hoc profiles", "tenant", tenantID, "err", err)
+		}
+	}
+}
+
+// deleteExpired deletes the tenant profiles uploaded before the given time.
+func (a *AdHocProfiles) deleteExpired(ctx context.Context, tenantID string, before time.Time) error {
+	bucket := a.getBucket(tenantID)
+	var expired []string
+	err := bucket.Iter(ctx, "", func(s string) error {
+		if !validID(s) {
+			return nil
+		}
+		id, _, err := parseID(s)
+		if err != nil {
+			return nil
+		}
+		if uploadedAt := time.UnixMilli(int64(id.Time())); uploadedAt.Before(before) {
+			expired = append(expired, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, s := range expired {
+		if err = bucket.Delete(ctx, s); err != nil {
+			return err
+		}
+	}
+	if len(expired) > 0 {
+		level.Info(a.logger).Log("msg", "deleted expired ad hoc profiles", "tenant", tenantID, "count", len(expired))
+	}
+	return nil
+}
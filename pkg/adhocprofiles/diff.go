@@ -0,0 +1,101 @@
+package adhocprofiles
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	v1 "github.com/grafana/pyroscope/api/gen/proto/go/adhocprofiles/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (a *AdHocProfiles) Diff(ctx context.Context, c *connect.Request[v1.AdHocProfilesDiffRequest]) (*connect.Response[v1.AdHocProfilesDiffResponse], error) {
+	tenantID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	maxNodes, err := validation.ValidateMaxNodes(a.limits, []string{tenantID}, c.Msg.GetMaxNodes())
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not determine max nodes")
+	}
+
+	var left, right *phlaremodel.Tree
+	g, ctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		left, err = a.diffTree(ctx, tenantID, c.Msg.Left)
+		return errors.Wrap(err, "left")
+	})
+	g.Go(func() (err error) {
+		right, err = a.diffTree(ctx, tenantID, c.Msg.Right)
+		return errors.Wrap(err, "right")
+	})
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+
+	diff, err := phlaremodel.NewFlamegraphDiff(left, right, maxNodes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&v1.AdHocProfilesDiffResponse{Flamegraph: diff}), nil
+}
+
+// diffTree returns the tree of the uploaded profile or of the query result.
+func (a *AdHocProfiles) diffTree(ctx context.Context, tenantID string, source *v1.AdHocProfilesDiffSource) (*phlaremodel.Tree, error) {
+	switch {
+	case source == nil || (source.Id == "" && source.Query == nil):
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("either a profile id or a query is required"))
+	case source.Id != "" && source.Query != nil:
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("profile id and query are mutually exclusive"))
+	case source.Query != nil:
+		return a.queryTree(ctx, source.Query)
+	}
+
+	adHocProfile, err := a.load(ctx, tenantID, source.Id)
+	if err != nil {
+		return nil, err
+	}
+	// The profile is not truncated: the diff
+	// is truncated consistently for both sides.
+	profile, _, err := parse(adHocProfile, source.ProfileType, -1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse profile")
+	}
+	return treeFromFlamebearer(profile)
+}
+
+func (a *AdHocProfiles) queryTree(ctx context.Context, query *querierv1.SelectMergeStacktracesRequest) (*phlaremodel.Tree, error) {
+	if a.querier == nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("querying the profile store is not configured"))
+	}
+	req := query.CloneVT()
+	req.Format = querierv1.ProfileFormat_PROFILE_FORMAT_TREE
+	resp, err := a.querier.SelectMergeStacktraces(ctx, connect.NewRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	return phlaremodel.UnmarshalTree(resp.Msg.Tree)
+}
+
+func treeFromFlamebearer(profile *flamebearer.FlamebearerProfile) (*phlaremodel.Tree, error) {
+	t, err := flamebearer.ProfileToTree(*profile)
+	if err != nil {
+		return nil, err
+	}
+	tree := new(phlaremodel.Tree)
+	t.IterateStacks(func(_ string, self uint64, stack []string) {
+		// The stack is ordered from the leaf to the root.
+		slices.Reverse(stack)
+		tree.InsertStack(int64(self), stack...)
+	})
+	return tree, nil
+}
@@ -6,8 +6,10 @@ import (
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	segmentwriterv1 "github.com/grafana/pyroscope/api/gen/proto/go/segmentwriter/v1"
 	metastoreadmin "github.com/grafana/pyroscope/pkg/metastore/admin"
+	"github.com/grafana/pyroscope/pkg/metrics"
 	"github.com/grafana/pyroscope/pkg/querybackend"
 	"github.com/grafana/pyroscope/pkg/segmentwriter"
+	"github.com/grafana/pyroscope/pkg/symbolizer"
 )
 
 // TODO(kolesnikovae): Recovery interceptor.
@@ -28,6 +30,18 @@ func (a *API) RegisterQueryBackend(svc *querybackend.QueryBackend) {
 	queryv1.RegisterQueryBackendServiceServer(a.server.GRPC, svc)
 }
 
+// RegisterRecordingRulesBackfill registers the endpoint evaluating
+// recording rules over the existing blocks.
+func (a *API) RegisterRecordingRulesBackfill(b *metrics.Backfiller) {
+	a.RegisterRoute("/compaction-worker/recording-rules/backfill", b, a.WithAuthMiddleware(), WithMethod("POST"))
+}
+
+// RegisterSymbolizer registers the endpoint uploading debug
+// info of a build ID to the symbolizer object store.
+func (a *API) RegisterSymbolizer(s *symbolizer.Symbolizer) {
+	a.RegisterRoute("/symbolizer/debuginfo/{build_id}", s.UploadHandler(), a.WithAuthMiddleware(), WithMethod("PUT"))
+}
+
 func (a *API) RegisterMetastoreAdmin(adm *metastoreadmin.Admin) {
 	a.RegisterRoute("/metastore-nodes", adm.NodeListHandler(), a.registerOptionsRingPage()...)
 	a.RegisterRoute("/metastore-client-test", adm.ClientTestHandler(), a.registerOptionsRingPage()...)
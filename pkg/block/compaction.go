@@ -6,11 +6,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
+	"github.com/go-kit/log"
 	"github.com/grafana/dskit/multierror"
 	"github.com/parquet-go/parquet-go"
 	"github.com/prometheus/common/model"
@@ -21,6 +24,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/block/metadata"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/phlaredb/downsample"
 	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/phlaredb/tsdb/index"
 	memindex "github.com/grafana/pyroscope/pkg/segmentwriter/memdb/index"
@@ -58,12 +62,42 @@ func WithSampleObserver(observer SampleObserver) CompactionOption {
 	}
 }
 
+// WithDownsampling enables creation of downsampled copies of the datasets
+// in blocks of the given compaction level and above. The downsampled
+// datasets are not compacted: they are rebuilt from the raw data.
+func WithDownsampling(minLevel uint32) CompactionOption {
+	return func(p *compactionConfig) {
+		p.downsamplingMinLevel = minLevel
+	}
+}
+
+// WithDatasetTombstones deletes the profiles specified in the tombstones
+// from the source blocks: the profiles are not written to the compacted
+// block, and datasets with no profiles left are removed.
+func WithDatasetTombstones(tombstones ...*metastorev1.DatasetTombstones) CompactionOption {
+	return func(p *compactionConfig) {
+		p.tombstones = append(p.tombstones, tombstones...)
+	}
+}
+
+// WithSeriesTombstones deletes the profiles of the series matching
+// the tombstone selectors within the tombstone time ranges.
+func WithSeriesTombstones(tombstones ...*metastorev1.SeriesTombstone) CompactionOption {
+	return func(p *compactionConfig) {
+		p.series = append(p.series, tombstones...)
+	}
+}
+
 type compactionConfig struct {
-	objectOptions  []ObjectOption
-	source         objstore.BucketReader
-	destination    objstore.Bucket
-	tempdir        string
-	sampleObserver SampleObserver
+	objectOptions        []ObjectOption
+	source               objstore.BucketReader
+	destination          objstore.Bucket
+	tempdir              string
+	sampleObserver       SampleObserver
+	symbolizer           Symbolizer
+	downsamplingMinLevel uint32
+	tombstones           []*metastorev1.DatasetTombstones
+	series               []*metastorev1.SeriesTombstone
 }
 
 type SampleObserver interface {
@@ -91,7 +125,11 @@ func Compact(
 	}
 
 	objects := ObjectsFromMetas(storage, blocks, c.objectOptions...)
-	plan, err := PlanCompaction(objects)
+	deleted, err := newDeletedProfiles(c.tombstones, c.series)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := planCompaction(objects, deleted)
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +143,8 @@ func Compact(
 
 	compacted := make([]*metastorev1.BlockMeta, 0, len(plan))
 	for _, p := range plan {
+		p.symbolizer = c.symbolizer
+		p.downsample = c.downsamplingMinLevel > 0 && p.meta.CompactionLevel >= c.downsamplingMinLevel
 		md, compactionErr := p.Compact(ctx, c.destination, c.tempdir, c.sampleObserver)
 		if compactionErr != nil {
 			return nil, compactionErr
@@ -115,7 +155,48 @@ func Compact(
 	return compacted, nil
 }
 
+// Observe evaluates profiles of the dataset within the time range
+// [start, end) with the observer, the same way it is done at compaction.
+// The symbols are rewritten in memory for the observer to resolve stack
+// traces; no output is produced.
+func Observe(ctx context.Context, ds *Dataset, observer SampleObserver, start, end int64) (err error) {
+	if err = ds.Open(ctx, SectionProfiles, SectionTSDB, SectionSymbols); err != nil {
+		return fmt.Errorf("opening dataset: %w", err)
+	}
+	// The iterator closes the dataset.
+	rows, err := NewProfileRowIterator(ds)
+	if err != nil {
+		return multierror.New(err, ds.Close()).Err()
+	}
+	defer func() {
+		err = multierror.New(err, rows.Close()).Err()
+	}()
+	symbols := newSymbolsRewriter(observer)
+	var i int
+	for rows.Next() {
+		if i++; i%1000 == 0 {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+		}
+		r := rows.At()
+		if r.Timestamp < start || r.Timestamp >= end {
+			continue
+		}
+		observe := observer.Evaluate(r)
+		if err = symbols.rewriteRow(r); err != nil {
+			return err
+		}
+		observe()
+	}
+	return rows.Err()
+}
+
 func PlanCompaction(objects Objects) ([]*CompactionPlan, error) {
+	return planCompaction(objects, nil)
+}
+
+func planCompaction(objects Objects, deleted *deletedProfiles) ([]*CompactionPlan, error) {
 	if len(objects) == 0 {
 		// Even if there's just a single object, we still need to rewrite it.
 		return nil, ErrNoBlocksToMerge
@@ -135,9 +216,13 @@ func PlanCompaction(objects Objects) ([]*CompactionPlan, error) {
 	m := make(map[string]*CompactionPlan)
 	for _, obj := range objects {
 		for _, ds := range obj.meta.Datasets {
-			if ds.Name == 0 {
-				// Anonymous dataset is never compacted:
-				// it is rebuilt based on the actual block contents.
+			if ds.Name == 0 || DatasetFormat(ds.Format) == DatasetFormat2 {
+				// Anonymous and downsampled datasets are never compacted:
+				// they are rebuilt based on the actual block contents.
+				continue
+			}
+			if ds = deleted.filterDataset(obj.meta, ds); ds == nil {
+				// All the dataset profiles are deleted.
 				continue
 			}
 			tm, ok := m[obj.meta.StringTable[ds.Tenant]]
@@ -148,6 +233,7 @@ func PlanCompaction(objects Objects) ([]*CompactionPlan, error) {
 					r.meta.Shard,
 					level,
 				)
+				tm.deleted = deleted
 				m[obj.meta.StringTable[ds.Tenant]] = tm
 			}
 			// Bind objects to datasets.
@@ -178,6 +264,9 @@ type CompactionPlan struct {
 	meta         *metastorev1.BlockMeta
 	strings      *metadata.StringTable
 	datasetIndex *datasetIndexWriter
+	symbolizer   Symbolizer
+	downsample   bool
+	deleted      *deletedProfiles
 }
 
 func newBlockCompaction(
@@ -221,6 +310,10 @@ func (b *CompactionPlan) Compact(
 	for i, s := range b.datasets {
 		b.datasetIndex.setIndex(uint32(i))
 		s.registerSampleObserver(observer)
+		s.symbolizer = b.symbolizer
+		if b.downsample {
+			s.downsampleDir = filepath.Join(tempdir, "downsample", strconv.Itoa(i))
+		}
 		if err = s.compact(ctx, w); err != nil {
 			return nil, fmt.Errorf("compacting block: %w", err)
 		}
@@ -229,6 +322,11 @@ func (b *CompactionPlan) Compact(
 	if err = b.writeDatasetIndex(w); err != nil {
 		return nil, fmt.Errorf("writing tenant index: %w", err)
 	}
+	// Downsampled datasets are listed after the dataset index
+	// so that the dataset IDs in the index are not affected.
+	for _, s := range b.datasets {
+		b.meta.Datasets = append(b.meta.Datasets, s.downsampled...)
+	}
 	b.meta.StringTable = b.strings.Strings
 	b.meta.MetadataOffset = w.Offset()
 	if err = metadata.Encode(w, b.meta); err != nil {
@@ -306,7 +404,13 @@ type datasetCompaction struct {
 
 	flushOnce sync.Once
 
-	observer SampleObserver
+	observer   SampleObserver
+	symbolizer Symbolizer
+
+	// Downsampling is enabled if the directory is set.
+	downsampleDir string
+	downsampler   *downsample.Downsampler
+	downsampled   []*metastorev1.Dataset
 }
 
 func (b *CompactionPlan) newDatasetCompaction(tenant, name int32) *datasetCompaction {
@@ -369,9 +473,62 @@ func (m *datasetCompaction) compact(ctx context.Context, w *Writer) (err error)
 
 	m.meta.Size = w.Offset() - off
 	m.meta.Labels = m.labels.Build()
+	if m.symbolizer != nil && !m.symbolsRewriter.incomplete {
+		// All the locations have been symbolized.
+		name := m.parent.strings.LookupString(metadata.LabelNameUnsymbolized)
+		m.meta.Labels = deleteLabelSets(m.meta.Labels, name)
+	}
+	if m.downsampler != nil {
+		if err = m.writeDownsampled(w, off); err != nil {
+			return fmt.Errorf("failed to write downsampled profiles: %w", err)
+		}
+	}
 	return nil
 }
 
+// writeDownsampled appends the downsampled profile tables to the dataset
+// sections. A downsampled dataset shares the tsdb index and symbols with
+// the source dataset; see DatasetFormat2.
+func (m *datasetCompaction) writeDownsampled(w *Writer, off uint64) error {
+	symbolsEnd := w.Offset()
+	for _, out := range m.downsampler.Outputs() {
+		profilesOffset := w.Offset()
+		if err := copyFile(w, out.Path); err != nil {
+			return err
+		}
+		m.downsampled = append(m.downsampled, &metastorev1.Dataset{
+			Format:  uint32(DatasetFormat2),
+			Tenant:  m.meta.Tenant,
+			Name:    m.meta.Name,
+			MinTime: m.meta.MinTime,
+			MaxTime: m.meta.MaxTime,
+			TableOfContents: []uint64{
+				off,
+				m.meta.TableOfContents[1],
+				m.meta.TableOfContents[2],
+				symbolsEnd,
+				profilesOffset,
+			},
+			Size:       w.Offset() - off,
+			Labels:     m.meta.Labels,
+			Resolution: out.Resolution.Milliseconds(),
+		})
+	}
+	return nil
+}
+
+func copyFile(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	_, err = io.Copy(w, f)
+	return err
+}
+
 func (m *datasetCompaction) registerSampleObserver(observer SampleObserver) {
 	m.observer = observer
 }
@@ -386,6 +543,18 @@ func (m *datasetCompaction) open(ctx context.Context, w io.Writer) (err error) {
 
 	m.indexRewriter = newIndexRewriter()
 	m.symbolsRewriter = newSymbolsRewriter(m.observer)
+	if m.downsampleDir != "" {
+		if err = os.MkdirAll(m.downsampleDir, 0o755); err != nil {
+			return err
+		}
+		if m.downsampler, err = downsample.NewDownsampler(m.downsampleDir, log.NewNopLogger()); err != nil {
+			return err
+		}
+	}
+	if m.symbolizer != nil {
+		m.symbolsRewriter.ctx = ctx
+		m.symbolsRewriter.symbolizer = m.symbolizer
+	}
 
 	g, ctx := errgroup.WithContext(ctx)
 	for _, s := range m.datasets {
@@ -434,6 +603,9 @@ func (m *datasetCompaction) merge(ctx context.Context) (err error) {
 }
 
 func (m *datasetCompaction) writeRow(r ProfileEntry) (err error) {
+	if m.parent.deleted.deletedEntry(r) {
+		return nil
+	}
 	if m.observer != nil {
 		observe := m.observer.Evaluate(r)
 		defer observe()
@@ -443,7 +615,13 @@ func (m *datasetCompaction) writeRow(r ProfileEntry) (err error) {
 	if err = m.symbolsRewriter.rewriteRow(r); err != nil {
 		return err
 	}
-	return m.profilesWriter.writeRow(r)
+	if err = m.profilesWriter.writeRow(r); err != nil {
+		return err
+	}
+	if m.downsampler != nil {
+		return m.downsampler.AddRow(r.Row, r.Fingerprint)
+	}
+	return nil
 }
 
 func (m *datasetCompaction) flush() (err error) {
@@ -452,6 +630,9 @@ func (m *datasetCompaction) flush() (err error) {
 		merr.Add(m.symbolsRewriter.Flush())
 		merr.Add(m.indexRewriter.Flush())
 		merr.Add(m.profilesWriter.Close())
+		if m.downsampler != nil {
+			merr.Add(m.downsampler.Close())
+		}
 		m.samples = m.symbolsRewriter.samples
 		m.series = m.indexRewriter.NumSeries()
 		m.profiles = m.profilesWriter.profiles
@@ -465,7 +646,11 @@ func (m *datasetCompaction) close() error {
 	m.symbolsRewriter = nil
 	m.indexRewriter = nil
 	m.profilesWriter = nil
+	m.downsampler = nil
 	m.datasets = nil
+	if m.downsampleDir != "" {
+		_ = os.RemoveAll(m.downsampleDir)
+	}
 	return err
 }
 
@@ -554,6 +739,10 @@ type symbolsRewriter struct {
 	samples  uint64
 	observer SampleObserver
 
+	ctx        context.Context
+	symbolizer Symbolizer
+	incomplete bool
+
 	stacktraces []uint32
 }
 
@@ -595,7 +784,16 @@ func (s *symbolsRewriter) rewriteRow(e ProfileEntry) (err error) {
 func (s *symbolsRewriter) rewriterFor(x *Dataset) *symdb.Rewriter {
 	rw, ok := s.rw[x]
 	if !ok {
-		rw = symdb.NewRewriter(s.w, x.Symbols(), s.observer)
+		src := x.Symbols()
+		if s.symbolizer != nil {
+			src = &symbolizingReader{
+				SymbolsReader: src,
+				ctx:           s.ctx,
+				symbolizer:    s.symbolizer,
+				incomplete:    &s.incomplete,
+			}
+		}
+		rw = symdb.NewRewriter(s.w, src, s.observer)
 		s.rw[x] = rw
 	}
 	return rw
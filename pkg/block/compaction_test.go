@@ -3,8 +3,10 @@ package block
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,7 +14,11 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/objstore/testutil"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 )
 
 func Test_CompactBlocks(t *testing.T) {
@@ -60,3 +66,270 @@ func Test_CompactBlocks(t *testing.T) {
 		require.Len(t, compactedBlocks[0].Datasets, 4)
 	})
 }
+
+func Test_CompactBlocks_Downsampling(t *testing.T) {
+	ctx := context.Background()
+	bucket, _ := testutil.NewFilesystemBucket(t, ctx, "testdata")
+
+	var resp metastorev1.GetBlockMetadataResponse
+	raw, err := os.ReadFile("testdata/block-metas.json")
+	require.NoError(t, err)
+	err = protojson.Unmarshal(raw, &resp)
+	require.NoError(t, err)
+
+	dst, tempdir := testutil.NewFilesystemBucket(t, ctx, t.TempDir())
+	options := []CompactionOption{
+		WithCompactionDestination(dst),
+		WithCompactionTempDir(tempdir),
+		WithDownsampling(1),
+		WithCompactionObjectOptions(
+			WithObjectDownload(filepath.Join(tempdir, "source")),
+			WithObjectMaxSizeLoadInMemory(0)), // Force download.
+	}
+
+	compactedBlocks, err := Compact(ctx, resp.Blocks, bucket, options...)
+	require.NoError(t, err)
+	require.Len(t, compactedBlocks, 1)
+	// The dataset index and 3 datasets, each with 2 downsampled datasets.
+	require.Len(t, compactedBlocks[0].Datasets, 10)
+	assertDownsampledDatasets(t, ctx, dst, compactedBlocks[0])
+
+	t.Run("Compact compacted blocks", func(t *testing.T) {
+		compactedBlocks, err = Compact(ctx, compactedBlocks, dst, options...)
+		require.NoError(t, err)
+		require.Len(t, compactedBlocks, 1)
+		require.Len(t, compactedBlocks[0].Datasets, 10)
+		assertDownsampledDatasets(t, ctx, dst, compactedBlocks[0])
+	})
+}
+
+// assertDownsampledDatasets checks that the downsampled datasets
+// retain the total value of the profiles of the source dataset.
+func assertDownsampledDatasets(t *testing.T, ctx context.Context, bucket objstore.Bucket, md *metastorev1.BlockMeta) {
+	obj := NewObject(bucket, md)
+	require.NoError(t, obj.Open(ctx))
+	defer obj.Close()
+
+	totals := make(map[int32]uint64)
+	resolutions := make(map[int32][]int64)
+	for _, ds := range md.Datasets {
+		if ds.Name == 0 {
+			continue
+		}
+		dataset := NewDataset(ds, obj)
+		require.NoError(t, dataset.Open(ctx, SectionProfiles, SectionTSDB, SectionSymbols))
+		total := totalValue(t, ctx, dataset)
+		require.NoError(t, dataset.Close())
+		if ds.Format != uint32(DatasetFormat2) {
+			require.Zero(t, ds.Resolution)
+			require.NotZero(t, total)
+			totals[ds.Name] = total
+			continue
+		}
+		resolutions[ds.Name] = append(resolutions[ds.Name], ds.Resolution)
+		assert.Equal(t, totals[ds.Name], total)
+	}
+	require.Len(t, resolutions, 3)
+	for _, r := range resolutions {
+		assert.ElementsMatch(t, []int64{300000, 3600000}, r)
+	}
+}
+
+func totalValue(t *testing.T, ctx context.Context, ds *Dataset) (total uint64) {
+	it := ds.Profiles().Column(ctx, schemav1.TotalValueColumnName, nil)
+	defer it.Close()
+	for it.Next() {
+		for _, e := range it.At().Entries {
+			total += e.V.Uint64()
+		}
+	}
+	require.NoError(t, it.Err())
+	return total
+}
+
+func Test_CompactBlocks_DatasetTombstones(t *testing.T) {
+	ctx := context.Background()
+	bucket, _ := testutil.NewFilesystemBucket(t, ctx, "testdata")
+
+	var resp metastorev1.GetBlockMetadataResponse
+	raw, err := os.ReadFile("testdata/block-metas.json")
+	require.NoError(t, err)
+	err = protojson.Unmarshal(raw, &resp)
+	require.NoError(t, err)
+
+	const (
+		deletedService = "pyroscope-test/alloy"
+		partialService = "pyroscope-test/ingester"
+	)
+	// The source block metadata does not include dataset labels:
+	// we add them as they would be created by the segment writer.
+	profiles := make(map[string][]deletedProfilesKey)
+	for _, md := range resp.Blocks {
+		profiles[md.Id] = addProfileLabels(t, ctx, bucket, md)
+	}
+	var partialType string
+	for _, keys := range profiles {
+		for _, k := range keys {
+			if k.serviceName == partialService && (partialType == "" || k.profileType < partialType) {
+				partialType = k.profileType
+			}
+		}
+	}
+	require.NotEmpty(t, partialType)
+
+	tombstones := make([]*metastorev1.DatasetTombstones, 0, len(resp.Blocks))
+	for _, md := range resp.Blocks {
+		t := &metastorev1.DatasetTombstones{Blocks: []string{md.Id}}
+		for _, k := range profiles[md.Id] {
+			if k.serviceName == deletedService || (k.serviceName == partialService && k.profileType == partialType) {
+				t.Labels = append(t.Labels, &typesv1.Labels{Labels: []*typesv1.LabelPair{
+					{Name: phlaremodel.LabelNameServiceName, Value: k.serviceName},
+					{Name: phlaremodel.LabelNameProfileType, Value: k.profileType},
+				}})
+			}
+		}
+		tombstones = append(tombstones, t)
+	}
+
+	dst, tempdir := testutil.NewFilesystemBucket(t, ctx, t.TempDir())
+	compactedBlocks, err := Compact(ctx, resp.Blocks, bucket,
+		WithCompactionDestination(dst),
+		WithCompactionTempDir(tempdir),
+		WithDatasetTombstones(tombstones...),
+		WithCompactionObjectOptions(
+			WithObjectDownload(filepath.Join(tempdir, "source")),
+			WithObjectMaxSizeLoadInMemory(0)), // Force download.
+	)
+	require.NoError(t, err)
+	require.Len(t, compactedBlocks, 1)
+	// The dataset index and 2 datasets.
+	md := compactedBlocks[0]
+	require.Len(t, md.Datasets, 3)
+
+	var found bool
+	for _, k := range readProfileKeys(t, ctx, dst, md) {
+		assert.NotEqual(t, deletedService, k.serviceName)
+		if k.serviceName == partialService {
+			assert.NotEqual(t, partialType, k.profileType)
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func Test_CompactBlocks_SeriesTombstones(t *testing.T) {
+	ctx := context.Background()
+	bucket, _ := testutil.NewFilesystemBucket(t, ctx, "testdata")
+
+	var resp metastorev1.GetBlockMetadataResponse
+	raw, err := os.ReadFile("testdata/block-metas.json")
+	require.NoError(t, err)
+	err = protojson.Unmarshal(raw, &resp)
+	require.NoError(t, err)
+
+	const deletedService = "pyroscope-test/alloy"
+	var sourceKeys []deletedProfilesKey
+	for _, md := range resp.Blocks {
+		for _, k := range readProfileKeys(t, ctx, bucket, md) {
+			if !slices.Contains(sourceKeys, k) {
+				sourceKeys = append(sourceKeys, k)
+			}
+		}
+	}
+
+	dst, tempdir := testutil.NewFilesystemBucket(t, ctx, t.TempDir())
+	compactedBlocks, err := Compact(ctx, resp.Blocks, bucket,
+		WithCompactionDestination(dst),
+		WithCompactionTempDir(tempdir),
+		WithSeriesTombstones(
+			&metastorev1.SeriesTombstone{
+				LabelSelector: `{service_name="` + deletedService + `"}`,
+				StartTime:     0,
+				EndTime:       math.MaxInt32 * 1000,
+			},
+			// The time range does not include any profiles.
+			&metastorev1.SeriesTombstone{
+				LabelSelector: `{service_name=~".+"}`,
+				StartTime:     0,
+				EndTime:       1,
+			},
+		),
+		WithCompactionObjectOptions(
+			WithObjectDownload(filepath.Join(tempdir, "source")),
+			WithObjectMaxSizeLoadInMemory(0)), // Force download.
+	)
+	require.NoError(t, err)
+	require.Len(t, compactedBlocks, 1)
+
+	var expected []deletedProfilesKey
+	for _, k := range sourceKeys {
+		if k.serviceName != deletedService {
+			expected = append(expected, k)
+		}
+	}
+	require.NotEqual(t, len(sourceKeys), len(expected))
+	assert.ElementsMatch(t, expected, readProfileKeys(t, ctx, dst, compactedBlocks[0]))
+}
+
+func Test_CompactBlocks_SeriesTombstones_InvalidSelector(t *testing.T) {
+	_, err := Compact(context.Background(), nil, nil,
+		WithSeriesTombstones(&metastorev1.SeriesTombstone{LabelSelector: "{"}))
+	require.Error(t, err)
+}
+
+// addProfileLabels sets the dataset labels to the service name
+// and profile type pairs of the dataset profiles.
+func addProfileLabels(t *testing.T, ctx context.Context, bucket objstore.Bucket, md *metastorev1.BlockMeta) []deletedProfilesKey {
+	keys := readProfileKeys(t, ctx, bucket, md)
+	lookup := func(s string) int32 {
+		if i := slices.Index(md.StringTable, s); i >= 0 {
+			return int32(i)
+		}
+		md.StringTable = append(md.StringTable, s)
+		return int32(len(md.StringTable) - 1)
+	}
+	for _, ds := range md.Datasets {
+		ds.Labels = ds.Labels[:0]
+		for _, k := range keys {
+			if md.StringTable[ds.Name] != k.serviceName {
+				continue
+			}
+			ds.Labels = append(ds.Labels, 2,
+				lookup(phlaremodel.LabelNameServiceName), lookup(k.serviceName),
+				lookup(phlaremodel.LabelNameProfileType), lookup(k.profileType),
+			)
+		}
+	}
+	return keys
+}
+
+func readProfileKeys(t *testing.T, ctx context.Context, bucket objstore.Bucket, md *metastorev1.BlockMeta) []deletedProfilesKey {
+	obj := NewObject(bucket, md)
+	require.NoError(t, obj.Open(ctx))
+	defer obj.Close()
+
+	var keys []deletedProfilesKey
+	for _, ds := range md.Datasets {
+		if ds.Name == 0 {
+			continue
+		}
+		dataset := NewDataset(ds, obj)
+		require.NoError(t, dataset.Open(ctx, SectionProfiles, SectionTSDB))
+		it, err := NewProfileRowIterator(dataset)
+		require.NoError(t, err)
+		for it.Next() {
+			e := it.At()
+			k := deletedProfilesKey{
+				serviceName: e.Labels.Get(phlaremodel.LabelNameServiceName),
+				profileType: e.Labels.Get(phlaremodel.LabelNameProfileType),
+			}
+			if !slices.Contains(keys, k) {
+				keys = append(keys, k)
+			}
+		}
+		require.NoError(t, it.Err())
+		// Closes the dataset.
+		require.NoError(t, it.Close())
+	}
+	return keys
+}
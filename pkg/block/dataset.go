@@ -23,6 +23,7 @@ type DatasetFormat uint32
 const (
 	DatasetFormat0 DatasetFormat = iota
 	DatasetFormat1
+	DatasetFormat2
 )
 
 type Section uint32
@@ -57,6 +58,14 @@ var (
 			SectionDatasetIndex: sectionDesc{index: 0, name: "dataset_tsdb_index"},
 			SectionTSDB:         sectionDesc{index: 0, name: "dataset_tsdb_index"},
 		},
+		DatasetFormat2: {
+			// The downsampled dataset shares the tsdb index and symbols
+			// with the source dataset. The entry 3 denotes the end of the
+			// symbols section, and the entry 0 is the source dataset offset.
+			SectionProfiles: sectionDesc{index: 4, name: "downsampled_profiles"},
+			SectionTSDB:     sectionDesc{index: 1, name: "tsdb"},
+			SectionSymbols:  sectionDesc{index: 2, name: "symbols"},
+		},
 	}
 )
 
@@ -34,6 +34,29 @@ func Sanitize(md *metastorev1.BlockMeta) error {
 	return err
 }
 
+type datasetKey struct{ tenant, name int32 }
+
+// ResolutionFilter returns a predicate that selects a single copy of
+// each dataset: the one downsampled to the coarsest resolution that
+// does not exceed the step (in milliseconds), or the raw dataset, if
+// there is no such copy. The datasets must share the string table.
+func ResolutionFilter(datasets []*metastorev1.Dataset, step int64) func(*metastorev1.Dataset) bool {
+	var selected map[datasetKey]int64
+	for _, ds := range datasets {
+		if ds.Resolution == 0 || ds.Resolution > step {
+			continue
+		}
+		if selected == nil {
+			selected = make(map[datasetKey]int64)
+		}
+		k := datasetKey{tenant: ds.Tenant, name: ds.Name}
+		selected[k] = max(selected[k], ds.Resolution)
+	}
+	return func(ds *metastorev1.Dataset) bool {
+		return ds.Resolution == selected[datasetKey{tenant: ds.Tenant, name: ds.Name}]
+	}
+}
+
 var stringTablePool = sync.Pool{
 	New: func() any { return NewStringTable() },
 }
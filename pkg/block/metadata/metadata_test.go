@@ -240,3 +240,28 @@ func TestMetadata_EncodeDecode(t *testing.T) {
 	require.NoError(t, Decode(raw, &d))
 	assert.Equal(t, md, &d)
 }
+
+func TestMetadata_ResolutionFilter(t *testing.T) {
+	datasets := []*metastorev1.Dataset{
+		{Tenant: 1, Name: 2},
+		{Tenant: 1, Name: 3},
+		{Tenant: 1, Name: 0, Format: 1},
+		{Tenant: 1, Name: 2, Format: 2, Resolution: 300000},
+		{Tenant: 1, Name: 2, Format: 2, Resolution: 3600000},
+	}
+	selected := func(step int64) []int {
+		var s []int
+		f := ResolutionFilter(datasets, step)
+		for i, ds := range datasets {
+			if f(ds) {
+				s = append(s, i)
+			}
+		}
+		return s
+	}
+	assert.Equal(t, []int{0, 1, 2}, selected(0))
+	assert.Equal(t, []int{0, 1, 2}, selected(60000))
+	assert.Equal(t, []int{1, 2, 3}, selected(300000))
+	assert.Equal(t, []int{1, 2, 3}, selected(1800000))
+	assert.Equal(t, []int{1, 2, 4}, selected(86400000))
+}
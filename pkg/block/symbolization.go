@@ -0,0 +1,123 @@
+package block
+
+import (
+	"context"
+
+	"github.com/grafana/pyroscope/pkg/block/metadata"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+// Symbolizer resolves locations that have not been symbolized at
+// ingestion, e.g., native frames of profiles collected without debug
+// info.
+type Symbolizer interface {
+	// SymbolizeSymbols resolves the unsymbolized locations in place,
+	// and reports whether all of them have been resolved.
+	SymbolizeSymbols(ctx context.Context, symbols *symdb.Symbols) (bool, error)
+}
+
+// WithSymbolizer makes the compaction resolve unsymbolized locations.
+// If all the locations of a dataset have been resolved, the compacted
+// dataset is not labeled as unsymbolized.
+func WithSymbolizer(symbolizer Symbolizer) CompactionOption {
+	return func(p *compactionConfig) {
+		p.symbolizer = symbolizer
+	}
+}
+
+// symbolizingReader symbolizes partitions of the source
+// dataset before they are rewritten.
+type symbolizingReader struct {
+	symdb.SymbolsReader
+	ctx        context.Context
+	symbolizer Symbolizer
+	// Set if any of the partitions has not been fully symbolized.
+	incomplete *bool
+}
+
+func (r *symbolizingReader) Partition(ctx context.Context, partition uint64) (symdb.PartitionReader, error) {
+	p, err := r.SymbolsReader.Partition(ctx, partition)
+	if err != nil {
+		return nil, err
+	}
+	symbols := p.Symbols()
+	if !hasUnsymbolizedLocations(symbols) {
+		return p, nil
+	}
+	// The source symbols must not be modified.
+	symbolized := cloneSymbols(symbols)
+	complete, err := r.symbolizer.SymbolizeSymbols(r.ctx, symbolized)
+	if err != nil {
+		p.Release()
+		return nil, err
+	}
+	if !complete {
+		*r.incomplete = true
+	}
+	return &symbolizedPartition{PartitionReader: p, symbols: symbolized}, nil
+}
+
+type symbolizedPartition struct {
+	symdb.PartitionReader
+	symbols *symdb.Symbols
+}
+
+func (p *symbolizedPartition) Symbols() *symdb.Symbols { return p.symbols }
+
+func (p *symbolizedPartition) WriteStats(s *symdb.PartitionStats) {
+	p.PartitionReader.WriteStats(s)
+	s.FunctionsTotal = len(p.symbols.Functions)
+	s.StringsTotal = len(p.symbols.Strings)
+}
+
+func hasUnsymbolizedLocations(symbols *symdb.Symbols) bool {
+	for _, loc := range symbols.Locations {
+		if len(loc.Line) == 0 && int(loc.MappingId) < len(symbols.Mappings) &&
+			!symbols.Mappings[loc.MappingId].HasFunctions {
+			return true
+		}
+	}
+	return false
+}
+
+func cloneSymbols(x *symdb.Symbols) *symdb.Symbols {
+	n := symdb.Symbols{
+		Stacktraces: x.Stacktraces,
+		Locations:   make([]schemav1.InMemoryLocation, len(x.Locations)),
+		Mappings:    make([]schemav1.InMemoryMapping, len(x.Mappings)),
+		Functions:   make([]schemav1.InMemoryFunction, len(x.Functions)),
+		Strings:     make([]string, len(x.Strings)),
+	}
+	for i, l := range x.Locations {
+		n.Locations[i] = l.Clone()
+	}
+	copy(n.Mappings, x.Mappings)
+	copy(n.Functions, x.Functions)
+	copy(n.Strings, x.Strings)
+	return &n
+}
+
+// deleteLabelSets removes the label sets that include the label name.
+func deleteLabelSets(labels []int32, name int32) []int32 {
+	if name < 0 {
+		return labels
+	}
+	dst := make([]int32, 0, len(labels))
+	pairs := metadata.LabelPairs(labels)
+	for pairs.Next() {
+		p := pairs.At()
+		var found bool
+		for i := 0; i < len(p); i += 2 {
+			if p[i] == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, int32(len(p)/2))
+			dst = append(dst, p...)
+		}
+	}
+	return dst
+}
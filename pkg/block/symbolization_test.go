@@ -0,0 +1,134 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+type fakeSymbolizer struct{ calls int }
+
+// SymbolizeSymbols resolves locations of the mapping with
+// the "found" build ID to the "symbolized" function.
+func (f *fakeSymbolizer) SymbolizeSymbols(_ context.Context, symbols *symdb.Symbols) (bool, error) {
+	f.calls++
+	complete := true
+	for i, loc := range symbols.Locations {
+		m := &symbols.Mappings[loc.MappingId]
+		if len(loc.Line) > 0 || m.HasFunctions {
+			continue
+		}
+		if symbols.Strings[m.BuildId] != "found" {
+			complete = false
+			continue
+		}
+		symbols.Strings = append(symbols.Strings, "symbolized")
+		symbols.Functions = append(symbols.Functions, schemav1.InMemoryFunction{
+			Name: uint32(len(symbols.Strings) - 1),
+		})
+		symbols.Locations[i].Line = []schemav1.InMemoryLine{{
+			FunctionId: uint32(len(symbols.Functions) - 1),
+		}}
+	}
+	return complete, nil
+}
+
+func Test_symbolizingReader(t *testing.T) {
+	ctx := context.Background()
+	db := symdb.NewSymDB(symdb.DefaultConfig())
+	profile := &profilev1.Profile{
+		StringTable: []string{"", "main", "found", "missing"},
+		Function:    []*profilev1.Function{{Id: 1, Name: 1}},
+		Mapping: []*profilev1.Mapping{
+			{Id: 1, HasFunctions: true},
+			{Id: 2, BuildId: 2},
+			{Id: 3, BuildId: 3},
+		},
+		Location: []*profilev1.Location{
+			{Id: 1, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 1}}},
+			{Id: 2, MappingId: 2, Address: 0x10},
+			{Id: 3, MappingId: 3, Address: 0x20},
+		},
+		Sample: []*profilev1.Sample{
+			{LocationId: []uint64{2, 1}, Value: []int64{1}},
+			{LocationId: []uint64{3, 1}, Value: []int64{1}},
+		},
+	}
+	db.WriteProfileSymbols(0, profile)
+	db.WriteProfileSymbols(1, &profilev1.Profile{
+		StringTable: []string{"", "main"},
+		Function:    []*profilev1.Function{{Id: 1, Name: 1}},
+		Mapping:     []*profilev1.Mapping{{Id: 1, HasFunctions: true}},
+		Location:    []*profilev1.Location{{Id: 1, MappingId: 1, Line: []*profilev1.Line{{FunctionId: 1}}}},
+		Sample:      []*profilev1.Sample{{LocationId: []uint64{1}, Value: []int64{1}}},
+	})
+
+	var incomplete bool
+	symbolizer := new(fakeSymbolizer)
+	r := &symbolizingReader{
+		SymbolsReader: db,
+		ctx:           ctx,
+		symbolizer:    symbolizer,
+		incomplete:    &incomplete,
+	}
+
+	p, err := r.Partition(ctx, 0)
+	require.NoError(t, err)
+	source, err := db.Partition(ctx, 0)
+	require.NoError(t, err)
+	src := source.Symbols()
+	dst := p.Symbols()
+
+	require.Equal(t, 1, symbolizer.calls)
+	require.True(t, incomplete)
+	require.Len(t, dst.Functions, len(src.Functions)+1)
+	var stats symdb.PartitionStats
+	p.WriteStats(&stats)
+	assert.Equal(t, len(dst.Functions), stats.FunctionsTotal)
+	assert.Equal(t, len(dst.Strings), stats.StringsTotal)
+	for i, loc := range dst.Locations {
+		switch dst.Strings[dst.Mappings[loc.MappingId].BuildId] {
+		case "found":
+			require.Len(t, loc.Line, 1)
+			assert.Equal(t, "symbolized", dst.Strings[dst.Functions[loc.Line[0].FunctionId].Name])
+			// The source symbols are not modified.
+			assert.Empty(t, src.Locations[i].Line)
+		case "missing":
+			assert.Empty(t, loc.Line)
+		}
+	}
+
+	// Symbolized partitions are not cloned.
+	incomplete = false
+	p, err = r.Partition(ctx, 1)
+	require.NoError(t, err)
+	source, err = db.Partition(ctx, 1)
+	require.NoError(t, err)
+	assert.Same(t, source, p)
+	assert.Equal(t, 1, symbolizer.calls)
+	assert.False(t, incomplete)
+}
+
+func Test_deleteLabelSets(t *testing.T) {
+	strings := metadata.NewStringTable()
+	labels := metadata.NewLabelBuilder(strings).
+		WithLabelSet("service_name", "svc", "__profile_type__", "cpu").
+		WithLabelSet("service_name", "svc", metadata.LabelNameUnsymbolized, "true").
+		WithLabelSet("service_name", "svc", "__profile_type__", "memory").
+		Build()
+
+	name := strings.LookupString(metadata.LabelNameUnsymbolized)
+	expected := metadata.NewLabelBuilder(strings).
+		WithLabelSet("service_name", "svc", "__profile_type__", "cpu").
+		WithLabelSet("service_name", "svc", "__profile_type__", "memory").
+		Build()
+	assert.Equal(t, expected, deleteLabelSets(labels, name))
+	assert.Equal(t, labels, deleteLabelSets(labels, -1))
+}
@@ -0,0 +1,180 @@
+package block
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+// SeriesTombstone matches profiles of a deleted series.
+type SeriesTombstone struct {
+	matchers []*labels.Matcher
+	// Time range in nanoseconds, inclusive.
+	start int64
+	end   int64
+}
+
+func NewSeriesTombstone(t *metastorev1.SeriesTombstone) (*SeriesTombstone, error) {
+	matchers, err := parser.ParseMetricSelector(t.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid series tombstone %q selector: %w", t.Name, err)
+	}
+	return &SeriesTombstone{
+		matchers: matchers,
+		start:    time.UnixMilli(t.StartTime).UnixNano(),
+		end:      time.UnixMilli(t.EndTime).UnixNano(),
+	}, nil
+}
+
+// Matches reports whether the profile with the given
+// timestamp (in nanoseconds) and labels is deleted.
+func (t *SeriesTombstone) Matches(timestamp int64, ls phlaremodel.Labels) bool {
+	if timestamp < t.start || timestamp > t.end {
+		return false
+	}
+	return t.MatchesLabels(ls)
+}
+
+// MatchesLabels reports whether the series labels match the tombstone,
+// regardless of the time range.
+func (t *SeriesTombstone) MatchesLabels(ls phlaremodel.Labels) bool {
+	for _, m := range t.matchers {
+		if !m.Matches(ls.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Covers reports whether the tombstone time range
+// includes the given one (in nanoseconds).
+func (t *SeriesTombstone) Covers(start, end int64) bool {
+	return t.start <= start && end <= t.end
+}
+
+// deletedProfiles describes profiles to be deleted at compaction:
+// for each of the source blocks, the service name and profile type
+// of the deleted profiles, and the deleted series of any block.
+type deletedProfiles struct {
+	datasets map[string]map[deletedProfilesKey]struct{}
+	series   []*SeriesTombstone
+}
+
+type deletedProfilesKey struct {
+	serviceName string
+	profileType string
+}
+
+func newDeletedProfiles(
+	tombstones []*metastorev1.DatasetTombstones,
+	series []*metastorev1.SeriesTombstone,
+) (*deletedProfiles, error) {
+	if len(tombstones) == 0 && len(series) == 0 {
+		return nil, nil
+	}
+	d := &deletedProfiles{datasets: make(map[string]map[deletedProfilesKey]struct{})}
+	for _, t := range series {
+		st, err := NewSeriesTombstone(t)
+		if err != nil {
+			return nil, err
+		}
+		d.series = append(d.series, st)
+	}
+	for _, t := range tombstones {
+		for _, b := range t.Blocks {
+			keys, ok := d.datasets[b]
+			if !ok {
+				keys = make(map[deletedProfilesKey]struct{})
+				d.datasets[b] = keys
+			}
+			for _, ls := range t.Labels {
+				var k deletedProfilesKey
+				for _, l := range ls.Labels {
+					switch l.Name {
+					case phlaremodel.LabelNameServiceName:
+						k.serviceName = l.Value
+					case phlaremodel.LabelNameProfileType:
+						k.profileType = l.Value
+					}
+				}
+				keys[k] = struct{}{}
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d *deletedProfiles) deleted(block, serviceName, profileType string) bool {
+	if keys, ok := d.datasets[block]; ok {
+		_, ok = keys[deletedProfilesKey{serviceName: serviceName, profileType: profileType}]
+		return ok
+	}
+	return false
+}
+
+func (d *deletedProfiles) deletedEntry(e ProfileEntry) bool {
+	if d == nil {
+		return false
+	}
+	for _, t := range d.series {
+		if t.Matches(e.Timestamp, e.Labels) {
+			return true
+		}
+	}
+	return d.deleted(e.Dataset.obj.meta.Id,
+		e.Labels.Get(phlaremodel.LabelNameServiceName),
+		e.Labels.Get(phlaremodel.LabelNameProfileType),
+	)
+}
+
+// filterDataset returns the dataset metadata without the label sets of
+// the deleted profiles. If all the profiles of the dataset are deleted,
+// the function returns nil.
+func (d *deletedProfiles) filterDataset(md *metastorev1.BlockMeta, ds *metastorev1.Dataset) *metastorev1.Dataset {
+	if d == nil {
+		return ds
+	}
+	keys, ok := d.datasets[md.Id]
+	if !ok {
+		return ds
+	}
+	labels := make([]int32, 0, len(ds.Labels))
+	var deleted, profiles int
+	pairs := metadata.LabelPairs(ds.Labels)
+	for pairs.Next() {
+		var k deletedProfilesKey
+		p := pairs.At()
+		for i := 0; i < len(p); i += 2 {
+			switch md.StringTable[p[i]] {
+			case phlaremodel.LabelNameServiceName:
+				k.serviceName = md.StringTable[p[i+1]]
+			case phlaremodel.LabelNameProfileType:
+				k.profileType = md.StringTable[p[i+1]]
+			}
+		}
+		if k.profileType != "" {
+			if _, ok = keys[k]; ok {
+				deleted++
+				continue
+			}
+			profiles++
+		}
+		labels = append(labels, int32(len(p)/2))
+		labels = append(labels, p...)
+	}
+	switch {
+	case deleted == 0:
+		return ds
+	case profiles == 0:
+		return nil
+	}
+	c := ds.CloneVT()
+	c.Labels = labels
+	return c
+}
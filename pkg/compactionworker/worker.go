@@ -42,6 +42,8 @@ type Config struct {
 	RequestTimeout     time.Duration  `yaml:"request_timeout"`
 	CleanupMaxDuration time.Duration  `yaml:"cleanup_max_duration"`
 	MetricsExporter    metrics.Config `yaml:"metrics_exporter"`
+
+	DownsamplingMinLevel uint `yaml:"downsampling_min_level"`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
@@ -52,6 +54,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.DurationVar(&cfg.CleanupMaxDuration, prefix+"cleanup-max-duration", 15*time.Second, "Maximum duration of the cleanup operations.")
 	f.IntVar(&cfg.SmallObjectSize, prefix+"small-object-size-bytes", 8<<20, "Size of the object that can be loaded in memory.")
 	f.StringVar(&cfg.TempDir, prefix+"temp-dir", os.TempDir(), "Temporary directory for compaction jobs.")
+	f.UintVar(&cfg.DownsamplingMinLevel, prefix+"downsampling-min-level", 0, "Minimum compaction level of blocks that include downsampled copies of the datasets. 0 disables downsampling.")
 	cfg.MetricsExporter.RegisterFlags(f)
 }
 
@@ -78,6 +81,9 @@ type Worker struct {
 
 	exporter metrics.Exporter
 	ruler    metrics.Ruler
+
+	symbolizer block.Symbolizer
+	limits     Limits
 }
 
 type compactionJob struct {
@@ -99,6 +105,10 @@ type MetastoreClient interface {
 	metastorev1.IndexServiceClient
 }
 
+type Limits interface {
+	SymbolizerEnabled(tenantID string) bool
+}
+
 func New(
 	logger log.Logger,
 	config Config,
@@ -107,6 +117,8 @@ func New(
 	reg prometheus.Registerer,
 	ruler metrics.Ruler,
 	exporter metrics.Exporter,
+	symbolizer block.Symbolizer,
+	limits Limits,
 ) (*Worker, error) {
 	config.TempDir = filepath.Join(filepath.Clean(config.TempDir), "pyroscope-compactor")
 	_ = os.RemoveAll(config.TempDir)
@@ -114,14 +126,16 @@ func New(
 		return nil, fmt.Errorf("failed to create compactor directory: %w", err)
 	}
 	w := &Worker{
-		config:    config,
-		logger:    logger,
-		client:    client,
-		storage:   storage,
-		compactFn: block.Compact,
-		metrics:   newMetrics(reg),
-		ruler:     ruler,
-		exporter:  exporter,
+		config:     config,
+		logger:     logger,
+		client:     client,
+		storage:    storage,
+		compactFn:  block.Compact,
+		metrics:    newMetrics(reg),
+		ruler:      ruler,
+		exporter:   exporter,
+		symbolizer: symbolizer,
+		limits:     limits,
 	}
 	w.threads = config.JobConcurrency
 	if w.threads < 1 {
@@ -447,6 +461,22 @@ func (w *Worker) runCompaction(job *compactionJob) {
 		options = append(options, block.WithSampleObserver(observer))
 	}
 
+	if w.symbolizer != nil && w.limits != nil && w.limits.SymbolizerEnabled(job.Tenant) {
+		options = append(options, block.WithSymbolizer(w.symbolizer))
+	}
+
+	if w.config.DownsamplingMinLevel > 0 {
+		options = append(options, block.WithDownsampling(uint32(w.config.DownsamplingMinLevel)))
+	}
+
+	if tombstones := datasetTombstones(job); len(tombstones) > 0 {
+		options = append(options, block.WithDatasetTombstones(tombstones...))
+	}
+
+	if tombstones := seriesTombstones(job); len(tombstones) > 0 {
+		options = append(options, block.WithSeriesTombstones(tombstones...))
+	}
+
 	compacted, err := w.compactFn(ctx, job.blocks, w.storage, options...)
 	defer func() {
 		if err = os.RemoveAll(tempdir); err != nil {
@@ -547,6 +577,30 @@ func (w *Worker) getBlockMetadata(logger log.Logger, job *compactionJob) error {
 	return nil
 }
 
+// datasetTombstones returns the dataset tombstones of the job: the
+// datasets are deleted from the source blocks when they are rewritten.
+func datasetTombstones(job *compactionJob) []*metastorev1.DatasetTombstones {
+	var tombstones []*metastorev1.DatasetTombstones
+	for _, t := range job.Tombstones {
+		if d := t.GetDatasets(); d != nil && d.Tenant == job.Tenant && d.Shard == job.Shard {
+			tombstones = append(tombstones, d)
+		}
+	}
+	return tombstones
+}
+
+// seriesTombstones returns the series tombstones of the job tenant:
+// the series profiles are deleted from any compacted block.
+func seriesTombstones(job *compactionJob) []*metastorev1.SeriesTombstone {
+	var tombstones []*metastorev1.SeriesTombstone
+	for _, t := range job.Tombstones {
+		if s := t.GetSeries(); s != nil && s.Tenant == job.Tenant {
+			tombstones = append(tombstones, s)
+		}
+	}
+	return tombstones
+}
+
 func (w *Worker) handleTombstones(logger log.Logger, tombstones ...*metastorev1.Tombstones) {
 	for _, t := range tombstones {
 		w.deleterPool.add(w.newDeleter(logger, t), w.config.CleanupMaxDuration)
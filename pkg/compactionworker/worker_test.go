@@ -48,6 +48,8 @@ func createTestWorker(t *testing.T, client MetastoreClient, compactFn compactFun
 		prometheus.NewRegistry(),
 		nil, // ruler
 		nil, // exporter
+		nil, // symbolizer
+		nil, // limits
 	)
 
 	require.NoError(t, err)
@@ -464,6 +466,8 @@ func TestWorker_CleanupMaxDurationAtShutdown(t *testing.T) {
 		nil, // registry
 		nil, // ruler
 		nil, // exporter
+		nil, // symbolizer
+		nil, // limits
 	)
 	require.NoError(t, err)
 	worker.compactFn = skipCompactionFn
@@ -77,6 +77,8 @@ type Config struct {
 
 	// Distributors ring
 	DistributorRing util.CommonRingConfig `yaml:"ring"`
+
+	Forwarding writepath.ForwardingConfig `yaml:"forwarding"`
 }
 
 // RegisterFlags registers distributor-related flags.
@@ -84,6 +86,11 @@ func (cfg *Config) RegisterFlags(fs *flag.FlagSet, logger log.Logger) {
 	cfg.PoolConfig.RegisterFlagsWithPrefix("distributor", fs)
 	fs.DurationVar(&cfg.PushTimeout, "distributor.push.timeout", 5*time.Second, "Timeout when pushing data to ingester.")
 	cfg.DistributorRing.RegisterFlags("distributor.ring.", "collectors/", "distributors", fs, logger)
+	cfg.Forwarding.RegisterFlags(fs)
+}
+
+func (cfg *Config) Validate() error {
+	return cfg.Forwarding.Validate()
 }
 
 // Distributor coordinates replicates and distribution of log streams.
@@ -105,6 +112,7 @@ type Distributor struct {
 	aggregator             *aggregator.MultiTenantAggregator[*pprof.ProfileMerge]
 	asyncRequests          sync.WaitGroup
 	ingestionLimitsSampler *ingestlimits.Sampler
+	adaptiveSampler        *sampling.AdaptiveSampler
 	usageGroupEvaluator    *validation.UsageGroupEvaluator
 
 	subservices        *services.Manager
@@ -184,15 +192,23 @@ func New(
 
 	ingesterRoute := writepath.IngesterFunc(d.sendRequestsToIngester)
 	segmentWriterRoute := writepath.IngesterFunc(d.sendRequestsToSegmentWriter)
+	var forwarder *writepath.Forwarder
+	if len(config.Forwarding.Destinations) > 0 {
+		forwarder = writepath.NewForwarder(logger, reg, config.Forwarding)
+	}
 	d.router = writepath.NewRouter(
 		logger, reg, limits,
 		ingesterRoute,
 		segmentWriterRoute,
+		forwarder,
 	)
 
 	var err error
 	subservices := []services.Service(nil)
 	subservices = append(subservices, d.pool)
+	if forwarder != nil {
+		subservices = append(subservices, forwarder.Service())
+	}
 
 	distributorsRing, distributorsLifecycler, err := newRingAndLifecycler(config.DistributorRing, d.healthyInstancesCount, logger, reg)
 	if err != nil {
@@ -200,9 +216,10 @@ func New(
 	}
 
 	d.ingestionLimitsSampler = ingestlimits.NewSampler(distributorsRing)
+	d.adaptiveSampler = sampling.NewAdaptiveSampler(distributorsRing, reg)
 	d.usageGroupEvaluator = validation.NewUsageGroupEvaluator(logger)
 
-	subservices = append(subservices, distributorsLifecycler, distributorsRing, d.aggregator, d.ingestionLimitsSampler)
+	subservices = append(subservices, distributorsLifecycler, distributorsRing, d.aggregator, d.ingestionLimitsSampler, d.adaptiveSampler)
 
 	d.ingestionRateLimiter = limiter.NewRateLimiter(newGlobalRateStrategy(newIngestionRateStrategy(limits), d), 10*time.Second)
 	d.distributorsLifecycler = distributorsLifecycler
@@ -385,6 +402,10 @@ func (d *Distributor) PushParsed(ctx context.Context, req *distributormodel.Push
 		}
 	}
 
+	d.sampleAdaptively(req)
+
+	// The check is based on the number of profiles received: profiles
+	// skipped by the adaptive sampling are accounted as discarded.
 	if req.TotalProfiles == 0 {
 		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("no profiles received"))
 	}
@@ -934,6 +955,35 @@ func (d *Distributor) shouldSample(tenantID string, groupsInRequest []validation
 	return rand.Float64() <= minProb
 }
 
+// sampleAdaptively down-samples the series of the services which profile
+// rate exceeds the target. Profiles that are accepted are scaled by the
+// inverse of the effective sampling rate, and the series are annotated.
+func (d *Distributor) sampleAdaptively(req *distributormodel.PushRequest) {
+	l := d.limits.DistributorSampling(req.TenantID)
+	if l == nil || l.Adaptive == nil {
+		return
+	}
+	for _, series := range req.Series {
+		minRate := 1.0
+		series.Samples = slices.RemoveInPlace(series.Samples, func(sample *distributormodel.ProfileSample, _ int) bool {
+			keep, rate := d.adaptiveSampler.Sample(req.TenantID, l.Adaptive, series.Labels)
+			if !keep {
+				validation.DiscardedProfiles.WithLabelValues(string(validation.SkippedByAdaptiveSampling), req.TenantID).Add(1)
+				validation.DiscardedBytes.WithLabelValues(string(validation.SkippedByAdaptiveSampling), req.TenantID).Add(float64(sample.Profile.SizeVT()))
+				return true
+			}
+			sampling.ScaleProfile(sample.Profile.Profile, rate)
+			minRate = min(minRate, rate)
+			return false
+		})
+		if minRate < 1 && len(series.Samples) > 0 {
+			if err := series.MarkSampled(minRate); err != nil {
+				level.Warn(d.logger).Log("msg", "failed to annotate sampled series", "err", err)
+			}
+		}
+	}
+}
+
 type profileTracker struct {
 	profile     *distributormodel.ProfileSeries
 	minSuccess  int
@@ -2725,3 +2725,63 @@ func TestDistributor_shouldSample_Probability(t *testing.T) {
 		})
 	}
 }
+
+type instanceCountMock int
+
+func (m instanceCountMock) InstancesCount() int { return int(m) }
+
+func TestDistributor_sampleAdaptively(t *testing.T) {
+	const tenantID = "test-tenant"
+	overrides := validation.MockOverrides(func(defaults *validation.Limits, tenantLimits map[string]*validation.Limits) {
+		l := validation.MockDefaultLimits()
+		l.DistributorSampling = &sampling.Config{
+			Adaptive: &sampling.AdaptiveConfig{TargetProfilesPerSecond: 0.1, Window: time.Minute},
+		}
+		tenantLimits[tenantID] = l
+	})
+	d := &Distributor{
+		logger:          log.NewNopLogger(),
+		limits:          overrides,
+		adaptiveSampler: sampling.NewAdaptiveSampler(instanceCountMock(1), nil),
+	}
+
+	newRequest := func() *distributormodel.PushRequest {
+		return &distributormodel.PushRequest{
+			TenantID: tenantID,
+			Series: []*distributormodel.ProfileSeries{{
+				Labels: []*typesv1.LabelPair{{Name: "service_name", Value: "svc"}},
+				Samples: []*distributormodel.ProfileSample{{
+					Profile: pprof2.RawFromProto(&profilev1.Profile{
+						Sample: []*profilev1.Sample{{Value: []int64{10}}},
+					}),
+				}},
+			}},
+		}
+	}
+
+	// The rate is below the target: the profile is accepted as is.
+	req := newRequest()
+	d.sampleAdaptively(req)
+	require.Len(t, req.Series[0].Samples, 1)
+	assert.Equal(t, []int64{10}, req.Series[0].Samples[0].Profile.Sample[0].Value)
+	assert.Empty(t, req.Series[0].Annotations)
+
+	var kept int
+	for i := 0; i < 100; i++ {
+		req = newRequest()
+		d.sampleAdaptively(req)
+		if len(req.Series[0].Samples) == 0 {
+			continue
+		}
+		kept++
+		if req.Series[0].Samples[0].Profile.Sample[0].Value[0] == 10 {
+			// The rate is below the target.
+			assert.Empty(t, req.Series[0].Annotations)
+			continue
+		}
+		require.Len(t, req.Series[0].Annotations, 1)
+		assert.Equal(t, sampling.ProfileAnnotationKeySampled, req.Series[0].Annotations[0].Key)
+	}
+	assert.Greater(t, kept, 0)
+	assert.Less(t, kept, 50)
+}
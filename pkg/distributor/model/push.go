@@ -5,6 +5,7 @@ import (
 
 	v1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/distributor/ingestlimits"
+	"github.com/grafana/pyroscope/pkg/distributor/sampling"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/pprof"
 )
@@ -143,3 +144,15 @@ func (req *PushRequest) MarkThrottledUsageGroup(l *ingestlimits.Config, usageGro
 	}
 	return nil
 }
+
+func (p *ProfileSeries) MarkSampled(rate float64) error {
+	annotation, err := sampling.CreateAdaptiveAnnotation(rate)
+	if err != nil {
+		return err
+	}
+	p.Annotations = append(p.Annotations, &v1.ProfileAnnotation{
+		Key:   sampling.ProfileAnnotationKeySampled,
+		Value: string(annotation),
+	})
+	return nil
+}
@@ -0,0 +1,191 @@
+package sampling
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/services"
+	"github.com/prometheus/client_golang/prometheus"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+type InstanceCountProvider interface {
+	InstancesCount() int
+}
+
+// AdaptiveSampler down-samples series of the services which profile
+// rate exceeds the target rate.
+//
+// The sampler keeps track of the profile rate of each service, which
+// determines the effective sampling rate: target / observed. Series are
+// sampled systematically: each series accumulates the rate with every
+// profile received, and a profile is accepted once the accumulated value
+// reaches one. The initial value is derived from the series hash, so that
+// the first profiles of the series are not favoured: each profile has the
+// same chance to be accepted, which keeps the scaled totals unbiased.
+//
+// As tracking is done in memory, the target rate is divided by the
+// number of distributor instances.
+type AdaptiveSampler struct {
+	*services.BasicService
+
+	mu       sync.Mutex
+	services map[serviceKey]*serviceTracker
+
+	instanceCountProvider InstanceCountProvider
+	rate                  *prometheus.GaugeVec
+	now                   func() time.Time
+
+	cleanupInterval time.Duration
+	maxAge          time.Duration
+}
+
+type serviceKey struct {
+	tenant  string
+	service string
+}
+
+type serviceTracker struct {
+	mu          sync.Mutex
+	windowStart time.Time
+	current     float64
+	previous    float64
+	lastSeen    time.Time
+	series      map[uint64]*seriesTracker
+}
+
+type seriesTracker struct {
+	credit   float64
+	lastSeen time.Time
+}
+
+func NewAdaptiveSampler(instanceCount InstanceCountProvider, reg prometheus.Registerer) *AdaptiveSampler {
+	s := &AdaptiveSampler{
+		services:              make(map[serviceKey]*serviceTracker),
+		instanceCountProvider: instanceCount,
+		now:                   time.Now,
+		cleanupInterval:       10 * time.Minute,
+		maxAge:                time.Hour,
+		rate: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "pyroscope",
+			Name:      "distributor_adaptive_sampling_rate",
+			Help:      "The effective adaptive sampling rate of the service profiles.",
+		}, []string{"tenant", "service_name"}),
+	}
+	if reg != nil {
+		reg.MustRegister(s.rate)
+	}
+	s.BasicService = services.NewTimerService(s.cleanupInterval, nil, s.iteration, nil)
+	return s
+}
+
+func (s *AdaptiveSampler) iteration(context.Context) error {
+	s.removeStaleSeries()
+	return nil
+}
+
+// Sample reports whether the profile of the series should be accepted,
+// and the effective sampling rate the profile values should be scaled by.
+func (s *AdaptiveSampler) Sample(tenantID string, config *AdaptiveConfig, labels phlaremodel.Labels) (keep bool, rate float64) {
+	if config == nil || config.TargetProfilesPerSecond <= 0 {
+		return true, 1
+	}
+	key := serviceKey{tenant: tenantID, service: labels.Get(phlaremodel.LabelNameServiceName)}
+	s.mu.Lock()
+	tracker, ok := s.services[key]
+	if !ok {
+		tracker = &serviceTracker{series: make(map[uint64]*seriesTracker)}
+		s.services[key] = tracker
+	}
+	s.mu.Unlock()
+
+	target := config.TargetProfilesPerSecond / float64(max(1, s.instanceCountProvider.InstancesCount()))
+	keep, rate = tracker.sample(s.now(), config.window(), target, labels.Hash())
+	s.rate.WithLabelValues(key.tenant, key.service).Set(rate)
+	return keep, rate
+}
+
+func (t *serviceTracker) sample(now time.Time, window time.Duration, target float64, series uint64) (bool, float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastSeen = now
+	t.observe(now, window)
+	rate := 1.0
+	if observed := t.observedRate(now, window); observed > target {
+		rate = target / observed
+	}
+	st, ok := t.series[series]
+	if !ok {
+		st = &seriesTracker{credit: initialCredit(series)}
+		t.series[series] = st
+	}
+	st.lastSeen = now
+	if st.credit += rate; st.credit >= 1 {
+		st.credit--
+		return true, rate
+	}
+	return false, rate
+}
+
+// initialCredit returns a value in [0, 1) uniformly
+// distributed over the series hashes.
+func initialCredit(series uint64) float64 {
+	return float64(series>>11) / (1 << 53)
+}
+
+func (t *serviceTracker) observe(now time.Time, window time.Duration) {
+	if elapsed := now.Sub(t.windowStart); elapsed >= window {
+		t.previous = t.current
+		if elapsed >= 2*window {
+			t.previous = 0
+		}
+		t.current = 0
+		t.windowStart = now
+	}
+	t.current++
+}
+
+// observedRate returns the profile rate (per second) estimated over
+// the sliding window.
+func (t *serviceTracker) observedRate(now time.Time, window time.Duration) float64 {
+	weight := 1 - float64(now.Sub(t.windowStart))/float64(window)
+	return (t.previous*weight + t.current) / window.Seconds()
+}
+
+func (s *AdaptiveSampler) removeStaleSeries() {
+	cutoff := s.now().Add(-s.maxAge)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, tracker := range s.services {
+		tracker.mu.Lock()
+		if tracker.lastSeen.Before(cutoff) {
+			delete(s.services, key)
+			s.rate.DeleteLabelValues(key.tenant, key.service)
+		} else {
+			for h, st := range tracker.series {
+				if st.lastSeen.Before(cutoff) {
+					delete(tracker.series, h)
+				}
+			}
+		}
+		tracker.mu.Unlock()
+	}
+}
+
+// ScaleProfile multiplies the profile sample values by the inverse
+// of the sampling rate to preserve the totals.
+func ScaleProfile(p *profilev1.Profile, rate float64) {
+	if rate <= 0 || rate >= 1 {
+		return
+	}
+	scale := 1 / rate
+	for _, sample := range p.Sample {
+		for i, v := range sample.Value {
+			sample.Value[i] = int64(math.Round(float64(v) * scale))
+		}
+	}
+}
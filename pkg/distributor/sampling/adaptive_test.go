@@ -0,0 +1,141 @@
+package sampling
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+)
+
+type mockRing struct {
+	instanceCount int
+}
+
+func (m *mockRing) InstancesCount() int {
+	return m.instanceCount
+}
+
+type testClock struct{ t time.Time }
+
+func (c *testClock) now() time.Time { return c.t }
+
+func newTestAdaptiveSampler(instances int) (*AdaptiveSampler, *testClock) {
+	s := NewAdaptiveSampler(&mockRing{instanceCount: instances}, prometheus.NewRegistry())
+	c := &testClock{t: time.Unix(0, 0)}
+	s.now = c.now
+	return s, c
+}
+
+func TestAdaptiveSampler_BelowTarget(t *testing.T) {
+	s, c := newTestAdaptiveSampler(1)
+	config := &AdaptiveConfig{TargetProfilesPerSecond: 10, Window: time.Minute}
+	labels := phlaremodel.LabelsFromStrings("service_name", "svc", "pod", "a")
+	for i := 0; i < 100; i++ {
+		keep, rate := s.Sample("tenant", config, labels)
+		assert.True(t, keep)
+		assert.Equal(t, 1.0, rate)
+		c.t = c.t.Add(time.Second)
+	}
+}
+
+func TestAdaptiveSampler_Disabled(t *testing.T) {
+	s, _ := newTestAdaptiveSampler(1)
+	labels := phlaremodel.LabelsFromStrings("service_name", "svc")
+	for _, config := range []*AdaptiveConfig{nil, {}} {
+		keep, rate := s.Sample("tenant", config, labels)
+		assert.True(t, keep)
+		assert.Equal(t, 1.0, rate)
+	}
+}
+
+func TestAdaptiveSampler_DownSamplesHighFrequencyService(t *testing.T) {
+	s, c := newTestAdaptiveSampler(2)
+	// Each of the two distributors targets 1 profile per second.
+	config := &AdaptiveConfig{TargetProfilesPerSecond: 2, Window: time.Minute}
+
+	// 100 pods of a service send a profile every 10 seconds:
+	// 10 profiles per second in total.
+	const pods = 100
+	series := make([]phlaremodel.Labels, pods)
+	for i := range series {
+		series[i] = phlaremodel.LabelsFromStrings("service_name", "svc", "pod", fmt.Sprint(i))
+	}
+
+	kept := make([]int, pods)
+	var total, scaled float64
+	for tick := 0; tick < 60; tick++ {
+		for i, ls := range series {
+			if i%10 != tick%10 {
+				continue
+			}
+			keep, rate := s.Sample("tenant", config, ls)
+			total++
+			if keep {
+				kept[i]++
+				scaled += 1 / rate
+			}
+		}
+		c.t = c.t.Add(time.Second)
+	}
+
+	// The series are represented.
+	var represented int
+	for _, n := range kept {
+		if n > 0 {
+			represented++
+		}
+	}
+	assert.Greater(t, represented, pods/2)
+	// The totals are preserved approximately.
+	assert.InDelta(t, total, scaled, total*0.2)
+
+	// The effective rate approaches the target.
+	_, rate := s.Sample("tenant", config, series[0])
+	assert.InDelta(t, 0.1, rate, 0.02)
+	assert.InDelta(t, rate, testutil.ToFloat64(s.rate.WithLabelValues("tenant", "svc")), 1e-9)
+
+	// The first profiles of new series are not favoured.
+	var accepted int
+	for i := 0; i < pods; i++ {
+		if keep, _ := s.Sample("tenant", config, phlaremodel.LabelsFromStrings("service_name", "svc", "pod", fmt.Sprint(pods+i))); keep {
+			accepted++
+		}
+	}
+	assert.InDelta(t, pods*rate, accepted, pods*0.1)
+
+	// Another service is not affected.
+	keep, rate := s.Sample("tenant", config, phlaremodel.LabelsFromStrings("service_name", "other"))
+	assert.True(t, keep)
+	assert.Equal(t, 1.0, rate)
+}
+
+func TestAdaptiveSampler_RemoveStaleSeries(t *testing.T) {
+	s, c := newTestAdaptiveSampler(1)
+	config := &AdaptiveConfig{TargetProfilesPerSecond: 1}
+	s.Sample("tenant", config, phlaremodel.LabelsFromStrings("service_name", "a"))
+	c.t = c.t.Add(s.maxAge / 2)
+	s.Sample("tenant", config, phlaremodel.LabelsFromStrings("service_name", "b"))
+	c.t = c.t.Add(s.maxAge/2 + time.Second)
+	s.removeStaleSeries()
+
+	require.Len(t, s.services, 1)
+	_, ok := s.services[serviceKey{tenant: "tenant", service: "b"}]
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(s.rate))
+}
+
+func TestScaleProfile(t *testing.T) {
+	p := &profilev1.Profile{Sample: []*profilev1.Sample{{Value: []int64{1, 10}}, {Value: []int64{3, 0}}}}
+	ScaleProfile(p, 0.25)
+	assert.Equal(t, []int64{4, 40}, p.Sample[0].Value)
+	assert.Equal(t, []int64{12, 0}, p.Sample[1].Value)
+	ScaleProfile(p, 1)
+	assert.Equal(t, []int64{4, 40}, p.Sample[0].Value)
+}
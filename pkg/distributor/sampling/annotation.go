@@ -0,0 +1,19 @@
+package sampling
+
+import "encoding/json"
+
+const (
+	ProfileAnnotationKeySampled = "pyroscope.ingest.sampled"
+)
+
+type ProfileAnnotation struct {
+	Body interface{} `json:"body"`
+}
+
+type SampledAnnotation struct {
+	Rate float64 `json:"rate"`
+}
+
+func CreateAdaptiveAnnotation(rate float64) ([]byte, error) {
+	return json.Marshal(&ProfileAnnotation{Body: SampledAnnotation{Rate: rate}})
+}
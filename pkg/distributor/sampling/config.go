@@ -1,10 +1,36 @@
 package sampling
 
+import "time"
+
 type Config struct {
 	// UsageGroups controls sampling for pre-configured usage groups.
 	UsageGroups map[string]UsageGroupSampling `yaml:"usage_groups" json:"usage_groups"`
+	// Adaptive controls the adaptive sampling of high-frequency series.
+	Adaptive *AdaptiveConfig `yaml:"adaptive" json:"adaptive"`
 }
 
 type UsageGroupSampling struct {
 	Probability float64 `yaml:"probability" json:"probability"`
 }
+
+// AdaptiveConfig describes the params of the adaptive sampling.
+//
+// Distributors track the rate of profiles per service, and down-sample
+// series of the services that exceed the target rate. Every series is
+// represented: the first profile of a series is always accepted. Sample
+// values of the accepted profiles are scaled to preserve the totals.
+type AdaptiveConfig struct {
+	// TargetProfilesPerSecond is the target rate of profiles per service.
+	TargetProfilesPerSecond float64 `yaml:"target_profiles_per_second" json:"target_profiles_per_second"`
+	// Window is the interval the rate of profiles is measured over.
+	Window time.Duration `yaml:"window" json:"window"`
+}
+
+const defaultAdaptiveWindow = time.Minute
+
+func (c *AdaptiveConfig) window() time.Duration {
+	if c.Window > 0 {
+		return c.Window
+	}
+	return defaultAdaptiveWindow
+}
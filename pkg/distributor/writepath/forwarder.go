@@ -0,0 +1,399 @@
+package writepath
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
+	"github.com/grafana/dskit/services"
+	"github.com/prometheus/client_golang/prometheus"
+
+	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
+	connectapi "github.com/grafana/pyroscope/pkg/api/connect"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/model/relabel"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+// ForwardingConfig configures forwarding of the incoming profiles to
+// remote Pyroscope endpoints. The forwarding is best-effort: profiles
+// are queued, and the client never waits for the remote write.
+type ForwardingConfig struct {
+	Destinations []ForwardingDestination `yaml:"destinations" category:"experimental" doc:"description=List of remote Pyroscope endpoints the incoming profiles are forwarded to."`
+
+	// Defaults for the destinations.
+	QueueSize   int            `yaml:"queue_size" category:"experimental"`
+	Concurrency int            `yaml:"concurrency" category:"experimental"`
+	Timeout     time.Duration  `yaml:"timeout" category:"experimental"`
+	Backoff     backoff.Config `yaml:"backoff_config" category:"experimental"`
+
+	DrainTimeout time.Duration `yaml:"drain_timeout" category:"experimental"`
+}
+
+// ForwardingDestination describes a remote Pyroscope endpoint. Only the
+// series of the listed tenants that are kept by the relabeling rules are
+// forwarded; the rules are applied to the series labels, and may be used
+// to alter them, e.g., to add the cluster label.
+type ForwardingDestination struct {
+	Name         string            `yaml:"name"`
+	URL          string            `yaml:"url"`
+	TenantID     string            `yaml:"tenant_id"`
+	Username     string            `yaml:"username"`
+	Password     flagext.Secret    `yaml:"password"`
+	Headers      map[string]string `yaml:"headers"`
+	Tenants      []string          `yaml:"tenants"`
+	RelabelRules []*relabel.Config `yaml:"relabel_rules"`
+	QueueSize    int               `yaml:"queue_size"`
+	Concurrency  int               `yaml:"concurrency"`
+	Timeout      time.Duration     `yaml:"timeout"`
+	Backoff      *backoff.Config   `yaml:"backoff_config"`
+}
+
+func (cfg *ForwardingConfig) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&cfg.QueueSize, "distributor.forwarding.queue-size", 1000, "Default number of requests queued per forwarding destination. If the queue is full, the requests are dropped.")
+	f.IntVar(&cfg.Concurrency, "distributor.forwarding.concurrency", 4, "Default number of concurrent requests per forwarding destination.")
+	f.DurationVar(&cfg.Timeout, "distributor.forwarding.timeout", 10*time.Second, "Default timeout of a request to a forwarding destination.")
+	f.DurationVar(&cfg.DrainTimeout, "distributor.forwarding.drain-timeout", 10*time.Second, "Maximum time to send the queued requests at shutdown. Requests not sent by then are dropped.")
+	cfg.Backoff.RegisterFlagsWithPrefix("distributor.forwarding", f)
+}
+
+func (cfg *ForwardingConfig) Validate() error {
+	names := make(map[string]struct{}, len(cfg.Destinations))
+	for i, d := range cfg.Destinations {
+		if d.Name == "" {
+			return fmt.Errorf("forwarding destination at pos %d: name is required", i)
+		}
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("forwarding destination %q: duplicate name", d.Name)
+		}
+		names[d.Name] = struct{}{}
+		if _, err := url.ParseRequestURI(d.URL); err != nil {
+			return fmt.Errorf("forwarding destination %q: invalid url: %w", d.Name, err)
+		}
+		for idx, rule := range d.RelabelRules {
+			if err := rule.Validate(); err != nil {
+				return fmt.Errorf("forwarding destination %q: rule at pos %d is not valid: %w", d.Name, idx, err)
+			}
+		}
+	}
+	return nil
+}
+
+// withDefaults returns the destination config with
+// the unset options set to the forwarding defaults.
+func (cfg *ForwardingConfig) withDefaults(d ForwardingDestination) ForwardingDestination {
+	if d.QueueSize <= 0 {
+		d.QueueSize = cfg.QueueSize
+	}
+	if d.Concurrency <= 0 {
+		d.Concurrency = max(1, cfg.Concurrency)
+	}
+	if d.Timeout <= 0 {
+		d.Timeout = cfg.Timeout
+	}
+	if d.Backoff == nil {
+		b := cfg.Backoff
+		d.Backoff = &b
+	}
+	return d
+}
+
+// Forwarder tees the incoming requests to the remote destinations.
+type Forwarder struct {
+	service services.Service
+	logger  log.Logger
+	metrics *forwarderMetrics
+
+	destinations []*destination
+	// Cancels the retries at shutdown.
+	ctx    context.Context
+	cancel context.CancelFunc
+	// Cancels the requests once the drain timeout expires.
+	sendCtx      context.Context
+	cancelSend   context.CancelFunc
+	drainTimeout time.Duration
+}
+
+func NewForwarder(
+	logger log.Logger,
+	registerer prometheus.Registerer,
+	config ForwardingConfig,
+	options ...connect.ClientOption,
+) *Forwarder {
+	f := &Forwarder{
+		logger:       logger,
+		metrics:      newForwarderMetrics(registerer),
+		drainTimeout: config.DrainTimeout,
+	}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	f.sendCtx, f.cancelSend = context.WithCancel(context.Background())
+	options = append(connectapi.DefaultClientOptions(), options...)
+	for _, d := range config.Destinations {
+		f.destinations = append(f.destinations, newDestination(f, config.withDefaults(d), options...))
+	}
+	f.service = services.NewIdleService(f.starting, f.stopping)
+	return f
+}
+
+func (f *Forwarder) Service() services.Service { return f.service }
+
+func (f *Forwarder) starting(context.Context) error {
+	for _, d := range f.destinations {
+		d.start()
+	}
+	return nil
+}
+
+func (f *Forwarder) stopping(_ error) error {
+	// The queued requests are sent without retries,
+	// until the drain timeout expires.
+	f.cancel()
+	drain := time.AfterFunc(f.drainTimeout, f.cancelSend)
+	defer drain.Stop()
+	defer f.cancelSend()
+	var wg sync.WaitGroup
+	for _, d := range f.destinations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.stop()
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// Forward enqueues the request series selected by the destinations. The
+// call does not block: if the queue of a destination is full, the request
+// is dropped. The request must not be modified during the call.
+func (f *Forwarder) Forward(req *distributormodel.PushRequest) {
+	for _, d := range f.destinations {
+		d.forward(req)
+	}
+}
+
+type destination struct {
+	*Forwarder
+	config ForwardingDestination
+	client pushv1connect.PusherServiceClient
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan *distributormodel.PushRequest
+	wg     sync.WaitGroup
+}
+
+func newDestination(f *Forwarder, config ForwardingDestination, options ...connect.ClientOption) *destination {
+	return &destination{
+		Forwarder: f,
+		config:    config,
+		client:    pushv1connect.NewPusherServiceClient(http.DefaultClient, config.URL, options...),
+		queue:     make(chan *distributormodel.PushRequest, config.QueueSize),
+	}
+}
+
+func (d *destination) start() {
+	for i := 0; i < d.config.Concurrency; i++ {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			for req := range d.queue {
+				d.send(req)
+				d.metrics.queueLength.WithLabelValues(d.config.Name).Dec()
+			}
+		}()
+	}
+}
+
+func (d *destination) stop() {
+	d.mu.Lock()
+	d.closed = true
+	close(d.queue)
+	d.mu.Unlock()
+	d.wg.Wait()
+}
+
+func (d *destination) forward(req *distributormodel.PushRequest) {
+	if !d.selectsTenant(req.TenantID) {
+		return
+	}
+	selected := d.selectSeries(req)
+	if selected == nil {
+		return
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	if d.closed {
+		return
+	}
+	d.metrics.queueLength.WithLabelValues(d.config.Name).Inc()
+	select {
+	case d.queue <- selected:
+	default:
+		d.metrics.queueLength.WithLabelValues(d.config.Name).Dec()
+		d.metrics.profiles.WithLabelValues(d.config.Name, outcomeDropped).Add(float64(countProfiles(selected)))
+	}
+}
+
+func (d *destination) selectsTenant(tenantID string) bool {
+	if len(d.config.Tenants) == 0 {
+		return true
+	}
+	for _, t := range d.config.Tenants {
+		if t == tenantID {
+			return true
+		}
+	}
+	return false
+}
+
+// selectSeries returns a copy of the request with the series kept by the
+// relabeling rules, or nil, if no series are kept. The profiles are cloned
+// as the request is modified when it is sent to the local write path.
+func (d *destination) selectSeries(req *distributormodel.PushRequest) *distributormodel.PushRequest {
+	var selected *distributormodel.PushRequest
+	builder := phlaremodel.NewLabelsBuilder(nil)
+	for _, s := range req.Series {
+		labels := phlaremodel.Labels(s.Labels).Clone()
+		if len(d.config.RelabelRules) > 0 {
+			builder.Reset(s.Labels)
+			if keep := relabel.ProcessBuilder(builder, d.config.RelabelRules...); !keep {
+				continue
+			}
+			labels = builder.Labels()
+		}
+		if selected == nil {
+			selected = &distributormodel.PushRequest{TenantID: req.TenantID}
+		}
+		series := &distributormodel.ProfileSeries{
+			Labels:  labels,
+			Samples: make([]*distributormodel.ProfileSample, 0, len(s.Samples)),
+		}
+		for _, p := range s.Samples {
+			if p.Profile == nil || p.Profile.Profile == nil {
+				continue
+			}
+			series.Samples = append(series.Samples, &distributormodel.ProfileSample{
+				Profile: &pprof.Profile{Profile: p.Profile.Profile.CloneVT()},
+				ID:      p.ID,
+			})
+		}
+		selected.Series = append(selected.Series, series)
+	}
+	return selected
+}
+
+func (d *destination) send(req *distributormodel.PushRequest) {
+	n := float64(countProfiles(req))
+	push, err := d.pushRequest(req)
+	if err != nil {
+		level.Warn(d.logger).Log("msg", "failed to prepare forwarded request", "destination", d.config.Name, "err", err)
+		d.metrics.profiles.WithLabelValues(d.config.Name, outcomeFailed).Add(n)
+		return
+	}
+	tenantID := d.config.TenantID
+	if tenantID == "" {
+		tenantID = req.TenantID
+	}
+	b := backoff.New(d.ctx, *d.config.Backoff)
+	for {
+		if err = d.push(tenantID, push); err == nil {
+			d.metrics.profiles.WithLabelValues(d.config.Name, outcomeSent).Add(n)
+			return
+		}
+		if !retryable(err) || !b.Ongoing() {
+			break
+		}
+		d.metrics.retries.WithLabelValues(d.config.Name).Inc()
+		b.Wait()
+		if !b.Ongoing() {
+			break
+		}
+	}
+	level.Warn(d.logger).Log("msg", "failed to forward profiles", "destination", d.config.Name, "tenant", req.TenantID, "err", err)
+	d.metrics.profiles.WithLabelValues(d.config.Name, outcomeFailed).Add(n)
+}
+
+func (d *destination) push(tenantID string, push *pushv1.PushRequest) (err error) {
+	ctx, cancel := context.WithTimeout(d.sendCtx, d.config.Timeout)
+	defer cancel()
+	r := connect.NewRequest(push)
+	r.Header().Set("X-Scope-OrgID", tenantID)
+	if password := d.config.Password.String(); d.config.Username != "" || password != "" {
+		r.Header().Set("Authorization", basicAuth(d.config.Username, password))
+	}
+	for k, v := range d.config.Headers {
+		r.Header().Set(k, v)
+	}
+	start := time.Now()
+	_, err = d.client.Push(ctx, r)
+	status := "success"
+	if err != nil {
+		status = connect.CodeOf(err).String()
+	}
+	d.metrics.duration.WithLabelValues(d.config.Name, status).Observe(time.Since(start).Seconds())
+	return err
+}
+
+func (d *destination) pushRequest(req *distributormodel.PushRequest) (*pushv1.PushRequest, error) {
+	push := &pushv1.PushRequest{Series: make([]*pushv1.RawProfileSeries, 0, len(req.Series))}
+	for _, s := range req.Series {
+		series := &pushv1.RawProfileSeries{
+			Labels:  s.Labels,
+			Samples: make([]*pushv1.RawSample, 0, len(s.Samples)),
+		}
+		for _, p := range s.Samples {
+			var buf bytes.Buffer
+			if _, err := p.Profile.WriteTo(&buf); err != nil {
+				return nil, err
+			}
+			series.Samples = append(series.Samples, &pushv1.RawSample{
+				RawProfile: buf.Bytes(),
+				ID:         p.ID,
+			})
+		}
+		push.Series = append(push.Series, series)
+	}
+	return push, nil
+}
+
+// retryable reports whether the request may succeed if retried.
+func retryable(err error) bool {
+	switch connect.CodeOf(err) {
+	case connect.CodeUnavailable,
+		connect.CodeResourceExhausted,
+		connect.CodeDeadlineExceeded,
+		connect.CodeAborted,
+		connect.CodeInternal,
+		connect.CodeUnknown:
+		return !errors.Is(err, context.Canceled)
+	default:
+		return false
+	}
+}
+
+func countProfiles(req *distributormodel.PushRequest) (n int) {
+	for _, s := range req.Series {
+		n += len(s.Samples)
+	}
+	return n
+}
+
+func basicAuth(username, password string) string {
+	r := http.Request{Header: make(http.Header)}
+	r.SetBasicAuth(username, password)
+	return r.Header.Get("Authorization")
+}
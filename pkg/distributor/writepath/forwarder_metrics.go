@@ -0,0 +1,55 @@
+package writepath
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	outcomeSent    = "sent"
+	outcomeDropped = "dropped"
+	outcomeFailed  = "failed"
+)
+
+type forwarderMetrics struct {
+	queueLength *prometheus.GaugeVec
+	profiles    *prometheus.CounterVec
+	retries     *prometheus.CounterVec
+	duration    *prometheus.HistogramVec
+}
+
+func newForwarderMetrics(reg prometheus.Registerer) *forwarderMetrics {
+	m := &forwarderMetrics{
+		queueLength: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "pyroscope_distributor_forwarding_queue_length",
+			Help: "Number of requests queued for forwarding to the destination.",
+		}, []string{"destination"}),
+		profiles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pyroscope_distributor_forwarding_profiles_total",
+			Help: "Number of profiles forwarded to the destination, by outcome.",
+		}, []string{"destination", "outcome"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pyroscope_distributor_forwarding_retries_total",
+			Help: "Number of retried requests to the destination.",
+		}, []string{"destination"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "pyroscope_distributor_forwarding_request_duration_seconds",
+			Help: "Duration of requests made to the forwarding destination.",
+
+			Buckets:                         prometheus.ExponentialBucketsRange(0.001, 10, 30),
+			NativeHistogramBucketFactor:     1.1,
+			NativeHistogramMaxBucketNumber:  32,
+			NativeHistogramMinResetDuration: time.Hour,
+		}, []string{"destination", "status"}),
+	}
+	if reg != nil {
+		reg.MustRegister(
+			m.queueLength,
+			m.profiles,
+			m.retries,
+			m.duration,
+		)
+	}
+	return m
+}
@@ -0,0 +1,251 @@
+package writepath
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/grafana/dskit/backoff"
+	"github.com/grafana/dskit/flagext"
+	"github.com/grafana/dskit/services"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	pushv1 "github.com/grafana/pyroscope/api/gen/proto/go/push/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/push/v1/pushv1connect"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	distributormodel "github.com/grafana/pyroscope/pkg/distributor/model"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+type mockPusher struct {
+	mu       sync.Mutex
+	failures int
+	blocking bool
+	requests []*pushv1.PushRequest
+	headers  []http.Header
+}
+
+func (m *mockPusher) Push(ctx context.Context, req *connect.Request[pushv1.PushRequest]) (*connect.Response[pushv1.PushResponse], error) {
+	if m.blocking {
+		<-ctx.Done()
+		return nil, connect.NewError(connect.CodeCanceled, ctx.Err())
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		return nil, connect.NewError(connect.CodeUnavailable, nil)
+	}
+	m.requests = append(m.requests, req.Msg)
+	m.headers = append(m.headers, req.Header())
+	return connect.NewResponse(&pushv1.PushResponse{}), nil
+}
+
+func newTestPusherServer(t *testing.T, pusher *mockPusher) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.Handle(pushv1connect.NewPusherServiceHandler(pusher))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestForwardingConfig(destinations ...ForwardingDestination) ForwardingConfig {
+	return ForwardingConfig{
+		Destinations: destinations,
+		QueueSize:    10,
+		Concurrency:  1,
+		Timeout:      time.Second,
+		DrainTimeout: 5 * time.Second,
+		Backoff: backoff.Config{
+			MinBackoff: time.Millisecond,
+			MaxBackoff: time.Millisecond,
+			MaxRetries: 3,
+		},
+	}
+}
+
+func newTestPushRequest(tenantID string, series ...[]*typesv1.LabelPair) *distributormodel.PushRequest {
+	req := &distributormodel.PushRequest{TenantID: tenantID}
+	for _, ls := range series {
+		req.Series = append(req.Series, &distributormodel.ProfileSeries{
+			Labels: ls,
+			Samples: []*distributormodel.ProfileSample{{
+				Profile: &pprof.Profile{Profile: &profilev1.Profile{
+					StringTable: []string{"", "cpu", "nanoseconds"},
+					SampleType:  []*profilev1.ValueType{{Type: 1, Unit: 2}},
+				}},
+			}},
+		})
+	}
+	return req
+}
+
+func Test_Forwarder_RelabelsAndFiltersSeries(t *testing.T) {
+	pusher := new(mockPusher)
+	server := newTestPusherServer(t, pusher)
+	reg := prometheus.NewRegistry()
+	f := NewForwarder(log.NewLogfmtLogger(io.Discard), reg, newTestForwardingConfig(ForwardingDestination{
+		Name:     "remote",
+		URL:      server.URL,
+		TenantID: "remote-tenant",
+		Username: "user",
+		Password: flagext.SecretWithValue("pass"),
+		Headers:  map[string]string{"X-Custom": "value"},
+		Tenants:  []string{"tenant-a"},
+		RelabelRules: []*relabel.Config{
+			{
+				SourceLabels: []model.LabelName{"service_name"},
+				Regex:        relabel.MustNewRegexp("svc-a"),
+				Action:       relabel.Keep,
+			},
+			{
+				TargetLabel: "cluster",
+				Regex:       relabel.MustNewRegexp("(.*)"),
+				Replacement: "eu-west",
+				Action:      relabel.Replace,
+			},
+		},
+	}))
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), f.Service()))
+
+	req := newTestPushRequest("tenant-a",
+		phlaremodel.LabelsFromStrings("service_name", "svc-a"),
+		phlaremodel.LabelsFromStrings("service_name", "svc-b"),
+	)
+	f.Forward(req)
+	// The request is not retained by the forwarder.
+	req.Series[0].Labels = nil
+	req.Series[0].Samples = nil
+	// Not selected tenant.
+	f.Forward(newTestPushRequest("tenant-b", phlaremodel.LabelsFromStrings("service_name", "svc-a")))
+
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), f.Service()))
+
+	require.Len(t, pusher.requests, 1)
+	require.Len(t, pusher.requests[0].Series, 1)
+	s := pusher.requests[0].Series[0]
+	assert.Equal(t, `{cluster="eu-west", service_name="svc-a"}`, phlaremodel.LabelPairsString(s.Labels))
+	require.Len(t, s.Samples, 1)
+	p, err := pprof.RawFromBytes(s.Samples[0].RawProfile)
+	require.NoError(t, err)
+	assert.Equal(t, "cpu", p.StringTable[p.SampleType[0].Type])
+
+	h := pusher.headers[0]
+	assert.Equal(t, "remote-tenant", h.Get("X-Scope-OrgID"))
+	assert.Equal(t, "value", h.Get("X-Custom"))
+	assert.Equal(t, "Basic dXNlcjpwYXNz", h.Get("Authorization"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(f.metrics.profiles.WithLabelValues("remote", outcomeSent)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(f.metrics.queueLength.WithLabelValues("remote")))
+}
+
+func Test_Forwarder_Retries(t *testing.T) {
+	pusher := &mockPusher{failures: 2}
+	server := newTestPusherServer(t, pusher)
+	f := NewForwarder(log.NewLogfmtLogger(io.Discard), nil, newTestForwardingConfig(
+		ForwardingDestination{Name: "remote", URL: server.URL},
+	))
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), f.Service()))
+	f.Forward(newTestPushRequest("tenant-a", phlaremodel.LabelsFromStrings("service_name", "svc-a")))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(f.metrics.profiles.WithLabelValues("remote", outcomeSent)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), f.Service()))
+
+	require.Len(t, pusher.requests, 1)
+	assert.Equal(t, "tenant-a", pusher.headers[0].Get("X-Scope-OrgID"))
+	assert.Equal(t, float64(2), testutil.ToFloat64(f.metrics.retries.WithLabelValues("remote")))
+}
+
+func Test_Forwarder_GivesUpAfterMaxRetries(t *testing.T) {
+	pusher := &mockPusher{failures: 10}
+	server := newTestPusherServer(t, pusher)
+	f := NewForwarder(log.NewLogfmtLogger(io.Discard), nil, newTestForwardingConfig(
+		ForwardingDestination{Name: "remote", URL: server.URL},
+	))
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), f.Service()))
+	f.Forward(newTestPushRequest("tenant-a", phlaremodel.LabelsFromStrings("service_name", "svc-a")))
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(f.metrics.profiles.WithLabelValues("remote", outcomeFailed)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), f.Service()))
+	assert.Empty(t, pusher.requests)
+}
+
+func Test_Forwarder_DropsWhenQueueIsFull(t *testing.T) {
+	config := newTestForwardingConfig(ForwardingDestination{Name: "remote", URL: "http://localhost"})
+	config.QueueSize = 1
+	// The forwarder is not started: queued requests are not consumed.
+	f := NewForwarder(log.NewLogfmtLogger(io.Discard), nil, config)
+	for i := 0; i < 3; i++ {
+		f.Forward(newTestPushRequest("tenant-a", phlaremodel.LabelsFromStrings("service_name", "svc-a")))
+	}
+	assert.Equal(t, float64(1), testutil.ToFloat64(f.metrics.queueLength.WithLabelValues("remote")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(f.metrics.profiles.WithLabelValues("remote", outcomeDropped)))
+}
+
+func Test_Forwarder_DrainTimeout(t *testing.T) {
+	pusher := &mockPusher{blocking: true}
+	server := newTestPusherServer(t, pusher)
+	config := newTestForwardingConfig(ForwardingDestination{Name: "remote", URL: server.URL})
+	config.Timeout = time.Hour
+	config.DrainTimeout = 100 * time.Millisecond
+	f := NewForwarder(log.NewLogfmtLogger(io.Discard), nil, config)
+	require.NoError(t, services.StartAndAwaitRunning(context.Background(), f.Service()))
+	for i := 0; i < 5; i++ {
+		f.Forward(newTestPushRequest("tenant-a", phlaremodel.LabelsFromStrings("service_name", "svc-a")))
+	}
+
+	start := time.Now()
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), f.Service()))
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, float64(5), testutil.ToFloat64(f.metrics.profiles.WithLabelValues("remote", outcomeFailed)))
+}
+
+func Test_ForwardingConfig_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		destinations []ForwardingDestination
+		valid        bool
+	}{
+		{name: "empty", valid: true},
+		{
+			name:         "valid",
+			destinations: []ForwardingDestination{{Name: "a", URL: "http://a"}, {Name: "b", URL: "https://b"}},
+			valid:        true,
+		},
+		{name: "missing name", destinations: []ForwardingDestination{{URL: "http://a"}}},
+		{name: "duplicate name", destinations: []ForwardingDestination{{Name: "a", URL: "http://a"}, {Name: "a", URL: "http://b"}}},
+		{name: "invalid url", destinations: []ForwardingDestination{{Name: "a", URL: "a"}}},
+		{
+			name: "invalid rule",
+			destinations: []ForwardingDestination{{
+				Name: "a", URL: "http://a",
+				RelabelRules: []*relabel.Config{{Action: relabel.Replace}},
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := ForwardingConfig{Destinations: tc.destinations}
+			err := cfg.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -57,6 +57,7 @@ type Router struct {
 
 	ingester  IngesterClient
 	segwriter IngesterClient
+	forwarder *Forwarder
 }
 
 func NewRouter(
@@ -65,6 +66,7 @@ func NewRouter(
 	overrides Overrides,
 	ingester IngesterClient,
 	segwriter IngesterClient,
+	forwarder *Forwarder,
 ) *Router {
 	r := &Router{
 		logger:    logger,
@@ -72,6 +74,7 @@ func NewRouter(
 		metrics:   newMetrics(registerer),
 		ingester:  ingester,
 		segwriter: segwriter,
+		forwarder: forwarder,
 	}
 	r.service = services.NewBasicService(r.starting, r.running, r.stopping)
 	return r
@@ -93,6 +96,11 @@ func (m *Router) running(ctx context.Context) error {
 }
 
 func (m *Router) Send(ctx context.Context, req *distributormodel.PushRequest) error {
+	if m.forwarder != nil {
+		// The request is modified by the routes,
+		// therefore it must be forwarded first.
+		m.forwarder.Forward(req)
+	}
 	config := m.overrides.WritePathOverrides(req.TenantID)
 	switch config.WritePath {
 	case SegmentWriterPath:
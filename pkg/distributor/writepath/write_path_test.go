@@ -70,6 +70,7 @@ func (s *routerTestSuite) SetupTest() {
 		s.overrides,
 		s.ingester,
 		s.segwriter,
+		nil,
 	)
 }
 
@@ -0,0 +1,196 @@
+// Package dot renders call graphs of profiles in the DOT format.
+package dot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+
+	"connectrpc.com/connect"
+	"github.com/google/pprof/profile"
+	"golang.org/x/sync/errgroup"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/dot/graph"
+	"github.com/grafana/pyroscope/pkg/frontend/dot/report"
+)
+
+const (
+	DefaultMaxNodes = 100
+
+	// diffBaseLabel marks the samples of the base profile,
+	// as expected by the report.
+	diffBaseLabel = "pprof::base"
+)
+
+// SelectMergeProfileFunc returns the merged profile matching the request.
+type SelectMergeProfileFunc func(
+	context.Context,
+	*connect.Request[querierv1.SelectMergeProfileRequest],
+) (*connect.Response[profilev1.Profile], error)
+
+// SelectGraph selects the profiles with the given function,
+// and renders the call graph in the DOT format.
+func SelectGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectGraphRequest],
+	selectMergeProfile SelectMergeProfileFunc,
+) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	req := c.Msg
+	if req.Profile == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("profile selector is required"))
+	}
+	if req.Base != nil && req.Base.ProfileTypeID != req.Profile.ProfileTypeID {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("profile types must match"))
+	}
+	var p, base *profilev1.Profile
+	g, ctx := errgroup.WithContext(ctx)
+	selectProfile := func(dst **profilev1.Profile, r *querierv1.SelectMergeProfileRequest) func() error {
+		return func() error {
+			resp, err := selectMergeProfile(ctx, connect.NewRequest(r))
+			if err != nil {
+				return err
+			}
+			*dst = resp.Msg
+			return nil
+		}
+	}
+	g.Go(selectProfile(&p, req.Profile))
+	if req.Base != nil {
+		g.Go(selectProfile(&base, req.Base))
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := RenderDiff(&buf, base, p, OptionsFromProto(req.Options)); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&querierv1.SelectGraphResponse{Dot: buf.String()}), nil
+}
+
+// Options control the graph rendering.
+type Options struct {
+	// MaxNodes is the maximum number of nodes in the graph.
+	MaxNodes int
+	// NodeFraction and EdgeFraction specify the fraction of the
+	// total value below which nodes and edges are dropped.
+	NodeFraction float64
+	EdgeFraction float64
+	// Focus and Ignore are regular expressions matched against
+	// the function names: only samples with a frame matching Focus
+	// are kept, and samples with a frame matching Ignore are dropped.
+	Focus  string
+	Ignore string
+}
+
+func OptionsFromProto(o *querierv1.GraphOptions) Options {
+	return Options{
+		MaxNodes:     int(o.GetMaxNodes()),
+		NodeFraction: o.GetNodeFraction(),
+		EdgeFraction: o.GetEdgeFraction(),
+		Focus:        o.GetFocus(),
+		Ignore:       o.GetIgnore(),
+	}
+}
+
+// RenderDiff writes the call graph of the difference between the profile
+// and the base to w: the nodes and edges are colored by the delta. If the
+// base is empty, the graph of the profile is rendered.
+//
+// Nothing is written if both profiles are empty.
+func RenderDiff(w io.Writer, base, p *profilev1.Profile, o Options) error {
+	focus, err := compileRegexp("focus", o.Focus)
+	if err != nil {
+		return err
+	}
+	ignore, err := compileRegexp("ignore", o.Ignore)
+	if err != nil {
+		return err
+	}
+	profiles := make([]*profile.Profile, 0, 2)
+	if !isEmpty(p) {
+		x, err := convert(p)
+		if err != nil {
+			return err
+		}
+		profiles = append(profiles, x)
+	}
+	if !isEmpty(base) {
+		x, err := convert(base)
+		if err != nil {
+			return err
+		}
+		x.Scale(-1)
+		for _, s := range x.Sample {
+			if s.Label == nil {
+				s.Label = make(map[string][]string, 1)
+			}
+			s.Label[diffBaseLabel] = []string{"true"}
+		}
+		profiles = append(profiles, x)
+	}
+	if len(profiles) == 0 {
+		return nil
+	}
+	merged, err := profile.Merge(profiles)
+	if err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	var filters []string
+	if focus != nil || ignore != nil {
+		merged.FilterSamplesByName(focus, ignore, nil, nil)
+		if focus != nil {
+			filters = append(filters, "focus="+o.Focus)
+		}
+		if ignore != nil {
+			filters = append(filters, "ignore="+o.Ignore)
+		}
+	}
+
+	maxNodes := o.MaxNodes
+	if maxNodes <= 0 {
+		maxNodes = DefaultMaxNodes
+	}
+	rpt := report.NewDefault(merged, report.Options{
+		NodeCount:     maxNodes,
+		NodeFraction:  o.NodeFraction,
+		EdgeFraction:  o.EdgeFraction,
+		ActiveFilters: filters,
+		OutputUnit:    "minimum",
+	})
+	gr, cfg := report.GetDOT(rpt)
+	graph.ComposeDot(w, gr, &graph.DotAttributes{}, cfg)
+	return nil
+}
+
+func compileRegexp(name, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid %s expression: %w", name, err))
+	}
+	return r, nil
+}
+
+func isEmpty(p *profilev1.Profile) bool {
+	return p == nil || len(p.SampleType) == 0 || len(p.Sample) == 0
+}
+
+func convert(p *profilev1.Profile) (*profile.Profile, error) {
+	data, err := p.MarshalVT()
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	x, err := profile.ParseData(data)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInternal, err)
+	}
+	return x, nil
+}
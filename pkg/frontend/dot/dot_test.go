@@ -0,0 +1,96 @@
+package dot
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/require"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/pkg/pprof/testhelper"
+)
+
+func testProfile(a, b int64) *profilev1.Profile {
+	p := testhelper.NewProfileBuilder(0).CPUProfile()
+	p.ForStacktraceString("foo", "main").AddSamples(a)
+	p.ForStacktraceString("bar", "main").AddSamples(b)
+	return p.Profile
+}
+
+// nodeColor returns the color of the node with the given name.
+func nodeColor(t *testing.T, dot, name string) string {
+	t.Helper()
+	for _, line := range strings.Split(dot, "\n") {
+		if strings.Contains(line, `[label="`+name+`\n`) {
+			m := regexp.MustCompile(` color="(#[0-9a-f]{6})"`).FindStringSubmatch(line)
+			require.NotNil(t, m, line)
+			return m[1]
+		}
+	}
+	t.Fatalf("node %q not found:\n%s", name, dot)
+	return ""
+}
+
+func Test_RenderDiff(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderDiff(&buf, testProfile(100, 100), testProfile(300, 50), Options{}))
+	out := buf.String()
+	// foo got slower, and bar got faster.
+	require.True(t, strings.HasPrefix(nodeColor(t, out, "foo"), "#b2"))
+	require.True(t, strings.HasPrefix(nodeColor(t, out, "bar")[3:], "b2"))
+}
+
+func Test_RenderDiff_Options(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderDiff(&buf, nil, testProfile(100, 10), Options{Focus: "^foo$"}))
+	require.Contains(t, buf.String(), "focus=^foo$")
+	require.Contains(t, buf.String(), `label="foo\n`)
+	require.NotContains(t, buf.String(), `label="bar\n`)
+
+	buf.Reset()
+	require.NoError(t, RenderDiff(&buf, nil, testProfile(100, 10), Options{Ignore: "foo"}))
+	require.NotContains(t, buf.String(), `label="foo\n`)
+	require.Contains(t, buf.String(), `label="bar\n`)
+
+	buf.Reset()
+	require.NoError(t, RenderDiff(&buf, nil, testProfile(100, 10), Options{NodeFraction: 0.5}))
+	require.Contains(t, buf.String(), `label="foo\n`)
+	require.NotContains(t, buf.String(), `label="bar\n`)
+
+	err := RenderDiff(&buf, nil, testProfile(100, 10), Options{Focus: "("})
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func Test_RenderDiff_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, RenderDiff(&buf, &profilev1.Profile{}, nil, Options{}))
+	require.Empty(t, buf.String())
+}
+
+func Test_SelectGraph(t *testing.T) {
+	profiles := map[string]*profilev1.Profile{
+		`{service_name="base"}`:    testProfile(100, 100),
+		`{service_name="profile"}`: testProfile(300, 50),
+	}
+	selectMergeProfile := func(_ context.Context, c *connect.Request[querierv1.SelectMergeProfileRequest]) (*connect.Response[profilev1.Profile], error) {
+		return connect.NewResponse(profiles[c.Msg.LabelSelector]), nil
+	}
+
+	resp, err := SelectGraph(context.Background(), connect.NewRequest(&querierv1.SelectGraphRequest{
+		Profile: &querierv1.SelectMergeProfileRequest{ProfileTypeID: "cpu", LabelSelector: `{service_name="profile"}`},
+		Base:    &querierv1.SelectMergeProfileRequest{ProfileTypeID: "cpu", LabelSelector: `{service_name="base"}`},
+	}), selectMergeProfile)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(nodeColor(t, resp.Msg.Dot, "foo"), "#b2"))
+
+	_, err = SelectGraph(context.Background(), connect.NewRequest(&querierv1.SelectGraphRequest{
+		Profile: &querierv1.SelectMergeProfileRequest{ProfileTypeID: "cpu"},
+		Base:    &querierv1.SelectMergeProfileRequest{ProfileTypeID: "memory"},
+	}), selectMergeProfile)
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
@@ -49,6 +49,8 @@ func (m *mockLimits) MaxFlameGraphNodesMax(_ string) int {
 
 func (m *mockLimits) SymbolizerEnabled(s string) bool { return true }
 
+func (m *mockLimits) MaxHeatmapPoints(_ string) int { return 0 }
+
 type mockRoundTripper struct {
 	callback func(ctx context.Context, req *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error)
 }
@@ -0,0 +1,28 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+// SelectExemplars and SelectProfileByID are not supported: individual
+// profiles can only be selected by ID in the query backend.
+
+func (f *Frontend) SelectExemplars(
+	context.Context,
+	*connect.Request[querierv1.SelectExemplarsRequest],
+) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exemplar queries require the query backend"))
+}
+
+func (f *Frontend) SelectProfileByID(
+	context.Context,
+	*connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exemplar queries require the query backend"))
+}
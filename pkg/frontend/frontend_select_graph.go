@@ -0,0 +1,17 @@
+package frontend
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/dot"
+)
+
+func (f *Frontend) SelectGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectGraphRequest],
+) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	return dot.SelectGraph(ctx, c, f.SelectMergeProfile)
+}
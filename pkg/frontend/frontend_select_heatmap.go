@@ -0,0 +1,19 @@
+package frontend
+
+import (
+	"context"
+	"errors"
+
+	"connectrpc.com/connect"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+)
+
+// SelectHeatmap is not supported: heatmaps are built of the values of
+// individual profiles, which are only provided by the query backend.
+func (f *Frontend) SelectHeatmap(
+	context.Context,
+	*connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("heatmap queries require the query backend"))
+}
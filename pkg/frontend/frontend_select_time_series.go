@@ -51,6 +51,9 @@ func (f *Frontend) SelectSeries(
 		g.SetLimit(maxConcurrent)
 	}
 
+	// Intervals are aligned to the step, therefore each step is
+	// aggregated by exactly one querier, and summing the points
+	// does not affect the result, regardless of the aggregation.
 	m := phlaremodel.NewTimeSeriesMerger(true)
 	interval := validationutil.MaxDurationOrZeroPerTenant(tenantIDs, f.limits.QuerySplitDuration)
 	intervals := NewTimeIntervalIterator(time.UnixMilli(c.Msg.Start), time.UnixMilli(c.Msg.End), interval,
@@ -0,0 +1,62 @@
+package frontend
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/user"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+	"github.com/grafana/pyroscope/pkg/util/httpgrpc"
+)
+
+func Test_Frontend_SelectTopFunctions(t *testing.T) {
+	var calls atomic.Int64
+	frontend := Frontend{
+		limits: &mockLimits{},
+		GRPCRoundTripper: &mockRoundTripper{callback: func(ctx context.Context, req *httpgrpc.HTTPRequest) (*httpgrpc.HTTPResponse, error) {
+			return connectgrpc.HandleUnary[querierv1.SelectTopFunctionsRequest, querierv1.SelectTopFunctionsResponse](ctx, req, func(ctx context.Context, req *connect.Request[querierv1.SelectTopFunctionsRequest]) (*connect.Response[querierv1.SelectTopFunctionsResponse], error) {
+				// The limit must only be applied to the merged result.
+				if req.Msg.Limit != nil {
+					t.Errorf("unexpected limit in the split request: %d", req.Msg.GetLimit())
+				}
+				calls.Add(1)
+				return connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+					Functions: []*typesv1.TopFunction{
+						{Name: "foo", Self: 2, Total: 2},
+						{Name: "bar", Self: 1, Total: 3},
+					},
+					Total: 3,
+				}), nil
+			})
+		}},
+	}
+
+	ctx := user.InjectOrgID(context.Background(), "test")
+	_, ctx = opentracing.StartSpanFromContext(ctx, "test")
+	// The query range spans two split intervals.
+	end := time.Now().Add(-time.Hour).Truncate(time.Hour).Add(10 * time.Minute)
+	start := end.Add(-30 * time.Minute)
+
+	limit := int64(1)
+	resp, err := frontend.SelectTopFunctions(ctx, connect.NewRequest(&querierv1.SelectTopFunctionsRequest{
+		ProfileTypeID: "memory:inuse_space:bytes:space:byte",
+		LabelSelector: "{}",
+		Start:         start.UnixMilli(),
+		End:           end.UnixMilli(),
+		Limit:         &limit,
+	}))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), calls.Load())
+	require.Equal(t, &querierv1.SelectTopFunctionsResponse{
+		Functions: []*typesv1.TopFunction{{Name: "foo", Self: 4, Total: 4}},
+		Total:     6,
+	}, resp.Msg)
+}
@@ -2,6 +2,7 @@ package readpath
 
 import (
 	"context"
+	"fmt"
 	"slices"
 
 	"connectrpc.com/connect"
@@ -11,6 +12,7 @@ import (
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/dot"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/pprof"
 )
@@ -161,6 +163,61 @@ func (r *Router) SelectSeries(
 		})
 }
 
+func (r *Router) SelectTopFunctions(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectTopFunctionsRequest],
+) (*connect.Response[querierv1.SelectTopFunctionsResponse], error) {
+	limit := int(c.Msg.GetLimit())
+	return Query[querierv1.SelectTopFunctionsRequest, querierv1.SelectTopFunctionsResponse](ctx, r, c,
+		func(a, b *querierv1.SelectTopFunctionsRequest) {
+			// Similarly to SelectSeries, the limit must be
+			// applied after merging the results.
+			if a != nil && b != nil && limit > 0 {
+				a.Limit = nil
+				b.Limit = nil
+			}
+		},
+		func(a, b *querierv1.SelectTopFunctionsResponse) (*querierv1.SelectTopFunctionsResponse, error) {
+			m := phlaremodel.NewTopFunctionsMerger()
+			m.MergeTopFunctions(a.Total, a.Functions)
+			m.MergeTopFunctions(b.Total, b.Functions)
+			return &querierv1.SelectTopFunctionsResponse{
+				Functions: m.Top(limit),
+				Total:     m.Total(),
+			}, nil
+		})
+}
+
+func (r *Router) SelectHeatmap(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return QueryBackend[querierv1.SelectHeatmapRequest, querierv1.SelectHeatmapResponse](ctx, r, c)
+}
+
+func (r *Router) SelectExemplars(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectExemplarsRequest],
+) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	return QueryBackend[querierv1.SelectExemplarsRequest, querierv1.SelectExemplarsResponse](ctx, r, c)
+}
+
+func (r *Router) SelectProfileByID(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	resp, err := QueryBackend[querierv1.SelectProfileByIDRequest, profilev1.Profile](ctx, r, c)
+	if err != nil {
+		return nil, err
+	}
+	// The response is empty, if the time range precedes
+	// the query backend enablement: the profile can't be found.
+	if len(resp.Msg.Sample) == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("profile %s not found", c.Msg.ProfileId))
+	}
+	return resp, nil
+}
+
 func (r *Router) Diff(
 	ctx context.Context,
 	c *connect.Request[querierv1.DiffRequest],
@@ -196,6 +253,13 @@ func (r *Router) Diff(
 	return connect.NewResponse(&querierv1.DiffResponse{Flamegraph: diff}), nil
 }
 
+func (r *Router) SelectGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectGraphRequest],
+) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	return dot.SelectGraph(ctx, c, r.SelectMergeProfile)
+}
+
 // Stubs: these methods are not supposed to be implemented
 // and only needed to satisfy interfaces.
 
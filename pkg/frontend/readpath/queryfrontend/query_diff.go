@@ -8,6 +8,7 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
@@ -33,24 +34,132 @@ func (q *QueryFrontend) Diff(
 	c.Msg.Right.MaxNodes = &maxNodes
 
 	var left, right []byte
+	if q.symbolizationEnabled(tenantIDs) || !diffSharedQuery(c.Msg) {
+		// Symbolization is only supported for tree queries,
+		// therefore, the sides are queried separately. Sides
+		// that do not share the selector and time range are
+		// also queried separately: otherwise, the query would
+		// read the data in between, that neither side needs.
+		left, right, err = q.diffTrees(ctx, c.Msg)
+	} else {
+		left, right, err = q.diffQuery(ctx, tenantIDs, c.Msg, maxNodes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := phlaremodel.NewFlamegraphDiffFromBytes(left, right, maxNodes)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return connect.NewResponse(&querierv1.DiffResponse{Flamegraph: diff}), nil
+}
+
+func (q *QueryFrontend) diffTrees(
+	ctx context.Context,
+	req *querierv1.DiffRequest,
+) (left, right []byte, err error) {
 	g, ctx := errgroup.WithContext(ctx)
 	g.Go(func() error {
 		var leftErr error
-		left, leftErr = q.selectMergeStacktracesTree(ctx, connect.NewRequest(c.Msg.Left))
+		left, leftErr = q.selectMergeStacktracesTree(ctx, connect.NewRequest(req.Left))
 		return leftErr
 	})
 	g.Go(func() error {
 		var rightErr error
-		right, rightErr = q.selectMergeStacktracesTree(ctx, connect.NewRequest(c.Msg.Right))
+		right, rightErr = q.selectMergeStacktracesTree(ctx, connect.NewRequest(req.Right))
 		return rightErr
 	})
 	if err = g.Wait(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	return left, right, nil
+}
 
-	diff, err := phlaremodel.NewFlamegraphDiffFromBytes(left, right, maxNodes)
+// diffSharedQuery reports whether both sides can be selected with
+// a single query: the label selectors must be identical, and the time
+// ranges must overlap or be adjacent.
+func diffSharedQuery(req *querierv1.DiffRequest) bool {
+	l, r := req.Left, req.Right
+	return l.ProfileTypeID == r.ProfileTypeID &&
+		l.LabelSelector == r.LabelSelector &&
+		l.Start <= r.End && r.Start <= l.End
+}
+
+// diffQuery selects both sides within a single query: the trees
+// are truncated consistently, and the symbols are resolved once.
+func (q *QueryFrontend) diffQuery(
+	ctx context.Context,
+	tenantIDs []string,
+	req *querierv1.DiffRequest,
+	maxNodes int64,
+) (left, right []byte, err error) {
+	leftSelector, err := q.diffSelector(tenantIDs, req.Left)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightSelector, err := q.diffSelector(tenantIDs, req.Right)
+	if err != nil {
+		return nil, nil, err
+	}
+	if leftSelector == nil && rightSelector == nil {
+		return nil, nil, nil
+	}
+
+	query := &queryv1.QueryRequest{
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_DIFF,
+			Diff: &queryv1.DiffQuery{
+				MaxNodes: maxNodes,
+				Left:     leftSelector,
+				Right:    rightSelector,
+			},
+		}},
+	}
+	// The request must cover both sides. The sides share
+	// the label selector, see diffSharedQuery.
+	for _, s := range []*queryv1.DiffSelector{leftSelector, rightSelector} {
+		if s == nil {
+			continue
+		}
+		if query.LabelSelector == "" {
+			query.LabelSelector = s.LabelSelector
+			query.StartTime, query.EndTime = s.StartTime, s.EndTime
+		} else {
+			query.StartTime = min(query.StartTime, s.StartTime)
+			query.EndTime = max(query.EndTime, s.EndTime)
+		}
+	}
+
+	report, err := q.querySingle(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+	if report == nil {
+		return nil, nil, nil
+	}
+	return report.Diff.Left, report.Diff.Right, nil
+}
+
+// diffSelector returns nil, if the time range of the request is empty.
+func (q *QueryFrontend) diffSelector(
+	tenantIDs []string,
+	req *querierv1.SelectMergeStacktracesRequest,
+) (*queryv1.DiffSelector, error) {
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &req.Start, &req.End)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
-	return connect.NewResponse(&querierv1.DiffResponse{Flamegraph: diff}), nil
+	if empty {
+		return nil, nil
+	}
+	labelSelector, err := buildLabelSelectorWithProfileType(req.LabelSelector, req.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	return &queryv1.DiffSelector{
+		LabelSelector: labelSelector,
+		StartTime:     req.Start,
+		EndTime:       req.End,
+	}, nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/model"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/querybackend"
 	"github.com/grafana/pyroscope/pkg/querybackend/queryplan"
 )
 
@@ -80,10 +81,11 @@ func (q *QueryFrontend) Query(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	blocks, err := q.QueryMetadata(ctx, req)
+	md, err := q.queryMetadata(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	blocks := md.Blocks
 	if len(blocks) == 0 {
 		return new(queryv1.QueryResponse), nil
 	}
@@ -103,13 +105,16 @@ func (q *QueryFrontend) Query(
 	for i, originalQuery := range req.Query {
 		modifiedQueries[i] = originalQuery.CloneVT()
 
-		// If we need symbolization and this is a TREE query, convert it to PPROF
-		if shouldSymbolize && originalQuery.QueryType == queryv1.QueryType_QUERY_TREE {
+		// If we need symbolization and this is a TREE or TOP_FUNCTIONS
+		// query, convert it to PPROF
+		if shouldSymbolize && (originalQuery.QueryType == queryv1.QueryType_QUERY_TREE ||
+			originalQuery.QueryType == queryv1.QueryType_QUERY_TOP_FUNCTIONS) {
 			modifiedQueries[i].QueryType = queryv1.QueryType_QUERY_PPROF
 			modifiedQueries[i].Pprof = &queryv1.PprofQuery{
 				MaxNodes: 0,
 			}
 			modifiedQueries[i].Tree = nil
+			modifiedQueries[i].TopFunctions = nil
 		}
 	}
 
@@ -121,6 +126,8 @@ func (q *QueryFrontend) Query(
 		Options:       &queryv1.InvokeOptions{},
 		QueryPlan:     p,
 		Query:         modifiedQueries,
+		// Series deleted, but not yet removed from the blocks.
+		SeriesTombstones: md.SeriesTombstones,
 	})
 	if err != nil {
 		return nil, err
@@ -146,6 +153,17 @@ func (q *QueryFrontend) QueryMetadata(
 	ctx context.Context,
 	req *queryv1.QueryRequest,
 ) ([]*metastorev1.BlockMeta, error) {
+	md, err := q.queryMetadata(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return md.Blocks, nil
+}
+
+func (q *QueryFrontend) queryMetadata(
+	ctx context.Context,
+	req *queryv1.QueryRequest,
+) (*metastorev1.QueryMetadataResponse, error) {
 	tenants, err := tenant.TenantIDs(ctx)
 	if err != nil {
 		return nil, status.Error(codes.InvalidArgument, err.Error())
@@ -159,6 +177,7 @@ func (q *QueryFrontend) QueryMetadata(
 		StartTime: req.StartTime,
 		EndTime:   req.EndTime,
 		Labels:    []string{metadata.LabelNameUnsymbolized},
+		Step:      querybackend.QueryStep(req.Query).Milliseconds(),
 	}
 
 	// Delete all matchers but service_name with strict match. If no matchers
@@ -180,12 +199,7 @@ func (q *QueryFrontend) QueryMetadata(
 	}
 
 	query.Query = matchersToLabelSelector(matchers)
-	md, err := q.metadataQueryClient.QueryMetadata(ctx, query)
-	if err != nil {
-		return nil, err
-	}
-
-	return md.Blocks, nil
+	return q.metadataQueryClient.QueryMetadata(ctx, query)
 }
 
 // hasUnsymbolizedProfiles checks if a block has unsymbolized profiles
@@ -200,16 +214,10 @@ func (q *QueryFrontend) hasUnsymbolizedProfiles(block *metastorev1.BlockMeta) bo
 
 // shouldSymbolize determines if we should symbolize profiles based on tenant settings
 func (q *QueryFrontend) shouldSymbolize(tenants []string, blocks []*metastorev1.BlockMeta) bool {
-	if q.symbolizer == nil {
+	if !q.symbolizationEnabled(tenants) {
 		return false
 	}
 
-	for _, t := range tenants {
-		if !q.limits.SymbolizerEnabled(t) {
-			return false
-		}
-	}
-
 	for _, block := range blocks {
 		if q.hasUnsymbolizedProfiles(block) {
 			return true
@@ -219,6 +227,19 @@ func (q *QueryFrontend) shouldSymbolize(tenants []string, blocks []*metastorev1.
 	return false
 }
 
+// symbolizationEnabled reports whether all the tenants have symbolization enabled.
+func (q *QueryFrontend) symbolizationEnabled(tenants []string) bool {
+	if q.symbolizer == nil {
+		return false
+	}
+	for _, t := range tenants {
+		if !q.limits.SymbolizerEnabled(t) {
+			return false
+		}
+	}
+	return true
+}
+
 // processAndSymbolizeProfiles handles the symbolization of profiles from the response
 func (q *QueryFrontend) processAndSymbolizeProfiles(
 	ctx context.Context,
@@ -253,6 +274,20 @@ func (q *QueryFrontend) processAndSymbolizeProfiles(
 			r.Tree = &queryv1.TreeReport{Tree: treeBytes}
 			r.ReportType = queryv1.ReportType_REPORT_TREE
 			r.Pprof = nil
+		} else if i < len(originalQueries) && originalQueries[i].QueryType == queryv1.QueryType_QUERY_TOP_FUNCTIONS {
+			tree, err := model.NewTreeFromBackendProfile(&prof)
+			if err != nil {
+				return fmt.Errorf("failed to build tree: %w", err)
+			}
+			m := model.NewTopFunctionsMerger()
+			m.MergeTree(tree)
+			r.TopFunctions = &queryv1.TopFunctionsReport{
+				Query:     originalQueries[i].TopFunctions.CloneVT(),
+				Functions: m.Top(0),
+				Total:     m.Total(),
+			}
+			r.ReportType = queryv1.ReportType_REPORT_TOP_FUNCTIONS
+			r.Pprof = nil
 		} else {
 			symbolizedBytes, err := pprof.Marshal(&prof, true)
 			if err != nil {
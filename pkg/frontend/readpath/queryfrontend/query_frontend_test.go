@@ -4,17 +4,24 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"connectrpc.com/connect"
 
 	"github.com/go-kit/log"
 	"github.com/grafana/dskit/user"
+	"github.com/opentracing/opentracing-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block/metadata"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/tenant"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockfrontend"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockmetastorev1"
@@ -196,6 +203,102 @@ func TestQueryFrontendSymbolization(t *testing.T) {
 	}
 }
 
+func TestQueryFrontend_SelectTopFunctions_Symbolization(t *testing.T) {
+	mockLimits := mockfrontend.NewMockLimits(t)
+	mockLimits.On("MaxQueryLookback", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxQueryLength", "tenant1").Return(time.Duration(0))
+	mockLimits.On("SymbolizerEnabled", "tenant1").Return(true)
+
+	mockSymbolizer := mockqueryfrontend.NewMockSymbolizer(t)
+	mockSymbolizer.On("SymbolizePprof", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// Resolve addresses 0x1, 0x2, 0x3 to main, foo, and bar.
+			p := args.Get(1).(*profilev1.Profile)
+			p.StringTable = append(p.StringTable, "main", "foo", "bar")
+			for i, loc := range p.Location {
+				p.Function = append(p.Function, &profilev1.Function{
+					Id:   uint64(i + 1),
+					Name: int64(len(p.StringTable) - 3 + int(loc.Address) - 1),
+				})
+				loc.Line = []*profilev1.Line{{FunctionId: uint64(i + 1)}}
+			}
+		}).
+		Return(nil).
+		Once()
+
+	profile := &profilev1.Profile{
+		StringTable: []string{""},
+		Location: []*profilev1.Location{
+			{Id: 1, Address: 0x1},
+			{Id: 2, Address: 0x2},
+			{Id: 3, Address: 0x3},
+		},
+		Sample: []*profilev1.Sample{
+			{LocationId: []uint64{3, 2, 1}, Value: []int64{5}},
+			{LocationId: []uint64{2, 1}, Value: []int64{3}},
+			{LocationId: []uint64{3, 1}, Value: []int64{1}},
+		},
+	}
+	b, err := profile.MarshalVT()
+	require.NoError(t, err)
+
+	mockQueryBackend := mockqueryfrontend.NewMockQueryBackend(t)
+	mockQueryBackend.On("Invoke", mock.Anything, mock.MatchedBy(func(r *queryv1.InvokeRequest) bool {
+		// The top functions query is to be converted to pprof.
+		return len(r.Query) == 1 &&
+			r.Query[0].QueryType == queryv1.QueryType_QUERY_PPROF &&
+			r.Query[0].TopFunctions == nil
+	})).Return(&queryv1.InvokeResponse{
+		Reports: []*queryv1.Report{{
+			ReportType: queryv1.ReportType_REPORT_PPROF,
+			Pprof:      &queryv1.PprofReport{Pprof: b},
+		}},
+	}, nil).Once()
+
+	mockMetadataClient := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	mockMetadataClient.On("QueryMetadata", mock.Anything, mock.Anything).
+		Return(&metastorev1.QueryMetadataResponse{
+			Blocks: []*metastorev1.BlockMeta{{
+				Id:          "block_id",
+				Datasets:    []*metastorev1.Dataset{{Labels: []int32{1, 1, 2}}},
+				StringTable: []string{"", metadata.LabelNameUnsymbolized, "true"},
+			}},
+		}, nil).
+		Once()
+
+	qf := NewQueryFrontend(
+		log.NewNopLogger(),
+		mockLimits,
+		mockMetadataClient,
+		nil,
+		mockQueryBackend,
+		mockSymbolizer,
+	)
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant1")
+	_, ctx = opentracing.StartSpanFromContext(ctx, t.Name())
+	limit := int64(2)
+	resp, err := qf.SelectTopFunctions(ctx, connect.NewRequest(&querierv1.SelectTopFunctionsRequest{
+		ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+		LabelSelector: `{service_name="test-service"}`,
+		Start:         1,
+		End:           2,
+		Limit:         &limit,
+	}))
+	require.NoError(t, err)
+
+	// The limit is applied by the frontend, after the symbolization.
+	assert.Equal(t, &querierv1.SelectTopFunctionsResponse{
+		Functions: []*typesv1.TopFunction{
+			{Name: "bar", Self: 6, Total: 6},
+			{Name: "foo", Self: 3, Total: 8},
+		},
+		Total: 9,
+	}, resp.Msg)
+
+	mockMetadataClient.AssertExpectations(t)
+}
+
 func createProfile(t *testing.T) []byte {
 	t.Helper()
 
@@ -221,3 +324,221 @@ func createProfile(t *testing.T) []byte {
 	require.NoError(t, err)
 	return bytes
 }
+
+func TestQueryFrontend_SelectSeries_Aggregation(t *testing.T) {
+	mockLimits := mockfrontend.NewMockLimits(t)
+	mockLimits.On("MaxQueryLookback", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxQueryLength", "tenant1").Return(time.Duration(0))
+
+	// Quantiles can't be aggregated partially, therefore the
+	// query backend returns all the points of the series.
+	series := []*typesv1.Series{{
+		Points: []*typesv1.Point{
+			{Timestamp: 2000, Value: 3},
+			{Timestamp: 2000, Value: 1},
+			{Timestamp: 2000, Value: 2},
+			{Timestamp: 3000, Value: 5},
+		},
+	}}
+
+	mockQueryBackend := mockqueryfrontend.NewMockQueryBackend(t)
+	mockQueryBackend.On("Invoke", mock.Anything, mock.MatchedBy(func(r *queryv1.InvokeRequest) bool {
+		return len(r.Query) == 1 && r.Query[0].TimeSeries.Aggregation ==
+			typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P50
+	})).Return(&queryv1.InvokeResponse{
+		Reports: []*queryv1.Report{{
+			ReportType: queryv1.ReportType_REPORT_TIME_SERIES,
+			TimeSeries: &queryv1.TimeSeriesReport{TimeSeries: series},
+		}},
+	}, nil).Once()
+
+	mockMetadataClient := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	mockMetadataClient.On("QueryMetadata", mock.Anything, mock.Anything).
+		Return(&metastorev1.QueryMetadataResponse{
+			Blocks: []*metastorev1.BlockMeta{{Id: "block_id"}},
+		}, nil).
+		Once()
+
+	qf := NewQueryFrontend(
+		log.NewNopLogger(),
+		mockLimits,
+		mockMetadataClient,
+		nil,
+		mockQueryBackend,
+		nil,
+	)
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant1")
+	_, ctx = opentracing.StartSpanFromContext(ctx, t.Name())
+	aggregation := typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P50
+	resp, err := qf.SelectSeries(ctx, connect.NewRequest(&querierv1.SelectSeriesRequest{
+		ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+		LabelSelector: `{service_name="test-service"}`,
+		Start:         2000,
+		End:           3000,
+		Step:          1,
+		Aggregation:   &aggregation,
+	}))
+	require.NoError(t, err)
+	require.Len(t, resp.Msg.Series, 1)
+	points := resp.Msg.Series[0].Points
+	require.Len(t, points, 2)
+	assert.Equal(t, int64(2000), points[0].Timestamp)
+	assert.Equal(t, float64(2), points[0].Value)
+	assert.Equal(t, int64(3000), points[1].Timestamp)
+	assert.Equal(t, float64(5), points[1].Value)
+
+	mockMetadataClient.AssertExpectations(t)
+}
+
+func TestQueryFrontend_Diff(t *testing.T) {
+	mockLimits := mockfrontend.NewMockLimits(t)
+	mockLimits.On("MaxQueryLookback", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxQueryLength", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxFlameGraphNodesMax", "tenant1").Return(0)
+
+	left, right := new(phlaremodel.Tree), new(phlaremodel.Tree)
+	left.InsertStack(1, "main", "foo")
+	right.InsertStack(2, "main", "bar")
+
+	// Both sides share the selector and the time ranges are adjacent:
+	// they are selected with a single query covering both of the sides.
+	const profileType = `__profile_type__="process_cpu:cpu:nanoseconds:cpu:nanoseconds"`
+	mockQueryBackend := mockqueryfrontend.NewMockQueryBackend(t)
+	mockQueryBackend.On("Invoke", mock.Anything, mock.MatchedBy(func(r *queryv1.InvokeRequest) bool {
+		return len(r.Query) == 1 &&
+			r.Query[0].QueryType == queryv1.QueryType_QUERY_DIFF &&
+			r.LabelSelector == `{service_name="a",`+profileType+`}` &&
+			r.StartTime == 1000 && r.EndTime == 3000 &&
+			r.Query[0].Diff.MaxNodes == 16 &&
+			r.Query[0].Diff.Left.StartTime == 1000 && r.Query[0].Diff.Left.EndTime == 2000 &&
+			r.Query[0].Diff.Right.StartTime == 2000 && r.Query[0].Diff.Right.EndTime == 3000
+	})).Return(&queryv1.InvokeResponse{
+		Reports: []*queryv1.Report{{
+			ReportType: queryv1.ReportType_REPORT_DIFF,
+			Diff: &queryv1.DiffReport{
+				Left:  left.Bytes(0),
+				Right: right.Bytes(0),
+			},
+		}},
+	}, nil).Once()
+
+	mockMetadataClient := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	mockMetadataClient.On("QueryMetadata", mock.Anything, mock.Anything).
+		Return(&metastorev1.QueryMetadataResponse{
+			Blocks: []*metastorev1.BlockMeta{{Id: "block_id"}},
+		}, nil).
+		Once()
+
+	qf := NewQueryFrontend(
+		log.NewNopLogger(),
+		mockLimits,
+		mockMetadataClient,
+		nil,
+		mockQueryBackend,
+		nil,
+	)
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant1")
+	maxNodes := int64(16)
+	resp, err := qf.Diff(ctx, connect.NewRequest(&querierv1.DiffRequest{
+		Left: &querierv1.SelectMergeStacktracesRequest{
+			ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			LabelSelector: `{service_name="a"}`,
+			Start:         1000,
+			End:           2000,
+			MaxNodes:      &maxNodes,
+		},
+		Right: &querierv1.SelectMergeStacktracesRequest{
+			ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			LabelSelector: `{service_name="a"}`,
+			Start:         2000,
+			End:           3000,
+			MaxNodes:      &maxNodes,
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Msg.Flamegraph.LeftTicks)
+	assert.Equal(t, int64(2), resp.Msg.Flamegraph.RightTicks)
+	assert.Equal(t, []string{"total", "main", "foo", "bar"}, resp.Msg.Flamegraph.Names)
+
+	mockMetadataClient.AssertExpectations(t)
+}
+
+func TestQueryFrontend_Diff_SeparateQueries(t *testing.T) {
+	mockLimits := mockfrontend.NewMockLimits(t)
+	mockLimits.On("MaxQueryLookback", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxQueryLength", "tenant1").Return(time.Duration(0))
+	mockLimits.On("MaxFlameGraphNodesMax", "tenant1").Return(0)
+
+	left, right := new(phlaremodel.Tree), new(phlaremodel.Tree)
+	left.InsertStack(1, "main", "foo")
+	right.InsertStack(2, "main", "bar")
+
+	// The sides have distinct selectors and distant time ranges:
+	// each side is selected with its own query, so that the data
+	// in between is not read.
+	const profileType = `__profile_type__="process_cpu:cpu:nanoseconds:cpu:nanoseconds"`
+	mockQueryBackend := mockqueryfrontend.NewMockQueryBackend(t)
+	for _, side := range []struct {
+		selector   string
+		start, end int64
+		tree       *phlaremodel.Tree
+	}{
+		{`{service_name="a",` + profileType + `}`, 1000, 2000, left},
+		{`{service_name="b",` + profileType + `}`, 5000, 6000, right},
+	} {
+		mockQueryBackend.On("Invoke", mock.Anything, mock.MatchedBy(func(r *queryv1.InvokeRequest) bool {
+			return len(r.Query) == 1 &&
+				r.Query[0].QueryType == queryv1.QueryType_QUERY_TREE &&
+				r.LabelSelector == side.selector &&
+				r.StartTime == side.start && r.EndTime == side.end
+		})).Return(&queryv1.InvokeResponse{
+			Reports: []*queryv1.Report{{
+				ReportType: queryv1.ReportType_REPORT_TREE,
+				Tree:       &queryv1.TreeReport{Tree: side.tree.Bytes(0)},
+			}},
+		}, nil).Once()
+	}
+
+	mockMetadataClient := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	mockMetadataClient.On("QueryMetadata", mock.Anything, mock.Anything).
+		Return(&metastorev1.QueryMetadataResponse{
+			Blocks: []*metastorev1.BlockMeta{{Id: "block_id"}},
+		}, nil).
+		Twice()
+
+	qf := NewQueryFrontend(
+		log.NewNopLogger(),
+		mockLimits,
+		mockMetadataClient,
+		nil,
+		mockQueryBackend,
+		nil,
+	)
+
+	ctx := tenant.InjectTenantID(context.Background(), "tenant1")
+	_, ctx = opentracing.StartSpanFromContext(ctx, t.Name())
+	maxNodes := int64(16)
+	resp, err := qf.Diff(ctx, connect.NewRequest(&querierv1.DiffRequest{
+		Left: &querierv1.SelectMergeStacktracesRequest{
+			ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			LabelSelector: `{service_name="a"}`,
+			Start:         1000,
+			End:           2000,
+			MaxNodes:      &maxNodes,
+		},
+		Right: &querierv1.SelectMergeStacktracesRequest{
+			ProfileTypeID: "process_cpu:cpu:nanoseconds:cpu:nanoseconds",
+			LabelSelector: `{service_name="b"}`,
+			Start:         5000,
+			End:           6000,
+			MaxNodes:      &maxNodes,
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Msg.Flamegraph.LeftTicks)
+	assert.Equal(t, int64(2), resp.Msg.Flamegraph.RightTicks)
+
+	mockMetadataClient.AssertExpectations(t)
+}
@@ -0,0 +1,17 @@
+package queryfrontend
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/dot"
+)
+
+func (q *QueryFrontend) SelectGraph(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectGraphRequest],
+) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	return dot.SelectGraph(ctx, c, q.SelectMergeProfile)
+}
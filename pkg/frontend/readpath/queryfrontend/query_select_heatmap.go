@@ -0,0 +1,85 @@
+package queryfrontend
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	validationutil "github.com/grafana/pyroscope/pkg/util/validation"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (q *QueryFrontend) SelectHeatmap(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectHeatmapRequest],
+) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("step", c.Msg.Step).
+		SetTag("by", c.Msg.GroupBy).
+		SetTag("buckets", c.Msg.GetBuckets()).
+		SetTag("log_scale", c.Msg.LogScale).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	if c.Msg.Step <= 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("step must be positive"))
+	}
+	if c.Msg.GetBuckets() < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("buckets must not be negative"))
+	}
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &c.Msg.Start, &c.Msg.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	if empty {
+		return connect.NewResponse(&querierv1.SelectHeatmapResponse{}), nil
+	}
+
+	stepMs := time.Duration(c.Msg.Step * float64(time.Second)).Milliseconds()
+	start := c.Msg.Start - stepMs
+
+	labelSelector, err := buildLabelSelectorWithProfileType(c.Msg.LabelSelector, c.Msg.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     start,
+		EndTime:       c.Msg.End,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_HEATMAP,
+			Heatmap: &queryv1.HeatmapQuery{
+				GroupBy:   c.Msg.GetGroupBy(),
+				MaxPoints: int64(validationutil.SmallestPositiveNonZeroIntPerTenant(tenantIDs, q.limits.MaxHeatmapPoints)),
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return connect.NewResponse(&querierv1.SelectHeatmapResponse{}), nil
+	}
+	// The value buckets are shared by all the series.
+	series := report.Heatmap.Series
+	bounds := phlaremodel.HeatmapBounds(series, int(c.Msg.GetBuckets()), c.Msg.LogScale)
+	return connect.NewResponse(&querierv1.SelectHeatmapResponse{
+		Bounds: bounds,
+		Series: phlaremodel.NewHeatmap(series, bounds, start, stepMs),
+	}), nil
+}
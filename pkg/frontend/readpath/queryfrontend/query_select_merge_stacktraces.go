@@ -11,6 +11,7 @@ import (
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
 
@@ -63,6 +64,9 @@ func (q *QueryFrontend) selectMergeStacktracesTree(
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if err = symdb.ValidateStackTraceSelector(c.Msg.StackTraceSelector); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 	labelSelector, err := buildLabelSelectorWithProfileType(c.Msg.LabelSelector, c.Msg.ProfileTypeID)
 	if err != nil {
 		return nil, err
@@ -73,7 +77,10 @@ func (q *QueryFrontend) selectMergeStacktracesTree(
 		LabelSelector: labelSelector,
 		Query: []*queryv1.Query{{
 			QueryType: queryv1.QueryType_QUERY_TREE,
-			Tree:      &queryv1.TreeQuery{MaxNodes: maxNodes},
+			Tree: &queryv1.TreeQuery{
+				MaxNodes:           maxNodes,
+				StackTraceSelector: c.Msg.StackTraceSelector,
+			},
 		}},
 	})
 	if err != nil {
@@ -0,0 +1,85 @@
+package queryfrontend
+
+import (
+	"context"
+	"fmt"
+
+	"connectrpc.com/connect"
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/tenant"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/common/model"
+
+	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/pprof"
+	"github.com/grafana/pyroscope/pkg/validation"
+)
+
+func (q *QueryFrontend) SelectProfileByID(
+	ctx context.Context,
+	c *connect.Request[querierv1.SelectProfileByIDRequest],
+) (*connect.Response[profilev1.Profile], error) {
+	opentracing.SpanFromContext(ctx).
+		SetTag("start", model.Time(c.Msg.Start).Time().String()).
+		SetTag("end", model.Time(c.Msg.End).Time().String()).
+		SetTag("selector", c.Msg.LabelSelector).
+		SetTag("profile_id", c.Msg.ProfileId).
+		SetTag("profile_type", c.Msg.ProfileTypeID)
+
+	if _, err := uuid.Parse(c.Msg.ProfileId); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid profile id: %w", err))
+	}
+
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	empty, err := validation.SanitizeTimeRange(q.limits, tenantIDs, &c.Msg.Start, &c.Msg.End)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	notFound := connect.NewError(connect.CodeNotFound, fmt.Errorf("profile %s not found", c.Msg.ProfileId))
+	if empty {
+		return nil, notFound
+	}
+
+	labelSelector := c.Msg.LabelSelector
+	if labelSelector == "" {
+		labelSelector = "{}"
+	}
+	labelSelector, err = buildLabelSelectorWithProfileType(labelSelector, c.Msg.ProfileTypeID)
+	if err != nil {
+		return nil, err
+	}
+	// Profile timestamps have nanosecond precision: the range is extended
+	// to include the last millisecond, so that the exemplar timestamp can
+	// be used as both the start and the end of the range.
+	report, err := q.querySingle(ctx, &queryv1.QueryRequest{
+		StartTime:     c.Msg.Start,
+		EndTime:       c.Msg.End + 1,
+		LabelSelector: labelSelector,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_PPROF,
+			Pprof: &queryv1.PprofQuery{
+				ProfileIdSelector: []string{c.Msg.ProfileId},
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, notFound
+	}
+	var p profilev1.Profile
+	if err = pprof.Unmarshal(report.Pprof.Pprof, &p); err != nil {
+		return nil, err
+	}
+	if len(p.Sample) == 0 {
+		return nil, notFound
+	}
+
+	return connect.NewResponse(&p), nil
+}
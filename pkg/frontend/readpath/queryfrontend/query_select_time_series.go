@@ -11,6 +11,7 @@ import (
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/validation"
 )
@@ -53,9 +54,10 @@ func (q *QueryFrontend) SelectSeries(
 		Query: []*queryv1.Query{{
 			QueryType: queryv1.QueryType_QUERY_TIME_SERIES,
 			TimeSeries: &queryv1.TimeSeriesQuery{
-				Step:    c.Msg.GetStep(),
-				GroupBy: c.Msg.GetGroupBy(),
-				Limit:   c.Msg.GetLimit(),
+				Step:        c.Msg.GetStep(),
+				GroupBy:     c.Msg.GetGroupBy(),
+				Limit:       c.Msg.GetLimit(),
+				Aggregation: c.Msg.GetAggregation(),
 			},
 		}},
 	})
@@ -65,6 +67,18 @@ func (q *QueryFrontend) SelectSeries(
 	if report == nil {
 		return connect.NewResponse(&querierv1.SelectSeriesResponse{}), nil
 	}
-	series := phlaremodel.TopSeries(report.TimeSeries.TimeSeries, int(c.Msg.GetLimit()))
+	series := report.TimeSeries.TimeSeries
+	if aggregation := c.Msg.GetAggregation(); aggregation != typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM {
+		// The query backend only aggregates the points partially,
+		// if at all: the final aggregation is done here.
+		series = phlaremodel.RangeSeries(
+			phlaremodel.NewTimeSeriesMergeIterator(series),
+			start,
+			c.Msg.End,
+			stepMs,
+			&aggregation,
+		)
+	}
+	series = phlaremodel.TopSeries(series, int(c.Msg.GetLimit()))
 	return connect.NewResponse(&querierv1.SelectSeriesResponse{Series: series}), nil
 }
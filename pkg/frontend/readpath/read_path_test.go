@@ -230,6 +230,65 @@ func (s *routerTestSuite) Test_TimeSeries_Limit() {
 	s.Assert().Equal(expected, resp)
 }
 
+func (s *routerTestSuite) Test_TopFunctions_Limit() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(5, 0),
+	})
+
+	// The limit must not be applied to the partial results:
+	// "foo" is not in the top of either of them.
+	one := int64(1)
+	req := connect.NewRequest(&querierv1.SelectTopFunctionsRequest{Start: 10, End: 10000, Limit: &one})
+	expected := connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+		Functions: []*typesv1.TopFunction{{Name: "foo", Self: 6, Total: 6}},
+		Total:     17,
+	})
+
+	s.oldFrontend.On("SelectTopFunctions",
+		mock.Anything, connect.NewRequest(&querierv1.SelectTopFunctionsRequest{Start: 10, End: 4999})).
+		Return(connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+			Functions: []*typesv1.TopFunction{
+				{Name: "bar", Self: 4, Total: 4},
+				{Name: "foo", Self: 3, Total: 3},
+			},
+			Total: 7,
+		}), nil).Once()
+
+	s.newFrontend.On("SelectTopFunctions",
+		mock.Anything, connect.NewRequest(&querierv1.SelectTopFunctionsRequest{Start: 5000, End: 10000})).
+		Return(connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+			Functions: []*typesv1.TopFunction{
+				{Name: "baz", Self: 4, Total: 4},
+				{Name: "foo", Self: 3, Total: 3},
+				{Name: "qux", Self: 3, Total: 3},
+			},
+			Total: 10,
+		}), nil).Once()
+
+	resp, err := s.router.SelectTopFunctions(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
+func (s *routerTestSuite) Test_TopFunctions_Limit_NewFrontendOnly() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend: true,
+	})
+
+	one := int64(1)
+	req := connect.NewRequest(&querierv1.SelectTopFunctionsRequest{Start: 10, End: 10000, Limit: &one})
+	expected := connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+		Functions: []*typesv1.TopFunction{{Name: "foo", Self: 6, Total: 6}},
+		Total:     17,
+	})
+
+	s.newFrontend.On("SelectTopFunctions", mock.Anything, req).Return(expected, nil).Once()
+	resp, err := s.router.SelectTopFunctions(s.ctx, req)
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+}
+
 func (s *routerTestSuite) Test_TimeSeries_Limit_NewFrontendOnly() {
 	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
 		EnableQueryBackend: true,
@@ -300,3 +359,46 @@ func (s *routerTestSuite) Test_TimeSeries_NoLimit() {
 	s.Require().NoError(err)
 	s.Assert().Equal(expected, resp)
 }
+
+func (s *routerTestSuite) Test_QueryBackendOnly() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(20, 0),
+	})
+
+	expected := connect.NewResponse(&querierv1.SelectHeatmapResponse{
+		Bounds: []float64{0, 1},
+	})
+	// The time range preceding the split is not queried.
+	req := connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 20000, End: 40000})
+	s.newFrontend.On("SelectHeatmap", mock.Anything, req).Return(expected, nil).Once()
+
+	resp, err := s.router.SelectHeatmap(s.ctx, connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 10, End: 40000}))
+	s.Require().NoError(err)
+	s.Assert().Equal(expected, resp)
+
+	resp, err = s.router.SelectHeatmap(s.ctx, connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 10, End: 10000}))
+	s.Require().NoError(err)
+	s.Assert().Empty(resp.Msg.Series)
+}
+
+func (s *routerTestSuite) Test_QueryBackendOnly_ProfileByID_BeforeSplit() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{
+		EnableQueryBackend:     true,
+		EnableQueryBackendFrom: time.Unix(20, 0),
+	})
+
+	_, err := s.router.SelectProfileByID(s.ctx, connect.NewRequest(&querierv1.SelectProfileByIDRequest{
+		ProfileId: "0ce0e5b4-5e4b-4d3c-9b1a-4e0e1e5b2c3d",
+		Start:     10,
+		End:       10000,
+	}))
+	s.Assert().Equal(connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func (s *routerTestSuite) Test_QueryBackendOnly_Disabled() {
+	s.overrides.On("ReadPathOverrides", "tenant-a").Return(Config{EnableQueryBackend: false})
+
+	_, err := s.router.SelectHeatmap(s.ctx, connect.NewRequest(&querierv1.SelectHeatmapRequest{Start: 10, End: 40000}))
+	s.Assert().Equal(connect.CodeUnimplemented, connect.CodeOf(err))
+}
@@ -2,6 +2,7 @@ package readpath
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"connectrpc.com/connect"
@@ -128,6 +129,33 @@ func Query[Req, Resp any](
 	return connect.NewResponse(resp), nil
 }
 
+// QueryBackend routes a query that is only supported by the query
+// backend to the new query frontend. The part of the query time range
+// preceding the query backend enablement is not queried.
+func QueryBackend[Req, Resp any](
+	ctx context.Context,
+	router *Router,
+	req *connect.Request[Req],
+) (*connect.Response[Resp], error) {
+	tenantIDs, err := tenant.TenantIDs(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	overrides := router.overrides.ReadPathOverrides(tenantIDs[0])
+	if !overrides.EnableQueryBackend {
+		return nil, connect.NewError(connect.CodeUnimplemented, errors.New("the query requires the query backend"))
+	}
+	split := model.TimeFromUnixNano(overrides.EnableQueryBackendFrom.UnixNano())
+	queryRange := phlaremodel.GetSafeTimeRange(time.Now(), req.Msg)
+	if split.After(queryRange.End) {
+		return connect.NewResponse(new(Resp)), nil
+	}
+	if split.After(queryRange.Start) {
+		phlaremodel.SetTimeRange(req.Msg, split, queryRange.End)
+	}
+	return query[Req, Resp](ctx, router.newFrontend, req)
+}
+
 func query[Req, Resp any](
 	ctx context.Context,
 	svc querierv1connect.QuerierServiceClient,
@@ -159,8 +187,16 @@ func query[Req, Resp any](
 		resp, err = svc.SelectMergeProfile(ctx, r)
 	case *connect.Request[querierv1.SelectSeriesRequest]:
 		resp, err = svc.SelectSeries(ctx, r)
+	case *connect.Request[querierv1.SelectTopFunctionsRequest]:
+		resp, err = svc.SelectTopFunctions(ctx, r)
 	case *connect.Request[querierv1.DiffRequest]:
 		resp, err = svc.Diff(ctx, r)
+	case *connect.Request[querierv1.SelectHeatmapRequest]:
+		resp, err = svc.SelectHeatmap(ctx, r)
+	case *connect.Request[querierv1.SelectExemplarsRequest]:
+		resp, err = svc.SelectExemplars(ctx, r)
+	case *connect.Request[querierv1.SelectProfileByIDRequest]:
+		resp, err = svc.SelectProfileByID(ctx, r)
 
 	default:
 		return nil, connect.NewError(connect.CodeUnimplemented, nil)
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/oauth2"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+// GiteaClient returns a Gitea (or Forgejo) client. The base
+// URL is the Gitea instance URL, e.g. https://gitea.example.com.
+func GiteaClient(ctx context.Context, baseURL string, token *oauth2.Token, client *http.Client) (*giteaClient, error) {
+	return &giteaClient{
+		rest: &restClient{
+			baseURL:    strings.TrimRight(baseURL, "/") + "/api/v1",
+			token:      token,
+			httpClient: client,
+		},
+	}, nil
+}
+
+type giteaClient struct {
+	rest *restClient
+}
+
+type giteaContents struct {
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+	HTMLURL  string `json:"html_url"`
+}
+
+type giteaCommit struct {
+	SHA     string `json:"sha"`
+	HTMLURL string `json:"html_url"`
+	Commit  struct {
+		Message string `json:"message"`
+		Author  *struct {
+			Date time.Time `json:"date"`
+		} `json:"author"`
+	} `json:"commit"`
+	Author *struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatar_url"`
+	} `json:"author"`
+}
+
+func giteaRepoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+func (gt *giteaClient) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
+	var commit giteaCommit
+	path := giteaRepoPath(owner, repo) + "/git/commits/" + url.PathEscape(ref)
+	if err := gt.rest.get(ctx, path, &commit); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+	if commit.Commit.Message == "" {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("commit contains no message"))
+	}
+	if commit.Commit.Author == nil || commit.Commit.Author.Date.IsZero() {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("commit contains no date"))
+	}
+	commitInfo := &vcsv1.CommitInfo{
+		Sha:     commit.SHA,
+		Message: commit.Commit.Message,
+		Date:    commit.Commit.Author.Date.Format(time.RFC3339),
+		URL:     commit.HTMLURL,
+	}
+	if commit.Author != nil && commit.Author.Login != "" {
+		commitInfo.Author = &vcsv1.CommitAuthor{
+			Login:     commit.Author.Login,
+			AvatarURL: commit.Author.AvatarURL,
+		}
+	}
+	return commitInfo, nil
+}
+
+func (gt *giteaClient) GetFile(ctx context.Context, req FileRequest) (File, error) {
+	var file giteaContents
+	path := giteaRepoPath(req.Owner, req.Repo) +
+		"/contents/" + escapePath(strings.TrimLeft(req.Path, "/")) +
+		"?ref=" + url.QueryEscape(req.Ref)
+	if err := gt.rest.get(ctx, path, &file); err != nil {
+		return File{}, err
+	}
+	// We only support files retrieval.
+	if file.Type != "file" {
+		return File{}, connect.NewError(connect.CodeInvalidArgument, errors.New("path is not a file"))
+	}
+	content, err := decodeFileContent(file.Encoding, file.Content)
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Content: content,
+		URL:     file.HTMLURL,
+	}, nil
+}
+
+// escapePath escapes the path segments, preserving the separators.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+func TestGiteaClient_GetFile(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("package main"))
+	server := newTestServer(t, map[string]string{
+		"/api/v1/repos/owner/repo/contents/cmd/main.go?ref=main": `{
+			"type": "file",
+			"encoding": "base64",
+			"content": "` + content + `",
+			"html_url": "https://gitea.example.com/owner/repo/src/branch/main/cmd/main.go"
+		}`,
+		"/api/v1/repos/owner/repo/contents/cmd?ref=main": `{"type": "dir"}`,
+	})
+	c, err := GiteaClient(context.Background(), server.URL, testToken, server.Client())
+	require.NoError(t, err)
+
+	file, err := c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "/cmd/main.go", Ref: "main"})
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		Content: "package main",
+		URL:     "https://gitea.example.com/owner/repo/src/branch/main/cmd/main.go",
+	}, file)
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "cmd", Ref: "main"})
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "owner", Repo: "repo", Path: "missing.go", Ref: "main"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGiteaClient_GetCommit(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v1/repos/owner/repo/git/commits/abc123": `{
+			"sha": "abc123",
+			"html_url": "https://gitea.example.com/owner/repo/commit/abc123",
+			"commit": {
+				"message": "test commit message",
+				"author": {"name": "Test User", "date": "2024-01-01T00:00:00Z"}
+			},
+			"author": {"login": "test-user", "avatar_url": "https://example.com/avatar.png"}
+		}`,
+		"/api/v1/repos/owner/repo/git/commits/nodate": `{"sha": "nodate", "commit": {"message": "test commit message"}}`,
+	})
+	c, err := GiteaClient(context.Background(), server.URL, testToken, server.Client())
+	require.NoError(t, err)
+
+	commit, err := c.GetCommit(context.Background(), "owner", "repo", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, &vcsv1.CommitInfo{
+		Sha:     "abc123",
+		Message: "test commit message",
+		Author: &vcsv1.CommitAuthor{
+			Login:     "test-user",
+			AvatarURL: "https://example.com/avatar.png",
+		},
+		Date: "2024-01-01T00:00:00Z",
+		URL:  "https://gitea.example.com/owner/repo/commit/abc123",
+	}, commit)
+
+	_, err = c.GetCommit(context.Background(), "owner", "repo", "nodate")
+	assert.Equal(t, connect.CodeInternal, connect.CodeOf(err))
+
+	_, err = c.GetCommit(context.Background(), "owner", "repo", "missing")
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"connectrpc.com/connect"
+	"golang.org/x/oauth2"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+// GitlabClient returns a GitLab client. The base URL
+// is the GitLab instance URL, e.g. https://gitlab.com.
+func GitlabClient(ctx context.Context, baseURL string, token *oauth2.Token, client *http.Client) (*gitlabClient, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	return &gitlabClient{
+		baseURL: baseURL,
+		rest: &restClient{
+			baseURL:    baseURL + "/api/v4",
+			token:      token,
+			httpClient: client,
+		},
+	}, nil
+}
+
+type gitlabClient struct {
+	baseURL string
+	rest    *restClient
+}
+
+type gitlabFile struct {
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+type gitlabCommit struct {
+	ID           string    `json:"id"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"author_name"`
+	AuthoredDate time.Time `json:"authored_date"`
+	WebURL       string    `json:"web_url"`
+}
+
+// gitlabProjectPath returns the escaped project path; the owner
+// may include subgroups, e.g. "group/subgroup".
+func gitlabProjectPath(owner, repo string) string {
+	return "/projects/" + url.PathEscape(owner+"/"+repo)
+}
+
+func (gl *gitlabClient) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
+	var commit gitlabCommit
+	path := gitlabProjectPath(owner, repo) + "/repository/commits/" + url.PathEscape(ref)
+	if err := gl.rest.get(ctx, path, &commit); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, connect.NewError(connect.CodeNotFound, err)
+		}
+		return nil, err
+	}
+	if commit.Message == "" {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("commit contains no message"))
+	}
+	if commit.AuthoredDate.IsZero() {
+		return nil, connect.NewError(connect.CodeInternal, errors.New("commit contains no date"))
+	}
+	commitInfo := &vcsv1.CommitInfo{
+		Sha:     commit.ID,
+		Message: commit.Message,
+		Date:    commit.AuthoredDate.Format(time.RFC3339),
+		URL:     commit.WebURL,
+	}
+	if commit.AuthorName != "" {
+		commitInfo.Author = &vcsv1.CommitAuthor{Login: commit.AuthorName}
+	}
+	return commitInfo, nil
+}
+
+func (gl *gitlabClient) GetFile(ctx context.Context, req FileRequest) (File, error) {
+	filePath := strings.TrimLeft(req.Path, "/")
+	var file gitlabFile
+	path := gitlabProjectPath(req.Owner, req.Repo) +
+		"/repository/files/" + url.PathEscape(filePath) +
+		"?ref=" + url.QueryEscape(req.Ref)
+	if err := gl.rest.get(ctx, path, &file); err != nil {
+		return File{}, err
+	}
+	content, err := decodeFileContent(file.Encoding, file.Content)
+	if err != nil {
+		return File{}, err
+	}
+	return File{
+		Content: content,
+		URL:     gl.baseURL + "/" + req.Owner + "/" + req.Repo + "/-/blob/" + req.Ref + "/" + filePath,
+	}, nil
+}
+
+func decodeFileContent(encoding, content string) (string, error) {
+	switch encoding {
+	case "base64":
+		decoded, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return "", err
+		}
+		return string(decoded), nil
+	case "":
+		return content, nil
+	default:
+		return "", connect.NewError(connect.CodeInternal, errors.New("unsupported file encoding: "+encoding))
+	}
+}
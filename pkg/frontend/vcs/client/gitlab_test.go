@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+func newTestServer(t *testing.T, routes map[string]string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := routes[r.URL.RequestURI()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+var testToken = &oauth2.Token{AccessToken: "test-token"}
+
+func TestGitlabClient_GetFile(t *testing.T) {
+	content := base64.StdEncoding.EncodeToString([]byte("package main"))
+	server := newTestServer(t, map[string]string{
+		"/api/v4/projects/group%2Fsub%2Frepo/repository/files/cmd%2Fmain.go?ref=main": `{"encoding":"base64","content":"` + content + `"}`,
+	})
+	c, err := GitlabClient(context.Background(), server.URL+"/", testToken, server.Client())
+	require.NoError(t, err)
+
+	file, err := c.GetFile(context.Background(), FileRequest{Owner: "group/sub", Repo: "repo", Path: "/cmd/main.go", Ref: "main"})
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		Content: "package main",
+		URL:     server.URL + "/group/sub/repo/-/blob/main/cmd/main.go",
+	}, file)
+
+	_, err = c.GetFile(context.Background(), FileRequest{Owner: "group/sub", Repo: "repo", Path: "missing.go", Ref: "main"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestGitlabClient_GetCommit(t *testing.T) {
+	server := newTestServer(t, map[string]string{
+		"/api/v4/projects/group%2Frepo/repository/commits/abc123": `{
+			"id": "abc123",
+			"message": "test commit message",
+			"author_name": "Test User",
+			"authored_date": "2024-01-01T00:00:00.000+00:00",
+			"web_url": "https://gitlab.example.com/group/repo/-/commit/abc123"
+		}`,
+	})
+	c, err := GitlabClient(context.Background(), server.URL, testToken, server.Client())
+	require.NoError(t, err)
+
+	commit, err := c.GetCommit(context.Background(), "group", "repo", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, &vcsv1.CommitInfo{
+		Sha:     "abc123",
+		Message: "test commit message",
+		Author:  &vcsv1.CommitAuthor{Login: "Test User"},
+		Date:    "2024-01-01T00:00:00Z",
+		URL:     "https://gitlab.example.com/group/repo/-/commit/abc123",
+	}, commit)
+
+	_, err = c.GetCommit(context.Background(), "group", "repo", "heads/abc123")
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestGitlabClient_Unauthorized(t *testing.T) {
+	server := newTestServer(t, nil)
+	c, err := GitlabClient(context.Background(), server.URL, &oauth2.Token{AccessToken: "invalid"}, server.Client())
+	require.NoError(t, err)
+	_, err = c.GetCommit(context.Background(), "group", "repo", "abc123")
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"golang.org/x/oauth2"
+
+	"github.com/grafana/pyroscope/pkg/util/connectgrpc"
+)
+
+// restClient is a minimal client of a JSON REST API
+// authorized with an OAuth bearer token.
+type restClient struct {
+	baseURL    string
+	token      *oauth2.Token
+	httpClient *http.Client
+}
+
+// get fetches the resource at the path relative to the base URL and
+// decodes the response into v. If the resource is not found, an error
+// wrapping ErrNotFound is returned.
+func (c *restClient) get(ctx context.Context, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	c.token.SetAuthHeader(req)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, req.URL.Path)
+	case resp.StatusCode != http.StatusOK:
+		code := connectgrpc.HTTPToCode(int32(resp.StatusCode))
+		return connect.NewError(code, fmt.Errorf("request to %s failed: %s", req.URL.Path, resp.Status))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
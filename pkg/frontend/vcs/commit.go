@@ -11,7 +11,7 @@ import (
 
 const maxConcurrentRequests = 10
 
-type gitHubCommitGetter interface {
+type commitGetter interface {
 	GetCommit(context.Context, string, string, string) (*vcsv1.CommitInfo, error)
 }
 
@@ -22,7 +22,7 @@ type gitHubCommitGetter interface {
 // 3. An overall error if no commits were successfully fetched
 // This function provides partial success behavior, returning any commits
 // that were successfully fetched along with errors for those that failed.
-func getCommits(ctx context.Context, client gitHubCommitGetter, owner, repo string, refs []string) ([]*vcsv1.CommitInfo, []error, error) {
+func getCommits(ctx context.Context, client commitGetter, owner, repo string, refs []string) ([]*vcsv1.CommitInfo, []error, error) {
 	type result struct {
 		commit *vcsv1.CommitInfo
 		err    error
@@ -70,7 +70,7 @@ func getCommits(ctx context.Context, client gitHubCommitGetter, owner, repo stri
 
 // tryGetCommit attempts to retrieve a commit using different ref formats (commit hash, branch, tag).
 // It tries each format in order and returns the first successful result.
-func tryGetCommit(ctx context.Context, client gitHubCommitGetter, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
+func tryGetCommit(ctx context.Context, client commitGetter, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
 	refFormats := []string{
 		ref,            // Try as a commit hash
 		"heads/" + ref, // Try as a branch
@@ -13,11 +13,11 @@ import (
 	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
 )
 
-type gitHubCommitGetterMock struct {
+type commitGetterMock struct {
 	mock.Mock
 }
 
-func (m *gitHubCommitGetterMock) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
+func (m *commitGetterMock) GetCommit(ctx context.Context, owner, repo, ref string) (*vcsv1.CommitInfo, error) {
 	args := m.Called(ctx, owner, repo, ref)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -30,7 +30,7 @@ func TestGetCommits(t *testing.T) {
 	tests := []struct {
 		name            string
 		refs            []string
-		mockSetup       func(*gitHubCommitGetterMock)
+		mockSetup       func(*commitGetterMock)
 		expectedCommits int
 		expectedErrors  int
 		expectError     bool
@@ -38,7 +38,7 @@ func TestGetCommits(t *testing.T) {
 		{
 			name: "All commits succeed",
 			refs: []string{"ref1", "ref2"},
-			mockSetup: func(m *gitHubCommitGetterMock) {
+			mockSetup: func(m *commitGetterMock) {
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&vcsv1.CommitInfo{}, nil)
 			},
 			expectedCommits: 2,
@@ -48,7 +48,7 @@ func TestGetCommits(t *testing.T) {
 		{
 			name: "Partial fetch commits success",
 			refs: []string{"ref1", "ref2", "ref3"},
-			mockSetup: func(m *gitHubCommitGetterMock) {
+			mockSetup: func(m *commitGetterMock) {
 				// ref1 succeeds on first try
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, "ref1").Return(&vcsv1.CommitInfo{}, nil)
 				// ref2 fails on first try, succeeds with "heads/" prefix
@@ -66,7 +66,7 @@ func TestGetCommits(t *testing.T) {
 		{
 			name: "All commits fail to fetch",
 			refs: []string{"ref1", "ref2"},
-			mockSetup: func(m *gitHubCommitGetterMock) {
+			mockSetup: func(m *commitGetterMock) {
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
 			},
 			expectedCommits: 0,
@@ -77,7 +77,7 @@ func TestGetCommits(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockGetter := new(gitHubCommitGetterMock)
+			mockGetter := new(commitGetterMock)
 			tt.mockSetup(mockGetter)
 
 			commits, failedFetches, err := getCommits(context.Background(), mockGetter, "owner", "repo", tt.refs)
@@ -99,13 +99,13 @@ func TestGetCommits(t *testing.T) {
 func TestTryGetCommit(t *testing.T) {
 	tests := []struct {
 		name      string
-		setupMock func(*gitHubCommitGetterMock)
+		setupMock func(*commitGetterMock)
 		ref       string
 		wantErr   bool
 	}{
 		{
 			name: "Direct commit hash",
-			setupMock: func(m *gitHubCommitGetterMock) {
+			setupMock: func(m *commitGetterMock) {
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&vcsv1.CommitInfo{}, nil)
 			},
 			ref:     "abcdef",
@@ -113,7 +113,7 @@ func TestTryGetCommit(t *testing.T) {
 		},
 		{
 			name: "Branch reference with heads prefix",
-			setupMock: func(m *gitHubCommitGetterMock) {
+			setupMock: func(m *commitGetterMock) {
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, "main").Return(nil, errors.New("not found"))
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, "heads/main").Return(&vcsv1.CommitInfo{}, nil)
 			},
@@ -122,7 +122,7 @@ func TestTryGetCommit(t *testing.T) {
 		},
 		{
 			name: "Tag reference with tags prefix",
-			setupMock: func(m *gitHubCommitGetterMock) {
+			setupMock: func(m *commitGetterMock) {
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, assert.AnError).Times(2)
 				m.On("GetCommit", mock.Anything, mock.Anything, mock.Anything, "tags/v1").Return(&vcsv1.CommitInfo{}, nil).Times(1)
@@ -132,7 +132,7 @@ func TestTryGetCommit(t *testing.T) {
 		},
 		{
 			name: "GitHub API returns not found error",
-			setupMock: func(m *gitHubCommitGetterMock) {
+			setupMock: func(m *commitGetterMock) {
 				notFoundErr := &github.ErrorResponse{
 					Response: &http.Response{StatusCode: http.StatusNotFound},
 				}
@@ -146,7 +146,7 @@ func TestTryGetCommit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			mockGetter := new(gitHubCommitGetterMock)
+			mockGetter := new(commitGetterMock)
 			tt.setupMock(mockGetter)
 
 			commit, err := tryGetCommit(context.Background(), mockGetter, "owner", "repo", tt.ref)
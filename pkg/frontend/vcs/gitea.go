@@ -0,0 +1,39 @@
+package vcs
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+// Default lifetime of a Gitea refresh token
+// (REFRESH_TOKEN_EXPIRATION_TIME setting).
+const giteaRefreshExpiryDuration = 730 * time.Hour
+
+var (
+	giteaURL             = os.Getenv("GITEA_URL")
+	giteaAppClientID     = os.Getenv("GITEA_CLIENT_ID")
+	giteaAppClientSecret = os.Getenv("GITEA_CLIENT_SECRET")
+)
+
+// newGiteaProvider returns a provider for the Gitea (or Forgejo)
+// instance at the base URL, e.g. https://gitea.example.com.
+func newGiteaProvider(baseURL, clientID, clientSecret string) *oauthProvider {
+	return &oauthProvider{
+		providerName: "gitea",
+		baseURL:      baseURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		authPath:     "/login/oauth/authorize",
+		tokenPath:    "/login/oauth/access_token",
+		refreshTTL:   giteaRefreshExpiryDuration,
+		newClient: func(ctx context.Context, baseURL string, token *oauth2.Token, httpClient *http.Client) (vcsClient, error) {
+			return client.GiteaClient(ctx, baseURL, token, httpClient)
+		},
+	}
+}
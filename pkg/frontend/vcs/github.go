@@ -8,10 +8,16 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"time"
 
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/kubescape/go-git-url/apis"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/endpoints"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/source"
 )
 
 const (
@@ -167,3 +173,45 @@ func isGitHubIntegrationConfigured() error {
 
 	return errors.Join(errs...)
 }
+
+// githubProvider implements the provider interface for GitHub.
+type githubProvider struct{}
+
+func (githubProvider) name() string { return apis.ProviderGitHub.String() }
+
+func (githubProvider) matches(host string) bool { return strings.EqualFold(host, "github.com") }
+
+func (githubProvider) configured() error { return isGitHubIntegrationConfigured() }
+
+func (githubProvider) repository(repositoryURL string) (source.Repository, error) {
+	return giturl.NewGitURL(repositoryURL)
+}
+
+func (githubProvider) oauthConfig(redirectURI string) (*oauth2.Config, error) {
+	cfg, err := githubOAuthConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.RedirectURL = redirectURI
+	return cfg, nil
+}
+
+func (githubProvider) refreshToken(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (*oauth2.Token, error) {
+	req, err := buildGithubRefreshRequest(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	githubToken, err := refreshGithubToken(req, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return githubToken.toOAuthToken(), nil
+}
+
+func (githubProvider) refreshTokenExpiry() time.Duration { return githubRefreshExpiryDuration }
+
+func (githubProvider) client(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (vcsClient, error) {
+	return client.GithubClient(ctx, token, httpClient)
+}
+
+func (githubProvider) cookieName() string { return sessionCookieName }
@@ -0,0 +1,49 @@
+package vcs
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+const (
+	gitlabDefaultURL = "https://gitlab.com"
+
+	// GitLab refresh tokens do not expire, but are revoked once
+	// used. We assume the same lifetime as for GitHub tokens.
+	gitlabRefreshExpiryDuration = githubRefreshExpiryDuration
+)
+
+var (
+	gitlabURL             = envOrDefault("GITLAB_URL", gitlabDefaultURL)
+	gitlabAppClientID     = os.Getenv("GITLAB_CLIENT_ID")
+	gitlabAppClientSecret = os.Getenv("GITLAB_CLIENT_SECRET")
+)
+
+// newGitlabProvider returns a provider for the GitLab instance
+// at the base URL, e.g. https://gitlab.com.
+func newGitlabProvider(baseURL, clientID, clientSecret string) *oauthProvider {
+	return &oauthProvider{
+		providerName: "gitlab",
+		baseURL:      baseURL,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		authPath:     "/oauth/authorize",
+		tokenPath:    "/oauth/token",
+		refreshTTL:   gitlabRefreshExpiryDuration,
+		newClient: func(ctx context.Context, baseURL string, token *oauth2.Token, httpClient *http.Client) (vcsClient, error) {
+			return client.GitlabClient(ctx, baseURL, token, httpClient)
+		},
+	}
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
@@ -0,0 +1,200 @@
+package vcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/source"
+)
+
+// provider abstracts the OAuth flow and the API of a VCS provider.
+type provider interface {
+	// name returns the provider name, e.g. "github".
+	name() string
+	// matches reports whether the provider hosts repositories of the host.
+	matches(host string) bool
+	// configured returns an error if the integration is not configured.
+	configured() error
+	// repository parses the repository URL.
+	repository(repositoryURL string) (source.Repository, error)
+	oauthConfig(redirectURI string) (*oauth2.Config, error)
+	refreshToken(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (*oauth2.Token, error)
+	// refreshTokenExpiry returns the lifetime of a refresh token.
+	refreshTokenExpiry() time.Duration
+	client(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (vcsClient, error)
+	// cookieName returns the name of the cookie the session token is stored in.
+	cookieName() string
+}
+
+type vcsClient interface {
+	source.VCSClient
+	commitGetter
+}
+
+// providerFor returns the provider of the repository.
+func (q *Service) providerFor(repositoryURL string) (provider, error) {
+	host, err := repositoryHost(repositoryURL)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range q.providers {
+		if p.matches(host) {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported repository host: %s", host)
+}
+
+// repositoryHost returns the host name of the repository URL, which
+// may be an HTTP(S) URL, or an SCP-like SSH address (git@host:path).
+func repositoryHost(repositoryURL string) (string, error) {
+	u, err := parseRepositoryURL(repositoryURL)
+	if err != nil {
+		return "", err
+	}
+	return u.Hostname(), nil
+}
+
+func parseRepositoryURL(repositoryURL string) (*url.URL, error) {
+	s := strings.TrimSpace(repositoryURL)
+	if s == "" {
+		return nil, errors.New("repository URL is empty")
+	}
+	if !strings.Contains(s, "://") {
+		if at := strings.Index(s, "@"); at >= 0 {
+			// git@host:owner/repo.git
+			s = "ssh://" + strings.Replace(s[at+1:], ":", "/", 1)
+		} else {
+			s = "https://" + s
+		}
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	if u.Hostname() == "" {
+		return nil, fmt.Errorf("invalid repository URL: %s", repositoryURL)
+	}
+	return u, nil
+}
+
+// repository is a repository hosted by a provider
+// that supports nested groups, e.g. GitLab.
+type repository struct {
+	host  string
+	owner string
+	repo  string
+}
+
+func (r repository) GetHostName() string  { return r.host }
+func (r repository) GetOwnerName() string { return r.owner }
+func (r repository) GetRepoName() string  { return r.repo }
+
+// parseRepository parses the repository URL. The last path segment is
+// the repository name, and the preceding ones are the owner. Anything
+// after the "/-/" separator (e.g. "/-/tree/main") is ignored.
+func parseRepository(repositoryURL string) (repository, error) {
+	u, err := parseRepositoryURL(repositoryURL)
+	if err != nil {
+		return repository{}, err
+	}
+	p := u.Path
+	if i := strings.Index(p, "/-/"); i >= 0 {
+		p = p[:i]
+	}
+	p = strings.TrimSuffix(strings.Trim(p, "/"), ".git")
+	i := strings.LastIndex(p, "/")
+	if i <= 0 || i == len(p)-1 {
+		return repository{}, fmt.Errorf("invalid repository URL: %s", repositoryURL)
+	}
+	return repository{
+		host:  u.Hostname(),
+		owner: p[:i],
+		repo:  p[i+1:],
+	}, nil
+}
+
+// oauthProvider implements the standard OAuth 2.0 flow
+// for self-hosted providers, such as GitLab and Gitea.
+type oauthProvider struct {
+	providerName string
+	baseURL      string
+	clientID     string
+	clientSecret string
+	authPath     string
+	tokenPath    string
+	refreshTTL   time.Duration
+	newClient    func(ctx context.Context, baseURL string, token *oauth2.Token, httpClient *http.Client) (vcsClient, error)
+}
+
+func (p *oauthProvider) name() string { return p.providerName }
+
+func (p *oauthProvider) matches(host string) bool {
+	if p.baseURL == "" {
+		return false
+	}
+	u, err := url.Parse(p.baseURL)
+	return err == nil && strings.EqualFold(u.Hostname(), host)
+}
+
+func (p *oauthProvider) configured() error {
+	var errs []error
+	if p.baseURL == "" {
+		errs = append(errs, fmt.Errorf("missing %s base URL", p.providerName))
+	}
+	if p.clientID == "" {
+		errs = append(errs, fmt.Errorf("missing %s client ID", p.providerName))
+	}
+	if p.clientSecret == "" {
+		errs = append(errs, fmt.Errorf("missing %s client secret", p.providerName))
+	}
+	if len(githubSessionSecret) == 0 {
+		errs = append(errs, fmt.Errorf("missing %s environment variable", envVarGithubSessionSecret))
+	}
+	return errors.Join(errs...)
+}
+
+func (p *oauthProvider) repository(repositoryURL string) (source.Repository, error) {
+	return parseRepository(repositoryURL)
+}
+
+func (p *oauthProvider) oauthConfig(redirectURI string) (*oauth2.Config, error) {
+	if p.clientID == "" || p.clientSecret == "" {
+		return nil, fmt.Errorf("%s integration is not configured", p.providerName)
+	}
+	baseURL := strings.TrimRight(p.baseURL, "/")
+	return &oauth2.Config{
+		ClientID:     p.clientID,
+		ClientSecret: p.clientSecret,
+		RedirectURL:  redirectURI,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  baseURL + p.authPath,
+			TokenURL: baseURL + p.tokenPath,
+		},
+	}, nil
+}
+
+func (p *oauthProvider) refreshToken(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (*oauth2.Token, error) {
+	cfg, err := p.oauthConfig("")
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	// The token source refreshes the token, as it has no access token.
+	return cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: token.RefreshToken}).Token()
+}
+
+func (p *oauthProvider) refreshTokenExpiry() time.Duration { return p.refreshTTL }
+
+func (p *oauthProvider) client(ctx context.Context, token *oauth2.Token, httpClient *http.Client) (vcsClient, error) {
+	return p.newClient(ctx, p.baseURL, token, httpClient)
+}
+
+func (p *oauthProvider) cookieName() string { return sessionCookieName + "_" + p.providerName }
@@ -0,0 +1,180 @@
+package vcs
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+func Test_parseRepository(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    repository
+		wantErr bool
+	}{
+		{url: "https://gitlab.com/group/repo", want: repository{host: "gitlab.com", owner: "group", repo: "repo"}},
+		{url: "https://gitlab.example.com/group/sub/repo.git", want: repository{host: "gitlab.example.com", owner: "group/sub", repo: "repo"}},
+		{url: "https://gitlab.example.com/group/repo/-/tree/main/pkg", want: repository{host: "gitlab.example.com", owner: "group", repo: "repo"}},
+		{url: "git@gitea.example.com:owner/repo.git", want: repository{host: "gitea.example.com", owner: "owner", repo: "repo"}},
+		{url: "gitea.example.com:3000/owner/repo", want: repository{host: "gitea.example.com", owner: "owner", repo: "repo"}},
+		{url: "https://gitlab.com/repo", wantErr: true},
+		{url: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := parseRepository(tt.url)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newTestService(providers ...provider) *Service {
+	return &Service{
+		logger:     log.NewNopLogger(),
+		httpClient: http.DefaultClient,
+		providers:  providers,
+	}
+}
+
+func Test_providerFor(t *testing.T) {
+	svc := newTestService(
+		githubProvider{},
+		newGitlabProvider(gitlabDefaultURL, "", ""),
+		newGiteaProvider("https://git.example.com", "", ""),
+	)
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://github.com/grafana/pyroscope", want: "github"},
+		{url: "https://gitlab.com/group/repo", want: "gitlab"},
+		{url: "git@git.example.com:owner/repo.git", want: "gitea"},
+		{url: "https://bitbucket.org/owner/repo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			p, err := svc.providerFor(tt.url)
+			if tt.want == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.name())
+		})
+	}
+}
+
+func Test_oauthProvider_refreshToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/oauth/token", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "old_refresh_token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"new_access_token","token_type":"Bearer","refresh_token":"new_refresh_token","expires_in":7200}`))
+	}))
+	defer server.Close()
+
+	p := newGitlabProvider(server.URL, "client_id", "client_secret")
+	token, err := p.refreshToken(newTestContext(), &oauth2.Token{RefreshToken: "old_refresh_token"}, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "new_access_token", token.AccessToken)
+	assert.Equal(t, "new_refresh_token", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(2*time.Hour), token.Expiry, time.Minute)
+}
+
+func TestService_ProviderApp(t *testing.T) {
+	githubSessionSecret = []byte("16_byte_key_XXXX")
+	svc := newTestService(
+		newGitlabProvider("https://gitlab.example.com/", "client_id", "client_secret"),
+		newGiteaProvider("https://gitea.example.com", "", ""),
+	)
+
+	res, err := svc.ProviderApp(newTestContext(), connect.NewRequest(&vcsv1.ProviderAppRequest{
+		RepositoryUrl: "https://gitlab.example.com/group/repo",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &vcsv1.ProviderAppResponse{
+		Provider:     "gitlab",
+		ClientId:     "client_id",
+		AuthorizeUrl: "https://gitlab.example.com/oauth/authorize",
+		CookieName:   "pyroscope_git_session_gitlab",
+	}, res.Msg)
+
+	_, err = svc.ProviderApp(newTestContext(), connect.NewRequest(&vcsv1.ProviderAppRequest{
+		RepositoryUrl: "https://gitea.example.com/owner/repo",
+	}))
+	assert.Equal(t, connect.CodeUnimplemented, connect.CodeOf(err))
+
+	_, err = svc.ProviderApp(newTestContext(), connect.NewRequest(&vcsv1.ProviderAppRequest{
+		RepositoryUrl: "https://unknown.example.com/owner/repo",
+	}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestService_GetFile_Gitea(t *testing.T) {
+	githubSessionSecret = []byte("16_byte_key_XXXX")
+	ctx := newTestContext()
+	content := base64.StdEncoding.EncodeToString([]byte("print('hello')"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer my_access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/api/v1/repos/owner/repo/contents/app/main.py" || r.URL.Query().Get("ref") != "main" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type":"file","encoding":"base64","content":"` + content + `","html_url":"https://gitea/main.py"}`))
+	}))
+	defer server.Close()
+
+	p := newGiteaProvider(server.URL, "client_id", "client_secret")
+	svc := newTestService(p)
+	svc.httpClient = server.Client()
+
+	derivedKey, err := deriveEncryptionKeyForContext(ctx)
+	require.NoError(t, err)
+	cookie := testEncodeCookie(t, derivedKey, &oauth2.Token{
+		AccessToken: "my_access_token",
+		Expiry:      time.Now().Add(time.Hour),
+	})
+	cookie.Name = p.cookieName()
+
+	req := connect.NewRequest(&vcsv1.GetFileRequest{
+		RepositoryURL: server.URL + "/owner/repo",
+		Ref:           "main",
+		LocalPath:     "/opt/service/app/main.py",
+	})
+	req.Header().Add("Cookie", cookie.String())
+	res, err := svc.GetFile(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitea/main.py", res.Msg.URL)
+	decoded, err := base64.StdEncoding.DecodeString(res.Msg.Content)
+	require.NoError(t, err)
+	assert.Equal(t, "print('hello')", string(decoded))
+
+	// The GitHub session cookie is not accepted.
+	cookie.Name = sessionCookieName
+	req = connect.NewRequest(&vcsv1.GetFileRequest{RepositoryURL: server.URL + "/owner/repo"})
+	req.Header().Add("Cookie", cookie.String())
+	_, err = svc.GetFile(ctx, req)
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(err))
+}
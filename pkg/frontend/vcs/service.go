@@ -9,8 +9,6 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
-	giturl "github.com/kubescape/go-git-url"
-	"github.com/kubescape/go-git-url/apis"
 	"github.com/prometheus/client_golang/prometheus"
 	"golang.org/x/oauth2"
 
@@ -25,6 +23,7 @@ var _ vcsv1connect.VCSServiceHandler = (*Service)(nil)
 type Service struct {
 	logger     log.Logger
 	httpClient *http.Client
+	providers  []provider
 }
 
 func New(logger log.Logger, reg prometheus.Registerer) *Service {
@@ -33,6 +32,11 @@ func New(logger log.Logger, reg prometheus.Registerer) *Service {
 	return &Service{
 		logger:     logger,
 		httpClient: httpClient,
+		providers: []provider{
+			githubProvider{},
+			newGitlabProvider(gitlabURL, gitlabAppClientID, gitlabAppClientSecret),
+			newGiteaProvider(giteaURL, giteaAppClientID, giteaAppClientSecret),
+		},
 	}
 }
 
@@ -137,41 +141,19 @@ func (q *Service) GithubRefresh(ctx context.Context, req *connect.Request[vcsv1.
 }
 
 func (q *Service) GetFile(ctx context.Context, req *connect.Request[vcsv1.GetFileRequest]) (*connect.Response[vcsv1.GetFileResponse], error) {
-	token, err := tokenFromRequest(ctx, req)
-	if err != nil {
-		q.logger.Log("err", err, "msg", "failed to extract token from request")
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token"))
-	}
-
-	err = rejectExpiredToken(token)
-	if err != nil {
-		return nil, err
-	}
-
-	// initialize and parse the git repo URL
-	gitURL, err := giturl.NewGitURL(req.Msg.RepositoryURL)
-	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
-	}
-
-	if gitURL.GetProvider() != apis.ProviderGitHub.String() {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only GitHub repositories are supported"))
-	}
-
-	// todo: we can support multiple provider: bitbucket, gitlab, etc.
-	ghClient, err := client.GithubClient(ctx, token, q.httpClient)
+	vcsClient, repo, err := q.repositoryClient(ctx, req, req.Msg.RepositoryURL)
 	if err != nil {
 		return nil, err
 	}
 
 	file, err := source.NewFileFinder(
-		ghClient,
-		gitURL,
+		vcsClient,
+		repo,
 		req.Msg.LocalPath,
 		req.Msg.RootPath,
 		req.Msg.Ref,
 		http.DefaultClient,
-		log.With(q.logger, "repo", gitURL.GetRepoName()),
+		log.With(q.logger, "repo", repo.GetRepoName()),
 	).Find(ctx)
 	if err != nil {
 		if errors.Is(err, client.ErrNotFound) {
@@ -183,93 +165,178 @@ func (q *Service) GetFile(ctx context.Context, req *connect.Request[vcsv1.GetFil
 }
 
 func (q *Service) GetCommit(ctx context.Context, req *connect.Request[vcsv1.GetCommitRequest]) (*connect.Response[vcsv1.GetCommitResponse], error) {
-	token, err := tokenFromRequest(ctx, req)
+	vcsClient, repo, err := q.repositoryClient(ctx, req, req.Msg.RepositoryURL)
 	if err != nil {
-		q.logger.Log("err", err, "msg", "failed to extract token from request")
-		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token"))
+		return nil, err
 	}
 
-	err = rejectExpiredToken(token)
+	owner := repo.GetOwnerName()
+	name := repo.GetRepoName()
+	ref := req.Msg.GetRef()
+
+	commit, err := tryGetCommit(ctx, vcsClient, owner, name, ref)
 	if err != nil {
 		return nil, err
 	}
 
-	gitURL, err := giturl.NewGitURL(req.Msg.RepositoryURL)
+	return connect.NewResponse(&vcsv1.GetCommitResponse{
+		Message: commit.GetMessage(),
+		Author:  commit.GetAuthor(),
+		Date:    commit.GetDate(),
+		Sha:     commit.GetSha(),
+		URL:     commit.GetURL(),
+	}), nil
+}
+
+func (q *Service) GetCommits(ctx context.Context, req *connect.Request[vcsv1.GetCommitsRequest]) (*connect.Response[vcsv1.GetCommitsResponse], error) {
+	vcsClient, repo, err := q.repositoryClient(ctx, req, req.Msg.RepositoryUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := repo.GetOwnerName()
+	name := repo.GetRepoName()
+	refs := req.Msg.Refs
+
+	commits, failedFetches, err := getCommits(ctx, vcsClient, owner, name, refs)
+	if err != nil {
+		q.logger.Log("err", err, "msg", "failed to get any commits", "owner", owner, "repo", name)
+		return nil, err
+	}
+
+	if len(failedFetches) > 0 {
+		q.logger.Log("warn", "partial success fetching commits", "owner", owner, "repo", name, "successCount", len(commits), "failureCount", len(failedFetches))
+		for _, fetchErr := range failedFetches {
+			q.logger.Log("err", fetchErr, "msg", "failed to fetch commit")
+		}
+	}
+
+	return connect.NewResponse(&vcsv1.GetCommitsResponse{Commits: commits}), nil
+}
+
+func (q *Service) ProviderApp(ctx context.Context, req *connect.Request[vcsv1.ProviderAppRequest]) (*connect.Response[vcsv1.ProviderAppResponse], error) {
+	p, err := q.providerFor(req.Msg.RepositoryUrl)
 	if err != nil {
 		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
+	if err = p.configured(); err != nil {
+		q.logger.Log("err", err, "msg", "VCS integration is not configured", "provider", p.name())
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("%s integration is not configured", p.name()))
+	}
+	cfg, err := p.oauthConfig("")
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnimplemented, fmt.Errorf("%s integration is not configured", p.name()))
+	}
+	return connect.NewResponse(&vcsv1.ProviderAppResponse{
+		Provider:     p.name(),
+		ClientId:     cfg.ClientID,
+		AuthorizeUrl: cfg.Endpoint.AuthURL,
+		CookieName:   p.cookieName(),
+	}), nil
+}
 
-	if gitURL.GetProvider() != apis.ProviderGitHub.String() {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only GitHub repositories are supported"))
+func (q *Service) ProviderLogin(ctx context.Context, req *connect.Request[vcsv1.ProviderLoginRequest]) (*connect.Response[vcsv1.ProviderLoginResponse], error) {
+	p, err := q.providerFor(req.Msg.RepositoryUrl)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	ghClient, err := client.GithubClient(ctx, token, q.httpClient)
+	cfg, err := p.oauthConfig(req.Msg.RedirectUri)
 	if err != nil {
-		return nil, err
+		q.logger.Log("err", err, "msg", "failed to get OAuth config", "provider", p.name())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize with %s", p.name()))
 	}
 
-	owner := gitURL.GetOwnerName()
-	repo := gitURL.GetRepoName()
-	ref := req.Msg.GetRef()
+	encryptionKey, err := deriveEncryptionKeyForContext(ctx)
+	if err != nil {
+		q.logger.Log("err", err, "msg", "failed to derive encryption key")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize with %s", p.name()))
+	}
 
-	commit, err := tryGetCommit(ctx, ghClient, owner, repo, ref)
+	token, err := cfg.Exchange(context.WithValue(ctx, oauth2.HTTPClient, q.httpClient), req.Msg.AuthorizationCode)
 	if err != nil {
-		return nil, err
+		q.logger.Log("err", err, "msg", "failed to exchange authorization code", "provider", p.name())
+		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("failed to authorize with %s", p.name()))
 	}
 
-	return connect.NewResponse(&vcsv1.GetCommitResponse{
-		Message: commit.GetMessage(),
-		Author:  commit.GetAuthor(),
-		Date:    commit.GetDate(),
-		Sha:     commit.GetSha(),
-		URL:     commit.GetURL(),
+	encoded, err := encryptToken(token, encryptionKey)
+	if err != nil {
+		q.logger.Log("err", err, "msg", "failed to encode OAuth token", "provider", p.name())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to authorize with %s", p.name()))
+	}
+
+	return connect.NewResponse(&vcsv1.ProviderLoginResponse{
+		Token:                 encoded,
+		TokenExpiresAt:        token.Expiry.UnixMilli(),
+		RefreshTokenExpiresAt: time.Now().Add(p.refreshTokenExpiry()).UnixMilli(),
 	}), nil
 }
 
-func (q *Service) GetCommits(ctx context.Context, req *connect.Request[vcsv1.GetCommitsRequest]) (*connect.Response[vcsv1.GetCommitsResponse], error) {
-	token, err := tokenFromRequest(ctx, req)
+func (q *Service) ProviderRefresh(ctx context.Context, req *connect.Request[vcsv1.ProviderRefreshRequest]) (*connect.Response[vcsv1.ProviderRefreshResponse], error) {
+	p, err := q.providerFor(req.Msg.RepositoryUrl)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	token, err := tokenFromRequestCookie(ctx, req, p.cookieName())
 	if err != nil {
 		q.logger.Log("err", err, "msg", "failed to extract token from request")
 		return nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token"))
 	}
 
-	err = rejectExpiredToken(token)
+	newToken, err := p.refreshToken(ctx, token, q.httpClient)
 	if err != nil {
-		return nil, err
+		q.logger.Log("err", err, "msg", "failed to refresh token", "provider", p.name())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to refresh token"))
 	}
 
-	gitURL, err := giturl.NewGitURL(req.Msg.RepositoryUrl)
+	derivedKey, err := deriveEncryptionKeyForContext(ctx)
 	if err != nil {
-		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		q.logger.Log("err", err, "msg", "failed to derive encryption key")
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to process token"))
 	}
 
-	if gitURL.GetProvider() != apis.ProviderGitHub.String() {
-		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("only GitHub repositories are supported"))
+	encoded, err := encryptToken(newToken, derivedKey)
+	if err != nil {
+		q.logger.Log("err", err, "msg", "failed to encode OAuth token", "provider", p.name())
+		return nil, connect.NewError(connect.CodeInternal, fmt.Errorf("failed to refresh token"))
 	}
 
-	ghClient, err := client.GithubClient(ctx, token, q.httpClient)
+	return connect.NewResponse(&vcsv1.ProviderRefreshResponse{
+		Token:                 encoded,
+		TokenExpiresAt:        newToken.Expiry.UnixMilli(),
+		RefreshTokenExpiresAt: time.Now().Add(p.refreshTokenExpiry()).UnixMilli(),
+	}), nil
+}
+
+// repositoryClient returns the VCS client for the repository
+// provider, authorized with the token from the request.
+func (q *Service) repositoryClient(ctx context.Context, req connect.AnyRequest, repositoryURL string) (vcsClient, source.Repository, error) {
+	p, err := q.providerFor(repositoryURL)
 	if err != nil {
-		return nil, err
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, err)
 	}
 
-	owner := gitURL.GetOwnerName()
-	repo := gitURL.GetRepoName()
-	refs := req.Msg.Refs
+	repo, err := p.repository(repositoryURL)
+	if err != nil {
+		return nil, nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
 
-	commits, failedFetches, err := getCommits(ctx, ghClient, owner, repo, refs)
+	token, err := tokenFromRequestCookie(ctx, req, p.cookieName())
 	if err != nil {
-		q.logger.Log("err", err, "msg", "failed to get any commits", "owner", owner, "repo", repo)
-		return nil, err
+		q.logger.Log("err", err, "msg", "failed to extract token from request")
+		return nil, nil, connect.NewError(connect.CodeUnauthenticated, fmt.Errorf("invalid token"))
 	}
 
-	if len(failedFetches) > 0 {
-		q.logger.Log("warn", "partial success fetching commits", "owner", owner, "repo", repo, "successCount", len(commits), "failureCount", len(failedFetches))
-		for _, fetchErr := range failedFetches {
-			q.logger.Log("err", fetchErr, "msg", "failed to fetch commit")
-		}
+	if err = rejectExpiredToken(token); err != nil {
+		return nil, nil, err
 	}
 
-	return connect.NewResponse(&vcsv1.GetCommitsResponse{Commits: commits}), nil
+	vcsClient, err := p.client(ctx, token, q.httpClient)
+	if err != nil {
+		return nil, nil, err
+	}
+	return vcsClient, repo, nil
 }
 
 func rejectExpiredToken(token *oauth2.Token) error {
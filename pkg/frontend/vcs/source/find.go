@@ -3,14 +3,16 @@ package source
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"connectrpc.com/connect"
 	"github.com/go-kit/log"
-	giturl "github.com/kubescape/go-git-url"
 
 	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
 	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
@@ -20,10 +22,17 @@ type VCSClient interface {
 	GetFile(ctx context.Context, req client.FileRequest) (client.File, error)
 }
 
+// Repository identifies a repository in the vcs.
+type Repository interface {
+	GetHostName() string
+	GetOwnerName() string
+	GetRepoName() string
+}
+
 // FileFinder finds a file in a vcs repository.
 type FileFinder struct {
 	path, ref, rootPath string
-	repo                giturl.IGitURL
+	repo                Repository
 
 	client     VCSClient
 	httpClient *http.Client
@@ -31,7 +40,7 @@ type FileFinder struct {
 }
 
 // NewFileFinder returns a new FileFinder.
-func NewFileFinder(client VCSClient, repo giturl.IGitURL, path, rootPath, ref string, httpClient *http.Client, logger log.Logger) *FileFinder {
+func NewFileFinder(client VCSClient, repo Repository, path, rootPath, ref string, httpClient *http.Client, logger log.Logger) *FileFinder {
 	if ref == "" {
 		ref = "HEAD"
 	}
@@ -51,7 +60,12 @@ func (ff FileFinder) Find(ctx context.Context) (*vcsv1.GetFileResponse, error) {
 	switch filepath.Ext(ff.path) {
 	case ExtGo:
 		return ff.findGoFile(ctx)
-	// todo: add more languages support
+	case ExtJava:
+		return ff.findJavaFile(ctx)
+	case ExtPython:
+		return ff.findPythonFile(ctx)
+	case ExtRust:
+		return ff.findRustFile(ctx)
 	default:
 		// by default we return the file content at the given path without any processing.
 		content, err := ff.fetchRepoFile(ctx, ff.path, ff.ref)
@@ -76,6 +90,37 @@ func (arg FileFinder) fetchRepoFile(ctx context.Context, path, ref string) (*vcs
 	return newFileResponse(content.Content, content.URL)
 }
 
+// fetchFirstFile fetches the first file found of the requested ones.
+// If none of the files is found, client.ErrNotFound is returned.
+func (ff FileFinder) fetchFirstFile(ctx context.Context, requests ...client.FileRequest) (*vcsv1.GetFileResponse, error) {
+	for _, req := range requests {
+		content, err := ff.client.GetFile(ctx, req)
+		if errors.Is(err, client.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return newFileResponse(content.Content, content.URL)
+	}
+	return nil, client.ErrNotFound
+}
+
+// repoFileRequests returns requests for the given paths
+// relative to the root path in the configured repository.
+func (ff FileFinder) repoFileRequests(paths ...string) []client.FileRequest {
+	requests := make([]client.FileRequest, 0, len(paths))
+	for _, p := range paths {
+		requests = append(requests, client.FileRequest{
+			Owner: ff.repo.GetOwnerName(),
+			Repo:  ff.repo.GetRepoName(),
+			Path:  strings.TrimLeft(path.Join(ff.rootPath, p), "/"),
+			Ref:   ff.ref,
+		})
+	}
+	return requests
+}
+
 // fetchURL fetches the file content from the given URL.
 func (ff FileFinder) fetchURL(ctx context.Context, url string, decodeBase64 bool) (*vcsv1.GetFileResponse, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
@@ -104,6 +149,12 @@ func (ff FileFinder) fetchURL(ctx context.Context, url string, decodeBase64 bool
 	return newFileResponse(string(decoded), url)
 }
 
+// githubRawURL returns the URL of the file content in a public
+// GitHub repository.
+func githubRawURL(owner, repo, ref, p string) string {
+	return "https://raw.githubusercontent.com/" + path.Join(owner, repo, ref, p)
+}
+
 func newFileResponse(content, url string) (*vcsv1.GetFileResponse, error) {
 	return &vcsv1.GetFileResponse{
 		Content: base64.StdEncoding.EncodeToString([]byte(content)),
@@ -109,12 +109,17 @@ func (ff FileFinder) fetchGoogleSourceDependencyFile(ctx context.Context, mod go
 // - "path/to/module1/log/log.go"
 // - "path/to/module1/log.go"
 func (ff FileFinder) tryFindGoFile(ctx context.Context, maxAttempts int) (*vcsv1.GetFileResponse, error) {
+	// Try to find the file in the repo.
+	path := strings.TrimPrefix(ff.path, strings.Join([]string{ff.repo.GetHostName(), ff.repo.GetOwnerName(), ff.repo.GetRepoName()}, "/"))
+	return ff.tryFindFile(ctx, strings.TrimLeft(path, "/"), maxAttempts)
+}
+
+// tryFindFile tries to find the file in the repo, under the rootPath,
+// by removing path segment after path segment. See tryFindGoFile.
+func (ff FileFinder) tryFindFile(ctx context.Context, path string, maxAttempts int) (*vcsv1.GetFileResponse, error) {
 	if maxAttempts <= 0 {
 		return nil, errors.New("invalid max attempts")
 	}
-	// Try to find the file in the repo.
-	path := strings.TrimPrefix(ff.path, strings.Join([]string{ff.repo.GetHostName(), ff.repo.GetOwnerName(), ff.repo.GetRepoName()}, "/"))
-	path = strings.TrimLeft(path, "/")
 	attempts := 0
 	for {
 		content, err := ff.client.GetFile(ctx, client.FileRequest{
@@ -0,0 +1,132 @@
+package source
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+const (
+	ExtJava = ".java"
+
+	javaSourceDir = "src/main/java"
+
+	jdkOwner = "openjdk"
+	jdkRepo  = "jdk"
+	jdkRef   = "master"
+)
+
+// jdkVersionDir matches the JDK installation directory that includes the
+// runtime version, e.g. "/usr/lib/jvm/jdk-17.0.2+8/" or "/opt/java/openjdk-21/".
+var jdkVersionDir = regexp.MustCompile(`(?:^|/)(?:jdk|openjdk|java)-(\d+)((?:\.\d+)*)[^/]*/`)
+
+// jdkModules maps JDK package prefixes to the modules they belong to.
+// More specific prefixes go first.
+var jdkModules = []struct {
+	prefix string
+	module string
+}{
+	{"java/lang/management/", "java.management"},
+	{"java/net/http/", "java.net.http"},
+	{"java/util/logging/", "java.logging"},
+	{"java/util/prefs/", "java.prefs"},
+	{"java/sql/", "java.sql"},
+	{"java/rmi/", "java.rmi"},
+	{"java/awt/", "java.desktop"},
+	{"java/beans/", "java.desktop"},
+	{"javax/swing/", "java.desktop"},
+	{"javax/imageio/", "java.desktop"},
+	{"javax/sound/", "java.desktop"},
+	{"javax/management/", "java.management"},
+	{"javax/naming/", "java.naming"},
+	{"javax/script/", "java.scripting"},
+	{"javax/sql/", "java.sql"},
+	{"javax/xml/crypto/", "java.xml.crypto"},
+	{"javax/xml/", "java.xml"},
+	{"org/w3c/dom/", "java.xml"},
+	{"org/xml/sax/", "java.xml"},
+	{"jdk/jfr/", "jdk.jfr"},
+	{"jdk/internal/", "java.base"},
+	{"java/", "java.base"},
+	{"javax/crypto/", "java.base"},
+	{"javax/net/", "java.base"},
+	{"javax/security/", "java.base"},
+	{"sun/", "java.base"},
+}
+
+// findJavaFile finds a java file in a vcs repository.
+//
+// The path is expected to be the package path of the class source file,
+// e.g., "com/example/app/Main.java". JDK classes are fetched from the
+// OpenJDK repository: if the path includes the JDK installation directory,
+// the file is fetched at the tag of the runtime version, otherwise at the
+// development branch. Other classes are looked up in the repository,
+// assuming the conventional (maven/gradle) source layout.
+func (ff FileFinder) findJavaFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if repo, ref, p, ok := jdkVersionPath(ff.path); ok {
+		if jdkPath, ok := jdkSourcePath(p); ok {
+			return ff.fetchURL(ctx, githubRawURL(jdkOwner, repo, ref, jdkPath), false)
+		}
+	}
+	p := javaPackagePath(ff.path)
+	if jdkPath, ok := jdkSourcePath(p); ok {
+		return ff.fetchURL(ctx, githubRawURL(jdkOwner, jdkRepo, jdkRef, jdkPath), false)
+	}
+	return ff.fetchFirstFile(ctx, ff.repoFileRequests(
+		path.Join(javaSourceDir, p),
+		path.Join("src", p),
+		p,
+	)...)
+}
+
+// javaPackagePath returns the path of the file relative to the source
+// root, if the path includes one.
+func javaPackagePath(p string) string {
+	if i := strings.LastIndex(p, "/"+javaSourceDir+"/"); i >= 0 {
+		return p[i+len(javaSourceDir)+2:]
+	}
+	if strings.HasPrefix(p, javaSourceDir+"/") {
+		return p[len(javaSourceDir)+1:]
+	}
+	return strings.TrimLeft(p, "/")
+}
+
+// jdkVersionPath returns the OpenJDK update repository and the GA tag of
+// the runtime version, if the path includes the JDK installation directory,
+// and the path of the file relative to the directory. JDK 8 and older
+// versions are not supported, as the source layout differs.
+func jdkVersionPath(p string) (repo, ref, rel string, ok bool) {
+	m := jdkVersionDir.FindStringSubmatchIndex(p)
+	if m == nil {
+		return "", "", "", false
+	}
+	feature := p[m[2]:m[3]]
+	if v, err := strconv.Atoi(feature); err != nil || v < 9 {
+		return "", "", "", false
+	}
+	rel = p[m[1]:]
+	// The sources may be located in the module directory,
+	// e.g. "lib/src.zip/java.base/java/util/Map.java".
+	for _, jm := range jdkModules {
+		if i := strings.Index(rel, "/"+jm.prefix); i >= 0 {
+			rel = rel[i+1:]
+			break
+		}
+	}
+	return jdkRepo + feature + "u", "jdk-" + feature + p[m[4]:m[5]] + "-ga", rel, true
+}
+
+// jdkSourcePath returns the path of the JDK class source file in the
+// OpenJDK repository.
+func jdkSourcePath(p string) (string, bool) {
+	for _, m := range jdkModules {
+		if strings.HasPrefix(p, m.prefix) {
+			return path.Join("src", m.module, "share/classes", p), true
+		}
+	}
+	return "", false
+}
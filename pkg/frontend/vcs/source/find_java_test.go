@@ -0,0 +1,61 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+func Test_findJavaFile(t *testing.T) {
+	files := map[string]string{
+		fileKey("grafana", "app", "main", "src/main/java/com/example/App.java"):         "app",
+		fileKey("grafana", "app", "main", "service/src/main/java/com/example/Svc.java"): "svc",
+		fileKey("grafana", "app", "main", "src/org/example/Legacy.java"):                "legacy",
+	}
+	raw := map[string]string{
+		"openjdk/jdk/master/src/java.base/share/classes/java/util/Map.java":                          "map",
+		"openjdk/jdk/master/src/java.sql/share/classes/java/sql/Date.java":                           "date",
+		"openjdk/jdk/master/src/java.logging/share/classes/java/util/logging/Logger.java":            "logger",
+		"openjdk/jdk17u/jdk-17.0.2-ga/src/java.base/share/classes/java/util/Map.java":                "map 17.0.2",
+		"openjdk/jdk21u/jdk-21-ga/src/java.base/share/classes/java/lang/Thread.java":                 "thread 21",
+		"openjdk/jdk11u/jdk-11.0.21-ga/src/java.logging/share/classes/java/util/logging/Logger.java": "logger 11.0.21",
+	}
+	tests := []struct {
+		name     string
+		path     string
+		rootPath string
+		expected string
+		err      error
+	}{
+		{name: "maven layout", path: "com/example/App.java", expected: "app"},
+		{name: "maven layout in root path", path: "com/example/Svc.java", rootPath: "service", expected: "svc"},
+		{name: "absolute path", path: "/build/app/src/main/java/com/example/App.java", expected: "app"},
+		{name: "src layout", path: "org/example/Legacy.java", expected: "legacy"},
+		{name: "jdk java.base", path: "java/util/Map.java", expected: "map"},
+		{name: "jdk java.sql", path: "java/sql/Date.java", expected: "date"},
+		{name: "jdk nested module package", path: "java/util/logging/Logger.java", expected: "logger"},
+		{name: "jdk version", path: "/usr/lib/jvm/jdk-17.0.2+8/java/util/Map.java", expected: "map 17.0.2"},
+		{name: "jdk feature version", path: "/opt/java/openjdk-21/lib/src.zip/java.base/java/lang/Thread.java", expected: "thread 21"},
+		{name: "jdk version module", path: "/usr/lib/jvm/java-11.0.21-openjdk-amd64/java.logging/java/util/logging/Logger.java", expected: "logger 11.0.21"},
+		{name: "jdk 8 is not supported", path: "/usr/lib/jvm/java-8-openjdk/java/util/Map.java", err: client.ErrNotFound},
+		{name: "not found", path: "com/example/Missing.java", err: client.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeVCSClient{files: files}
+			ff := newTestFileFinder(t, c, tt.path, tt.rootPath)
+			ff.httpClient = newFakeRawGitHub(raw)
+			resp, err := ff.Find(context.Background())
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			requireFileContent(t, tt.expected, resp)
+		})
+	}
+}
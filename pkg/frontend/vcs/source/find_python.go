@@ -0,0 +1,62 @@
+package source
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+)
+
+const (
+	ExtPython = ".py"
+
+	cpythonOwner = "python"
+	cpythonRepo  = "cpython"
+)
+
+// pythonLibDir matches the python installation library directory,
+// e.g. "/usr/lib/python3.12/" or "/opt/venv/lib/python3.11/".
+var pythonLibDir = regexp.MustCompile(`(?:^|/)lib(?:64)?/python(\d+\.\d+)/`)
+
+// pythonPackageDirs are the directories third-party packages are installed to.
+var pythonPackageDirs = []string{"site-packages/", "dist-packages/"}
+
+// findPythonFile finds a python file in a vcs repository.
+//
+// Files of the standard library are fetched from the CPython repository,
+// at the branch of the python version. Packages installed to a
+// virtualenv or to site-packages are looked up in the repository, with
+// the installation prefix stripped. Other paths are looked up in the
+// repository by removing path segment after path segment.
+func (ff FileFinder) findPythonFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if p, ok := pythonPackagePath(ff.path); ok {
+		return ff.fetchFirstFile(ctx, ff.repoFileRequests(p, path.Join("src", p))...)
+	}
+	if p, version, ok := pythonStdlibPath(ff.path); ok {
+		return ff.fetchURL(ctx, githubRawURL(cpythonOwner, cpythonRepo, version, path.Join("Lib", p)), false)
+	}
+	return ff.tryFindFile(ctx, strings.TrimLeft(ff.path, "/"), 30)
+}
+
+// pythonPackagePath returns the path of the file relative to the
+// site-packages directory, if the file belongs to an installed package.
+func pythonPackagePath(p string) (string, bool) {
+	for _, dir := range pythonPackageDirs {
+		if i := strings.LastIndex(p, dir); i >= 0 && (i == 0 || p[i-1] == '/') {
+			return p[i+len(dir):], true
+		}
+	}
+	return "", false
+}
+
+// pythonStdlibPath returns the path of the standard library file
+// relative to the library directory, and the python version.
+func pythonStdlibPath(p string) (string, string, bool) {
+	m := pythonLibDir.FindStringSubmatchIndex(p)
+	if m == nil {
+		return "", "", false
+	}
+	return p[m[1]:], p[m[2]:m[3]], true
+}
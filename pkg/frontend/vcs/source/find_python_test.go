@@ -0,0 +1,48 @@
+package source
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+func Test_findPythonFile(t *testing.T) {
+	files := map[string]string{
+		fileKey("grafana", "app", "main", "app/api.py"):        "api",
+		fileKey("grafana", "app", "main", "src/app/models.py"): "models",
+		fileKey("grafana", "app", "main", "app/main.py"):       "main",
+	}
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		err      error
+	}{
+		{name: "virtualenv site-packages", path: "/home/user/.venv/lib/python3.12/site-packages/app/api.py", expected: "api"},
+		{name: "src layout", path: "/usr/local/lib/python3.11/site-packages/app/models.py", expected: "models"},
+		{name: "dist-packages", path: "/usr/lib/python3/dist-packages/app/api.py", expected: "api"},
+		{name: "stdlib", path: "/usr/local/lib/python3.12/json/__init__.py", expected: "json"},
+		{name: "application path", path: "/opt/service/app/main.py", expected: "main"},
+		{name: "not found", path: "/usr/lib/python3.12/site-packages/requests/api.py", err: client.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeVCSClient{files: files}
+			ff := newTestFileFinder(t, c, tt.path, "")
+			ff.httpClient = newFakeRawGitHub(map[string]string{
+				"python/cpython/3.12/Lib/json/__init__.py": "json",
+			})
+			resp, err := ff.Find(context.Background())
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			requireFileContent(t, tt.expected, resp)
+		})
+	}
+}
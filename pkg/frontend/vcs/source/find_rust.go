@@ -0,0 +1,110 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+
+	"connectrpc.com/connect"
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/kubescape/go-git-url/apis"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+const (
+	ExtRust = ".rs"
+
+	rustOwner = "rust-lang"
+	rustRepo  = "rust"
+)
+
+var cratesIOURL = "https://crates.io"
+
+var (
+	// rustStdlibPath matches the path of the standard library files,
+	// e.g. "/rustc/<commit>/library/core/src/ops/function.rs".
+	rustStdlibPath = regexp.MustCompile(`^/rustc/([0-9a-f]{40})/(.+)$`)
+	// cargoRegistryPath matches the path of the crate files downloaded
+	// from the registry, e.g. "/root/.cargo/registry/src/index.crates.io-6f17d22bba15001f/tokio-1.35.1/src/lib.rs".
+	cargoRegistryPath = regexp.MustCompile(`/registry/src/[^/]+/([^/]+)-(\d+\.\d+\.\d+[^/]*)/(.+)$`)
+)
+
+// findRustFile finds a rust file in a vcs repository.
+//
+// Files of the standard library are fetched from the rust repository at
+// the compiler commit. Files of the crates downloaded from the registry
+// are fetched from the crate repository, at the tag of the crate version.
+// Other paths are looked up in the repository by removing path segment
+// after path segment.
+func (ff FileFinder) findRustFile(ctx context.Context) (*vcsv1.GetFileResponse, error) {
+	if m := rustStdlibPath.FindStringSubmatch(ff.path); m != nil {
+		return ff.fetchURL(ctx, githubRawURL(rustOwner, rustRepo, m[1], m[2]), false)
+	}
+	if m := cargoRegistryPath.FindStringSubmatch(ff.path); m != nil {
+		return ff.fetchCrateFile(ctx, m[1], m[2], m[3])
+	}
+	return ff.tryFindFile(ctx, strings.TrimLeft(ff.path, "/"), 30)
+}
+
+// fetchCrateFile fetches the crate file from the crate repository.
+// As there is no convention for tagging crate versions, and the crate
+// may be a workspace member, all the common combinations are tried.
+func (ff FileFinder) fetchCrateFile(ctx context.Context, name, version, file string) (*vcsv1.GetFileResponse, error) {
+	repo, err := ff.crateRepository(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if repo.GetProvider() != apis.ProviderGitHub.String() {
+		return nil, fmt.Errorf("unsupported crate repository: %s", repo.GetURL())
+	}
+	refs := []string{"v" + version, name + "-v" + version, name + "-" + version, version}
+	paths := []string{file, path.Join(name, file)}
+	requests := make([]client.FileRequest, 0, len(refs)*len(paths))
+	for _, ref := range refs {
+		for _, p := range paths {
+			requests = append(requests, client.FileRequest{
+				Owner: repo.GetOwnerName(),
+				Repo:  repo.GetRepoName(),
+				Path:  p,
+				Ref:   ref,
+			})
+		}
+	}
+	return ff.fetchFirstFile(ctx, requests...)
+}
+
+// crateRepository returns the crate repository URL from the registry.
+func (ff FileFinder) crateRepository(ctx context.Context, name string) (giturl.IGitURL, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", cratesIOURL+"/api/v1/crates/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	// crates.io requires a user agent to be set.
+	req.Header.Set("User-Agent", "pyroscope")
+	resp, err := ff.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("failed to fetch crate %s: %s", name, resp.Status))
+	}
+	var info struct {
+		Crate struct {
+			Repository string `json:"repository"`
+		} `json:"crate"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	if info.Crate.Repository == "" {
+		return nil, connect.NewError(connect.CodeNotFound, fmt.Errorf("crate %s has no repository", name))
+	}
+	return giturl.NewGitURL(info.Crate.Repository)
+}
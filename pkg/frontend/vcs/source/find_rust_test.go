@@ -0,0 +1,89 @@
+package source
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+func newFakeCratesIO(repos map[string]string, next http.RoundTripper) *http.Client {
+	return &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Host != "crates.io" {
+			return next.RoundTrip(r)
+		}
+		name := strings.TrimPrefix(r.URL.Path, "/api/v1/crates/")
+		repo, ok := repos[name]
+		if !ok || r.Header.Get("User-Agent") == "" {
+			return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: http.NoBody}, nil
+		}
+		body := `{"crate":{"name":"` + name + `","repository":"` + repo + `"}}`
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+	})}
+}
+
+func Test_findRustFile(t *testing.T) {
+	const rustc = "82e1608dfa6e0b5569232559e3d385fea5a93112"
+	files := map[string]string{
+		fileKey("grafana", "app", "main", "src/main.rs"):                         "main",
+		fileKey("tokio-rs", "tokio", "tokio-1.35.1", "tokio/src/runtime/mod.rs"): "tokio",
+		fileKey("serde-rs", "json", "v1.0.108", "src/de.rs"):                     "serde_json",
+	}
+	raw := newFakeRawGitHub(map[string]string{
+		"rust-lang/rust/" + rustc + "/library/core/src/ops/function.rs": "function",
+	})
+	crates := map[string]string{
+		"tokio":      "https://github.com/tokio-rs/tokio",
+		"serde_json": "https://github.com/serde-rs/json",
+		"gitlab":     "https://gitlab.com/example/crate",
+	}
+	const registry = "/home/user/.cargo/registry/src/index.crates.io-6f17d22bba15001f/"
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		err      error
+	}{
+		{name: "application path", path: "/build/app/src/main.rs", expected: "main"},
+		{name: "stdlib", path: "/rustc/" + rustc + "/library/core/src/ops/function.rs", expected: "function"},
+		{name: "workspace crate", path: registry + "tokio-1.35.1/src/runtime/mod.rs", expected: "tokio"},
+		{name: "crate", path: registry + "serde_json-1.0.108/src/de.rs", expected: "serde_json"},
+		{name: "crate not found", path: registry + "serde_json-1.0.109/src/de.rs", err: client.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &fakeVCSClient{files: files}
+			ff := newTestFileFinder(t, c, tt.path, "")
+			ff.httpClient = newFakeCratesIO(crates, raw.Transport)
+			resp, err := ff.Find(context.Background())
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			requireFileContent(t, tt.expected, resp)
+		})
+	}
+
+	t.Run("unknown crate", func(t *testing.T) {
+		ff := newTestFileFinder(t, &fakeVCSClient{}, registry+"unknown-0.1.0/src/lib.rs", "")
+		ff.httpClient = newFakeCratesIO(crates, raw.Transport)
+		_, err := ff.Find(context.Background())
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported repository", func(t *testing.T) {
+		c := &fakeVCSClient{}
+		ff := newTestFileFinder(t, c, registry+"gitlab-0.1.0/src/lib.rs", "")
+		ff.httpClient = newFakeCratesIO(crates, raw.Transport)
+		_, err := ff.Find(context.Background())
+		assert.Error(t, err)
+		assert.Empty(t, c.requests)
+	})
+}
@@ -0,0 +1,69 @@
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/log"
+	giturl "github.com/kubescape/go-git-url"
+	"github.com/stretchr/testify/require"
+
+	vcsv1 "github.com/grafana/pyroscope/api/gen/proto/go/vcs/v1"
+	"github.com/grafana/pyroscope/pkg/frontend/vcs/client"
+)
+
+// fakeVCSClient serves files keyed by "owner/repo@ref:path".
+type fakeVCSClient struct {
+	files    map[string]string
+	requests []client.FileRequest
+}
+
+func fileKey(owner, repo, ref, p string) string {
+	return path.Join(owner, repo) + "@" + ref + ":" + p
+}
+
+func (c *fakeVCSClient) GetFile(_ context.Context, req client.FileRequest) (client.File, error) {
+	c.requests = append(c.requests, req)
+	// The leading slash is insignificant for the repository path.
+	key := fileKey(req.Owner, req.Repo, req.Ref, strings.TrimLeft(req.Path, "/"))
+	content, ok := c.files[key]
+	if !ok {
+		return client.File{}, client.ErrNotFound
+	}
+	return client.File{Content: content, URL: key}, nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// newFakeRawGitHub serves files keyed by "owner/repo/ref/path"
+// at raw.githubusercontent.com.
+func newFakeRawGitHub(files map[string]string) *http.Client {
+	return &http.Client{Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		content, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok || r.URL.Host != "raw.githubusercontent.com" {
+			return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: http.NoBody}, nil
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(content))}, nil
+	})}
+}
+
+func newTestFileFinder(t *testing.T, c VCSClient, filePath, rootPath string) *FileFinder {
+	t.Helper()
+	repo, err := giturl.NewGitURL("https://github.com/grafana/app")
+	require.NoError(t, err)
+	return NewFileFinder(c, repo, filePath, rootPath, "main", nil, log.NewNopLogger())
+}
+
+func requireFileContent(t *testing.T, expected string, resp *vcsv1.GetFileResponse) {
+	t.Helper()
+	content, err := base64.StdEncoding.DecodeString(resp.Content)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(content))
+}
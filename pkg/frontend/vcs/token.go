@@ -91,9 +91,14 @@ func getDurationValueFrom(values url.Values, key string, scalar time.Duration) (
 
 // tokenFromRequest decodes an OAuth token from a request.
 func tokenFromRequest(ctx context.Context, req connect.AnyRequest) (*oauth2.Token, error) {
-	cookie, err := (&http.Request{Header: req.Header()}).Cookie(sessionCookieName)
+	return tokenFromRequestCookie(ctx, req, sessionCookieName)
+}
+
+// tokenFromRequestCookie decodes an OAuth token from the named request cookie.
+func tokenFromRequestCookie(ctx context.Context, req connect.AnyRequest, name string) (*oauth2.Token, error) {
+	cookie, err := (&http.Request{Header: req.Header()}).Cookie(name)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read cookie %s: %w", sessionCookieName, err)
+		return nil, fmt.Errorf("failed to read cookie %s: %w", name, err)
 	}
 
 	derivedKey, err := deriveEncryptionKeyForContext(ctx)
@@ -50,6 +50,10 @@ func (m *mockServer) DeleteTenant(ctx context.Context, request *metastorev1.Dele
 	return m.tenant.DeleteTenant(ctx, request)
 }
 
+func (m *mockServer) DeleteSeries(ctx context.Context, request *metastorev1.DeleteSeriesRequest) (*metastorev1.DeleteSeriesResponse, error) {
+	return m.tenant.DeleteSeries(ctx, request)
+}
+
 func (m *mockServer) PollCompactionJobs(ctx context.Context, request *metastorev1.PollCompactionJobsRequest) (*metastorev1.PollCompactionJobsResponse, error) {
 	return m.compactor.PollCompactionJobs(ctx, request)
 }
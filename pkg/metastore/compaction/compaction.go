@@ -23,6 +23,10 @@ type Planner interface {
 	// UpdatePlan communicates the status of the compaction job to the planner.
 	// Implementation: This method must be idempotent.
 	UpdatePlan(*bbolt.Tx, *raft_log.CompactionPlanUpdate) error
+	// Pending reports whether the series tombstone is yet to be applied to
+	// blocks created before the given time (Unix nanoseconds): the blocks
+	// are queued for compaction, or are to be rewritten.
+	Pending(t *metastorev1.SeriesTombstone, before int64) bool
 }
 
 type Plan interface {
@@ -39,6 +43,9 @@ type Scheduler interface {
 	// UpdateSchedule adds new jobs and updates the state of existing ones.
 	// Implementation: This method must be idempotent.
 	UpdateSchedule(*bbolt.Tx, *raft_log.CompactionPlanUpdate) error
+	// Scheduled reports whether any scheduled job of the tenant includes
+	// blocks created before the given time (Unix nanoseconds).
+	Scheduled(tx *bbolt.Tx, tenant string, before int64) (bool, error)
 }
 
 // Schedule prepares changes to the compaction plan based on status updates
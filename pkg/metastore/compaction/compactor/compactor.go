@@ -1,6 +1,8 @@
 package compactor
 
 import (
+	"math"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/raft"
@@ -12,6 +14,7 @@ import (
 	"github.com/grafana/pyroscope/pkg/iter"
 	"github.com/grafana/pyroscope/pkg/metastore/compaction"
 	"github.com/grafana/pyroscope/pkg/metastore/compaction/compactor/store"
+	"github.com/grafana/pyroscope/pkg/util"
 )
 
 var (
@@ -21,6 +24,7 @@ var (
 
 type Tombstones interface {
 	ListTombstones(before time.Time) iter.Iterator[*metastorev1.Tombstones]
+	ListSeriesTombstones(tenants ...string) []*metastorev1.Tombstones
 }
 
 type BlockQueueStore interface {
@@ -71,13 +75,30 @@ func (c *Compactor) enqueue(e compaction.BlockEntry) bool {
 	return c.queue.push(e)
 }
 
+// queued reports whether the block may be rewritten: blocks of the levels
+// subject to compaction must be present in the compaction queue.
+func (c *Compactor) queued(k compactionKey, block string) bool {
+	if int(k.level) >= len(c.config.Levels) {
+		return true
+	}
+	if int(k.level) >= len(c.queue.levels) || c.queue.levels[k.level] == nil {
+		return false
+	}
+	staged, ok := c.queue.levels[k.level].staged[k]
+	if !ok {
+		return false
+	}
+	_, ok = staged.refs[block]
+	return ok
+}
+
 func (c *Compactor) NewPlan(cmd *raft.Log) compaction.Plan {
 	now := cmd.AppendedAt.UnixNano()
 	before := cmd.AppendedAt.Add(-c.config.CleanupDelay)
-	tombstones := c.tombstones.ListTombstones(before)
 	return &plan{
 		compactor:  c,
-		tombstones: tombstones,
+		tombstones: c.tombstones.ListTombstones(before),
+		rewrites:   c.tombstones.ListTombstones(before),
 		blocks:     newBlockIter(),
 		now:        now,
 	}
@@ -85,7 +106,13 @@ func (c *Compactor) NewPlan(cmd *raft.Log) compaction.Plan {
 
 func (c *Compactor) UpdatePlan(tx *bbolt.Tx, plan *raft_log.CompactionPlanUpdate) error {
 	for _, job := range plan.NewJobs {
-		// Delete source blocks from the compaction queue.
+		if int(job.Plan.CompactionLevel) >= len(c.config.Levels) {
+			// Blocks of the level are not queued for compaction:
+			// this is the case for rewrite jobs.
+			continue
+		}
+		// Delete source blocks from the compaction queue. Note that
+		// blocks of rewrite jobs are deleted from the queue as well.
 		k := compactionKey{
 			tenant: job.Plan.Tenant,
 			shard:  job.Plan.Shard,
@@ -106,6 +133,39 @@ func (c *Compactor) UpdatePlan(tx *bbolt.Tx, plan *raft_log.CompactionPlanUpdate
 	return nil
 }
 
+// Pending reports whether the series tombstone is yet to be applied to
+// the blocks created before the given time: the tenant blocks remaining
+// in the compaction queue, and the blocks of the rewrite tombstones.
+func (c *Compactor) Pending(t *metastorev1.SeriesTombstone, before int64) bool {
+	for _, q := range c.queue.levels {
+		if q == nil {
+			continue
+		}
+		for k, staged := range q.staged {
+			if k.tenant != t.Tenant {
+				continue
+			}
+			for b := range staged.refs {
+				if util.ULIDStringUnixNano(b) < before {
+					return true
+				}
+			}
+		}
+	}
+	// Rewrite tombstone names are relative to the series tombstone name.
+	prefix := t.Name + "-"
+	tombstones := c.tombstones.ListTombstones(time.Unix(0, math.MaxInt64))
+	defer func() {
+		_ = tombstones.Close()
+	}()
+	for tombstones.Next() {
+		if d := tombstones.At().Datasets; d != nil && strings.HasPrefix(d.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Compactor) Init(tx *bbolt.Tx) error {
 	return c.store.CreateBuckets(tx)
 }
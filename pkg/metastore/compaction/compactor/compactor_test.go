@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/hashicorp/raft"
+	"github.com/oklog/ulid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -60,16 +62,20 @@ func TestCompactor_UpdatePlan(t *testing.T) {
 		require.NoError(t, err)
 	}
 
+	series := []*metastorev1.Tombstones{{Series: &metastorev1.SeriesTombstone{Name: "series-1", Tenant: "A"}}}
 	planned := make([]*raft_log.CompactionJobPlan, 3)
 	test.AssertIdempotent(t, func(t *testing.T) {
 		tombstones.On("ListTombstones", mock.Anything).
 			Return(iter.NewEmptyIterator[*metastorev1.Tombstones](), nil)
+		tombstones.On("ListSeriesTombstones", "A").Return(series)
 
 		planner := compactor.NewPlan(&raft.Log{Index: uint64(2), AppendedAt: now})
 		for i := range planned {
 			job, err := planner.CreateJob()
 			require.NoError(t, err)
 			require.NotNil(t, job)
+			// Series tombstones are attached to all jobs of the tenant.
+			require.Equal(t, series, job.Tombstones)
 			planned[i] = job
 		}
 
@@ -114,6 +120,7 @@ func TestCompactor_Restore(t *testing.T) {
 	tombstones := new(mockcompactor.MockTombstones)
 	tombstones.On("ListTombstones", mock.Anything).
 		Return(iter.NewEmptyIterator[*metastorev1.Tombstones](), nil)
+	tombstones.On("ListSeriesTombstones", "A").Return(nil)
 
 	compactor := NewCompactor(testConfig, queueStore, tombstones, nil)
 	require.NoError(t, compactor.Restore(nil))
@@ -126,3 +133,38 @@ func TestCompactor_Restore(t *testing.T) {
 	queueStore.AssertExpectations(t)
 	tombstones.AssertExpectations(t)
 }
+
+func TestCompactor_Pending(t *testing.T) {
+	queueStore := new(mockcompactor.MockBlockQueueStore)
+	queueStore.On("StoreEntry", mock.Anything, mock.Anything).Return(nil)
+	queueStore.On("DeleteEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	rewrite := &metastorev1.Tombstones{Datasets: &metastorev1.DatasetTombstones{Name: "series-1-A-0-L1"}}
+	tombstones := new(mockcompactor.MockTombstones)
+	tombstones.On("ListTombstones", mock.Anything).Return(func(time.Time) iter.Iterator[*metastorev1.Tombstones] {
+		return iter.NewSliceIterator([]*metastorev1.Tombstones{rewrite})
+	})
+
+	compactor := NewCompactor(testConfig, queueStore, tombstones, nil)
+	block := ulid.MustNew(10, nil).String()
+	require.NoError(t, compactor.Compact(nil, compaction.BlockEntry{ID: block, Tenant: "A"}))
+
+	before := time.UnixMilli(20).UnixNano()
+	series := &metastorev1.SeriesTombstone{Name: "series-2", Tenant: "A"}
+	// The block is queued.
+	assert.True(t, compactor.Pending(series, before))
+	// The block is created after the given time.
+	assert.False(t, compactor.Pending(series, time.UnixMilli(5).UnixNano()))
+	// The block belongs to another tenant.
+	assert.False(t, compactor.Pending(&metastorev1.SeriesTombstone{Name: "series-2", Tenant: "B"}, before))
+
+	require.NoError(t, compactor.UpdatePlan(nil, &raft_log.CompactionPlanUpdate{
+		NewJobs: []*raft_log.NewCompactionJob{{Plan: &raft_log.CompactionJobPlan{
+			Tenant:       "A",
+			SourceBlocks: []string{block},
+		}}},
+	}))
+	assert.False(t, compactor.Pending(series, before))
+	// The rewrite tombstone of the series tombstone is pending.
+	assert.True(t, compactor.Pending(&metastorev1.SeriesTombstone{Name: "series-1", Tenant: "A"}, before))
+}
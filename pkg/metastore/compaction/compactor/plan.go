@@ -21,6 +21,7 @@ type plan struct {
 	level uint32
 	// Read-only.
 	tombstones iter.Iterator[*metastorev1.Tombstones]
+	rewrites   iter.Iterator[*metastorev1.Tombstones]
 	compactor  *Compactor
 	batches    *batchIter
 	blocks     *blockIter
@@ -29,9 +30,15 @@ type plan struct {
 
 func (p *plan) CreateJob() (*raft_log.CompactionJobPlan, error) {
 	planned := p.nextJob()
+	if planned == nil {
+		planned = p.nextRewriteJob()
+	}
 	if planned == nil {
 		return nil, nil
 	}
+	// Series tombstones are attached to all the jobs of the tenant:
+	// the deleted series are removed from the compacted blocks.
+	planned.tombstones = append(planned.tombstones, p.compactor.tombstones.ListSeriesTombstones(planned.tenant)...)
 	job := raft_log.CompactionJobPlan{
 		Name:            planned.name,
 		Shard:           planned.shard,
@@ -168,11 +175,57 @@ func (p *plan) getTombstones(job *jobPlan) {
 		return
 	}
 	s := int(p.compactor.config.CleanupBatchSize)
-	for i := 0; i < s && p.tombstones.Next(); i++ {
-		job.tombstones = append(job.tombstones, p.tombstones.At())
+	for len(job.tombstones) < s && p.tombstones.Next() {
+		// Dataset tombstones are handled by rewrite jobs.
+		if t := p.tombstones.At(); t.Datasets == nil {
+			job.tombstones = append(job.tombstones, t)
+		}
 	}
 }
 
+// nextRewriteJob plans a job for the blocks of a dataset tombstone:
+// the blocks are compacted without the deleted profiles. Rewrite jobs
+// are only planned once no compaction jobs are left.
+//
+// Blocks of the levels subject to compaction are only rewritten if they
+// are still in the compaction queue: otherwise, the blocks are already
+// being compacted, and the tombstone is attached to the next rewrite job
+// to be deleted.
+func (p *plan) nextRewriteJob() *jobPlan {
+	var stale []*metastorev1.Tombstones
+	for p.rewrites.Next() {
+		t := p.rewrites.At()
+		if t.Datasets == nil {
+			continue
+		}
+		k := compactionKey{
+			tenant: t.Datasets.Tenant,
+			shard:  t.Datasets.Shard,
+			level:  t.Datasets.CompactionLevel,
+		}
+		job := p.newJob()
+		job.reset(k)
+		for _, b := range t.Datasets.Blocks {
+			// Blocks planned for compaction are skipped.
+			if _, visited := p.blocks.visited[b]; !visited && p.compactor.queued(k, b) {
+				job.blocks = append(job.blocks, b)
+			}
+		}
+		if len(job.blocks) == 0 {
+			stale = append(stale, t)
+			continue
+		}
+		for _, b := range job.blocks {
+			p.blocks.visited[b] = struct{}{}
+		}
+		job.tombstones = append(job.tombstones, t)
+		job.tombstones = append(job.tombstones, stale...)
+		job.finalize()
+		return job
+	}
+	return nil
+}
+
 func (p *plan) newJob() *jobPlan {
 	return &jobPlan{
 		config: &p.compactor.config,
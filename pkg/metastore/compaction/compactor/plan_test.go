@@ -9,6 +9,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/pkg/iter"
 	"github.com/grafana/pyroscope/pkg/metastore/compaction"
 	"github.com/grafana/pyroscope/pkg/test"
 )
@@ -270,6 +272,58 @@ func TestPlan_deleted_batch(t *testing.T) {
 	assert.Nil(t, p.nextJob())
 }
 
+func TestPlan_rewrite_jobs(t *testing.T) {
+	c := NewCompactor(testConfig, nil, nil, nil)
+	for i, e := range []compaction.BlockEntry{
+		{Tenant: "A", Shard: 1, Level: 0, ID: "1"},
+		{Tenant: "A", Shard: 1, Level: 0, ID: "2"},
+	} {
+		e.Index = uint64(i)
+		c.enqueue(e)
+	}
+
+	datasets := func(name string, level uint32, blocks ...string) *metastorev1.Tombstones {
+		return &metastorev1.Tombstones{Datasets: &metastorev1.DatasetTombstones{
+			Name:            name,
+			Tenant:          "A",
+			Shard:           1,
+			CompactionLevel: level,
+			Blocks:          blocks,
+		}}
+	}
+	tombstones := []*metastorev1.Tombstones{
+		// Block "3" is not in the compaction queue.
+		datasets("L0", 0, "1", "3"),
+		// None of the blocks are in the compaction queue.
+		datasets("L1", 1, "4"),
+		// Blocks of the level are not subject to compaction.
+		datasets("L3", 3, "5", "6"),
+	}
+
+	p := &plan{
+		compactor:  c,
+		tombstones: iter.NewEmptyIterator[*metastorev1.Tombstones](),
+		rewrites:   iter.NewSliceIterator(tombstones),
+		blocks:     newBlockIter(),
+	}
+	require.Nil(t, p.nextJob())
+
+	j := p.nextRewriteJob()
+	require.NotNil(t, j)
+	assert.Equal(t, compactionKey{tenant: "A", shard: 1, level: 0}, j.compactionKey)
+	assert.Equal(t, []string{"1"}, j.blocks)
+	assert.Equal(t, tombstones[:1], j.tombstones)
+
+	// The stale tombstone is attached to the next job.
+	j = p.nextRewriteJob()
+	require.NotNil(t, j)
+	assert.Equal(t, compactionKey{tenant: "A", shard: 1, level: 3}, j.compactionKey)
+	assert.Equal(t, []string{"5", "6"}, j.blocks)
+	assert.Equal(t, []*metastorev1.Tombstones{tombstones[2], tombstones[1]}, j.tombstones)
+
+	assert.Nil(t, p.nextRewriteJob())
+}
+
 func TestPlan_compact_by_time(t *testing.T) {
 	c := NewCompactor(Config{
 		Levels: []LevelConfig{
@@ -144,6 +144,26 @@ func (sc *Scheduler) UpdateSchedule(tx *bbolt.Tx, update *raft_log.CompactionPla
 	return nil
 }
 
+func (sc *Scheduler) Scheduled(tx *bbolt.Tx, tenant string, before int64) (bool, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	for name := range sc.queue.jobs {
+		plan, err := sc.store.GetJobPlan(tx, name)
+		if err != nil {
+			return false, err
+		}
+		if plan.Tenant != tenant {
+			continue
+		}
+		for _, b := range plan.SourceBlocks {
+			if util.ULIDStringUnixNano(b) < before {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 func (sc *Scheduler) Init(tx *bbolt.Tx) error {
 	return sc.store.CreateBuckets(tx)
 }
@@ -2,8 +2,10 @@ package scheduler
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/raft"
+	"github.com/oklog/ulid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -109,3 +111,33 @@ func TestScheduler_Restore(t *testing.T) {
 
 	store.AssertExpectations(t)
 }
+
+func TestScheduler_Scheduled(t *testing.T) {
+	block := ulid.MustNew(10, nil).String()
+	store := new(mockscheduler.MockJobStore)
+	store.On("GetJobPlan", mock.Anything, "1").Return(&raft_log.CompactionJobPlan{
+		Name:         "1",
+		Tenant:       "A",
+		SourceBlocks: []string{block},
+	}, nil)
+
+	scheduler := NewScheduler(Config{}, store, nil)
+	scheduled, err := scheduler.Scheduled(nil, "A", time.UnixMilli(20).UnixNano())
+	require.NoError(t, err)
+	assert.False(t, scheduled)
+
+	scheduler.queue.put(&raft_log.CompactionJobState{Name: "1"})
+	for _, tc := range []struct {
+		tenant   string
+		before   int64
+		expected bool
+	}{
+		{tenant: "A", before: time.UnixMilli(20).UnixNano(), expected: true},
+		{tenant: "A", before: time.UnixMilli(5).UnixNano(), expected: false},
+		{tenant: "B", before: time.UnixMilli(20).UnixNano(), expected: false},
+	} {
+		scheduled, err = scheduler.Scheduled(nil, tc.tenant, tc.before)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, scheduled)
+	}
+}
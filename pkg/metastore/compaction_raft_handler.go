@@ -1,6 +1,8 @@
 package metastore
 
 import (
+	"time"
+
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/hashicorp/raft"
@@ -166,7 +168,10 @@ func (h *CompactionCommandHandler) UpdateCompactionPlan(
 
 	for _, job := range req.PlanUpdate.CompletedJobs {
 		compacted := job.GetCompactedBlocks()
-		if compacted == nil || compacted.SourceBlocks == nil || len(compacted.NewBlocks) == 0 {
+		// Note that a rewrite job may delete all the source block profiles:
+		// no new blocks are created in this case.
+		if compacted == nil || compacted.SourceBlocks == nil ||
+			(len(compacted.NewBlocks) == 0 && len(compacted.SourceBlocks.Blocks) == 0) {
 			level.Warn(h.logger).Log("msg", "compacted blocks are missing; skipping", "job", job.State.Name)
 			continue
 		}
@@ -184,11 +189,44 @@ func (h *CompactionCommandHandler) UpdateCompactionPlan(
 			level.Error(h.logger).Log("msg", "failed to replace blocks", "err", err)
 			return nil, err
 		}
+		if err := h.expireSeriesTombstones(tx, cmd, compacted.SourceBlocks.Tenant); err != nil {
+			level.Error(h.logger).Log("msg", "failed to expire series tombstones", "err", err)
+			return nil, err
+		}
 	}
 
 	return &raft_log.UpdateCompactionPlanResponse{PlanUpdate: req.PlanUpdate}, nil
 }
 
+// Blocks are created shortly after the profiles are ingested: blocks
+// created later than the delay after the series tombstone time range
+// are not expected to include the series profiles.
+const seriesIngestionDelay = time.Minute
+
+// expireSeriesTombstones deletes the series tombstones of the tenant once
+// compaction has covered their time range: the blocks that may include the
+// series profiles have been compacted or rewritten with the tombstone
+// applied, and no new blocks are expected in the range.
+func (h *CompactionCommandHandler) expireSeriesTombstones(tx *bbolt.Tx, cmd *raft.Log, tenant string) error {
+	for _, t := range h.tombstones.ListSeriesTombstones(tenant) {
+		before := time.UnixMilli(t.Series.EndTime).Add(seriesIngestionDelay)
+		if !before.Before(cmd.AppendedAt) || h.planner.Pending(t.Series, before.UnixNano()) {
+			continue
+		}
+		scheduled, err := h.scheduler.Scheduled(tx, tenant, before.UnixNano())
+		if err != nil {
+			return err
+		}
+		if scheduled {
+			continue
+		}
+		if err = h.tombstones.DeleteSeriesTombstones(tx, t.Series.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func blockTombstonesForCompletedJob(job *raft_log.CompletedCompactionJob) *metastorev1.Tombstones {
 	source := job.CompactedBlocks.SourceBlocks
 	return &metastorev1.Tombstones{
@@ -2,18 +2,25 @@ package retention
 
 import (
 	"flag"
+	"fmt"
 	"iter"
 	"math"
 	"slices"
+	"strconv"
 	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
 	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"go.etcd.io/bbolt"
 
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
 	indexstore "github.com/grafana/pyroscope/pkg/metastore/index/store"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 )
 
 // Policy determines which parts of the index should be retained or deleted.
@@ -25,6 +32,16 @@ type Policy interface {
 
 type Config struct {
 	RetentionPeriod model.Duration `yaml:"retention_period" doc:"hidden"`
+	RetentionRules  []Rule         `yaml:"retention_rules" doc:"hidden"`
+}
+
+// Rule overrides the retention period for profiles matching the selector,
+// e.g. {service_name="api",__profile_type__=~"memory:.*"}. Only service_name
+// and __profile_type__ labels can be used. The first matching rule applies;
+// profiles that do not match any rule are subject to the retention period.
+type Rule struct {
+	Selector        string         `yaml:"selector"`
+	RetentionPeriod model.Duration `yaml:"retention_period"`
 }
 
 type Overrides interface {
@@ -36,6 +53,32 @@ func (c *Config) RegisterFlags(f *flag.FlagSet) {
 	f.Var(&c.RetentionPeriod, "retention-period", "Retention period for the data. 0 means data never deleted.")
 }
 
+func (c *Config) Validate() error {
+	for i, r := range c.RetentionRules {
+		if _, err := r.matchers(); err != nil {
+			return fmt.Errorf("retention rule at pos %d is not valid: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (c *Config) equal(x Config) bool {
+	return c.RetentionPeriod == x.RetentionPeriod && slices.Equal(c.RetentionRules, x.RetentionRules)
+}
+
+func (r *Rule) matchers() ([]*labels.Matcher, error) {
+	matchers, err := parser.ParseMetricSelector(r.Selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range matchers {
+		if m.Name != phlaremodel.LabelNameServiceName && m.Name != phlaremodel.LabelNameProfileType {
+			return nil, fmt.Errorf("label %q is not supported", m.Name)
+		}
+	}
+	return matchers, nil
+}
+
 // TimeBasedRetentionPolicy implements a retention policy based on time.
 type TimeBasedRetentionPolicy struct {
 	logger        log.Logger
@@ -49,8 +92,83 @@ type TimeBasedRetentionPolicy struct {
 }
 
 type marker struct {
-	tenantID  string
+	tenantID string
+	// The time before which some of the data should be deleted:
+	// the latest of the retention period and rule boundaries.
 	timestamp time.Time
+	// The time before which the whole shard should be deleted:
+	// the earliest of the boundaries; zero if any of the periods
+	// is infinite.
+	shard time.Time
+	// The time before which profiles not matching any of the
+	// rules should be deleted; zero if the period is infinite.
+	period time.Time
+	rules  []rule
+}
+
+type rule struct {
+	matchers  []*labels.Matcher
+	timestamp time.Time
+}
+
+func newMarker(tenantID string, c Config, now time.Time) *marker {
+	m := &marker{
+		tenantID: tenantID,
+		period:   boundary(now, c.RetentionPeriod),
+	}
+	m.timestamp = m.period
+	m.shard = m.period
+	for _, r := range c.RetentionRules {
+		matchers, err := r.matchers()
+		if err != nil {
+			// Rules are validated at load.
+			continue
+		}
+		t := boundary(now, r.RetentionPeriod)
+		m.rules = append(m.rules, rule{matchers: matchers, timestamp: t})
+		if t.After(m.timestamp) {
+			m.timestamp = t
+		}
+		if t.Before(m.shard) {
+			m.shard = t
+		}
+	}
+	return m
+}
+
+// boundary returns the time before which data should be deleted:
+// zero value means no retention period.
+func boundary(now time.Time, period model.Duration) time.Time {
+	if period > 0 {
+		return now.Add(-time.Duration(period))
+	}
+	return time.Time{}
+}
+
+// expired reports whether the profiles of the label set have passed
+// the retention period, if all of them are older than maxTime.
+func (m *marker) expired(serviceName, profileType string, maxTime time.Time) bool {
+	t := m.period
+	for _, r := range m.rules {
+		if matches(r.matchers, serviceName, profileType) {
+			t = r.timestamp
+			break
+		}
+	}
+	return !t.IsZero() && maxTime.Before(t)
+}
+
+func matches(matchers []*labels.Matcher, serviceName, profileType string) bool {
+	for _, m := range matchers {
+		v := serviceName
+		if m.Name == phlaremodel.LabelNameProfileType {
+			v = profileType
+		}
+		if !m.Matches(v) {
+			return false
+		}
+	}
+	return true
 }
 
 func NewTimeBasedRetentionPolicy(
@@ -71,20 +189,13 @@ func NewTimeBasedRetentionPolicy(
 	// Markers indicate the time before which data should be deleted
 	// for a given tenant.
 	for tenantID, override := range tenantOverrides {
-		if defaults.RetentionPeriod == override.RetentionPeriod {
+		if defaults.equal(override) {
 			continue
 		}
 		// An override is defined for the tenant, so we need to adjust the
 		// retention period for it. By default, we assume that the retention
 		// period is not defined, i.e. is infinite.
-		var timestamp time.Time // zero value means no retention period.
-		if period := time.Duration(override.RetentionPeriod); period > 0 {
-			timestamp = now.Add(-period)
-		}
-		m := &marker{
-			tenantID:  tenantID,
-			timestamp: timestamp,
-		}
+		m := newMarker(tenantID, override, now)
 		rp.markers = append(rp.markers, m)
 		rp.overrides[tenantID] = m
 	}
@@ -92,8 +203,8 @@ func NewTimeBasedRetentionPolicy(
 	// the marker if the retention period is not set. This allows us to avoid
 	// checking all partition tenant shards, and instead only check specific
 	// tenants that have a defined retention policy.
-	if defaults.RetentionPeriod > 0 {
-		rp.defaultPeriod = &marker{timestamp: now.Add(-time.Duration(defaults.RetentionPeriod))}
+	if m := newMarker("", defaults, now); !m.timestamp.IsZero() {
+		rp.defaultPeriod = m
 		rp.markers = append(rp.markers, rp.defaultPeriod)
 	}
 	// It is fine if there are marker pointing to the same time: for example,
@@ -241,7 +352,9 @@ func (rp *TimeBasedRetentionPolicy) createTombstonesForTenants(q *indexstore.Par
 			m = o
 		} else if rp.defaultPeriod != nil {
 			// Tenant-specific marker using the default retention period.
-			m = &marker{tenantID: tenantID, timestamp: rp.defaultPeriod.timestamp}
+			c := *rp.defaultPeriod
+			c.tenantID = tenantID
+			m = &c
 		} else {
 			// No retention policy for this tenant, and no default:
 			// we retain the data indefinitely.
@@ -267,7 +380,8 @@ func (rp *TimeBasedRetentionPolicy) createTombstonesForAnonTenant(q *indexstore.
 	// We want to bypass the timestamp check for the anonymous tenant:
 	// we know that if all the other tenants have been processed, it's
 	// safe to create tombstones for the anonymous tenant.
-	rp.createTombstones(q, &marker{timestamp: time.UnixMilli(math.MaxInt64)})
+	t := time.UnixMilli(math.MaxInt64)
+	rp.createTombstones(q, &marker{timestamp: t, shard: t})
 }
 
 func (rp *TimeBasedRetentionPolicy) hasTenants(q *indexstore.PartitionQuery) bool {
@@ -287,7 +401,8 @@ func (rp *TimeBasedRetentionPolicy) createTombstones(q *indexstore.PartitionQuer
 			return false
 		}
 		maxTime := time.Unix(0, shard.ShardIndex.MaxTime)
-		if maxTime.Before(m.timestamp) {
+		switch {
+		case maxTime.Before(m.shard):
 			// The shard does not contain data before the marker.
 			name := shard.TombstoneName()
 			level.Debug(rp.logger).Log("msg", "creating tombstone", "name", name)
@@ -300,7 +415,113 @@ func (rp *TimeBasedRetentionPolicy) createTombstones(q *indexstore.PartitionQuer
 					Tenant:    shard.Tenant,
 				},
 			})
+
+		case len(m.rules) > 0 && maxTime.Before(m.timestamp):
+			// Some of the shard profiles may have passed
+			// the retention period of the matching rules.
+			if !rp.createDatasetTombstones(q, shard, maxTime, m) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// createDatasetTombstones inspects the shard blocks and creates tombstones
+// for the profiles that have passed the retention period. A tombstone is
+// created for each compaction level: it lists the blocks to be rewritten,
+// and the label sets of the profiles to be deleted.
+func (rp *TimeBasedRetentionPolicy) createDatasetTombstones(
+	q *indexstore.PartitionQuery,
+	shard indexstore.Shard,
+	maxTime time.Time,
+	m *marker,
+) bool {
+	s, err := q.Shard(shard.Tenant, shard.Shard)
+	if err != nil || s == nil {
+		level.Warn(rp.logger).Log("msg", "cannot load shard, skipping", "shard", shard.Shard, "err", err)
+		return true
+	}
+	st := s.StringTable.Strings
+	lookup := func(x int32) string {
+		if x >= 0 && int(x) < len(st) {
+			return st[x]
+		}
+		return ""
+	}
+
+	type levelTombstones struct {
+		*metastorev1.DatasetTombstones
+		seen map[[2]string]struct{}
+	}
+	var levels []*levelTombstones
+	for md := range q.Blocks(s) {
+		var t *levelTombstones
+		for _, x := range levels {
+			if x.CompactionLevel == md.CompactionLevel {
+				t = x
+				break
+			}
+		}
+		var expired bool
+		for _, ds := range md.Datasets {
+			if ds.Name == 0 {
+				continue
+			}
+			pairs := metadata.LabelPairs(ds.Labels)
+			for pairs.Next() {
+				var k [2]string
+				p := pairs.At()
+				for i := 0; i < len(p); i += 2 {
+					switch lookup(p[i]) {
+					case phlaremodel.LabelNameServiceName:
+						k[0] = lookup(p[i+1])
+					case phlaremodel.LabelNameProfileType:
+						k[1] = lookup(p[i+1])
+					}
+				}
+				if k[1] == "" || !m.expired(k[0], k[1], maxTime) {
+					// Label sets that do not identify profiles
+					// (e.g., __unsymbolized__) are ignored.
+					continue
+				}
+				expired = true
+				if t == nil {
+					t = &levelTombstones{
+						DatasetTombstones: &metastorev1.DatasetTombstones{
+							Name:            shard.TombstoneName() + "-L" + strconv.FormatUint(uint64(md.CompactionLevel), 10),
+							Shard:           shard.Shard,
+							Tenant:          shard.Tenant,
+							CompactionLevel: md.CompactionLevel,
+						},
+						seen: make(map[[2]string]struct{}),
+					}
+					levels = append(levels, t)
+				}
+				if _, ok := t.seen[k]; ok {
+					continue
+				}
+				t.seen[k] = struct{}{}
+				t.Labels = append(t.Labels, &typesv1.Labels{Labels: []*typesv1.LabelPair{
+					{Name: phlaremodel.LabelNameServiceName, Value: k[0]},
+					{Name: phlaremodel.LabelNameProfileType, Value: k[1]},
+				}})
+			}
+		}
+		if expired {
+			t.Blocks = append(t.Blocks, md.Id)
+		}
+	}
+
+	slices.SortFunc(levels, func(a, b *levelTombstones) int {
+		return int(a.CompactionLevel) - int(b.CompactionLevel)
+	})
+	for _, t := range levels {
+		if len(rp.tombstones) >= rp.maxTombstones {
+			return false
 		}
+		level.Debug(rp.logger).Log("msg", "creating dataset tombstone", "name", t.Name, "blocks", len(t.Blocks))
+		rp.tombstones = append(rp.tombstones, &metastorev1.Tombstones{Datasets: t.DatasetTombstones})
 	}
 	return true
 }
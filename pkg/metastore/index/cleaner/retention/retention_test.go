@@ -382,3 +382,147 @@ func TestTimeBasedRetentionPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeBasedRetentionPolicy_Rules(t *testing.T) {
+	now := test.Time("2024-01-01T00:00:00Z")
+	createdAt := now.Add(-13 * time.Hour)
+	block := &metastorev1.BlockMeta{
+		Id:              test.ULID(createdAt.Format(time.RFC3339)),
+		Tenant:          1,
+		Shard:           1,
+		CompactionLevel: 1,
+		MinTime:         now.Add(-14 * time.Hour).UnixNano(),
+		MaxTime:         now.Add(-12 * time.Hour).UnixNano(),
+		StringTable: []string{
+			"", "tenant-1", "service_name", "__profile_type__",
+			"svc-a", "svc-b", "cpu", "memory", "__unsymbolized__", "true",
+		},
+		Datasets: []*metastorev1.Dataset{
+			{Tenant: 1, Name: 4, Labels: []int32{
+				2, 2, 4, 3, 6,
+				2, 2, 4, 3, 7,
+				2, 2, 4, 8, 9,
+			}},
+			{Tenant: 1, Name: 5, Labels: []int32{
+				2, 2, 5, 3, 7,
+			}},
+		},
+	}
+
+	type expectedTombstone struct {
+		serviceName string
+		profileType string
+	}
+
+	for _, tc := range []struct {
+		name     string
+		config   Config
+		expected []expectedTombstone
+		shard    bool
+	}{
+		{
+			name: "rule shorter than default",
+			config: Config{
+				RetentionPeriod: model.Duration(24 * time.Hour),
+				RetentionRules: []Rule{
+					{Selector: `{__profile_type__="memory"}`, RetentionPeriod: model.Duration(6 * time.Hour)},
+				},
+			},
+			expected: []expectedTombstone{
+				{serviceName: "svc-a", profileType: "memory"},
+				{serviceName: "svc-b", profileType: "memory"},
+			},
+		},
+		{
+			name: "rule longer than default",
+			config: Config{
+				RetentionPeriod: model.Duration(6 * time.Hour),
+				RetentionRules: []Rule{
+					{Selector: `{service_name="svc-a"}`, RetentionPeriod: model.Duration(48 * time.Hour)},
+				},
+			},
+			expected: []expectedTombstone{
+				{serviceName: "svc-b", profileType: "memory"},
+			},
+		},
+		{
+			name: "first matching rule wins",
+			config: Config{
+				RetentionRules: []Rule{
+					{Selector: `{service_name="svc-a", __profile_type__="cpu"}`, RetentionPeriod: model.Duration(6 * time.Hour)},
+					{Selector: `{service_name=~"svc-.*"}`, RetentionPeriod: model.Duration(48 * time.Hour)},
+				},
+			},
+			expected: []expectedTombstone{
+				{serviceName: "svc-a", profileType: "cpu"},
+			},
+		},
+		{
+			name: "all rules expired",
+			config: Config{
+				RetentionPeriod: model.Duration(6 * time.Hour),
+				RetentionRules: []Rule{
+					{Selector: `{service_name="svc-a"}`, RetentionPeriod: model.Duration(8 * time.Hour)},
+				},
+			},
+			shard: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, tc.config.Validate())
+			db := test.BoltDB(t)
+			store := indexstore.NewIndexStore()
+			require.NoError(t, db.Update(store.CreateBuckets))
+			defer db.Close()
+
+			const partitionDuration = 6 * time.Hour
+			require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+				p := indexstore.NewPartition(createdAt.Truncate(partitionDuration), partitionDuration)
+				return indexstore.NewShard(p, "tenant-1", 1).Store(tx, block.CloneVT())
+			}))
+
+			policy := NewTimeBasedRetentionPolicy(
+				log.NewNopLogger(),
+				&mockOverrides{defaultConfig: tc.config},
+				10,
+				time.Hour,
+				now,
+			)
+
+			require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+				tombstones := policy.CreateTombstones(tx, store.Partitions(tx))
+				if tc.shard {
+					require.Len(t, tombstones, 1)
+					assert.NotNil(t, tombstones[0].Shard)
+					return nil
+				}
+				require.Len(t, tombstones, 1)
+				ds := tombstones[0].Datasets
+				require.NotNil(t, ds)
+				assert.Equal(t, "tenant-1", ds.Tenant)
+				assert.Equal(t, uint32(1), ds.Shard)
+				assert.Equal(t, uint32(1), ds.CompactionLevel)
+				assert.Equal(t, []string{block.Id}, ds.Blocks)
+				actual := make([]expectedTombstone, 0, len(ds.Labels))
+				for _, ls := range ds.Labels {
+					require.Len(t, ls.Labels, 2)
+					actual = append(actual, expectedTombstone{
+						serviceName: ls.Labels[0].Value,
+						profileType: ls.Labels[1].Value,
+					})
+				}
+				assert.ElementsMatch(t, tc.expected, actual)
+				return nil
+			}))
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	c := Config{RetentionRules: []Rule{{Selector: `{service_name="svc-a"}`}}}
+	require.NoError(t, c.Validate())
+	c.RetentionRules = append(c.RetentionRules, Rule{Selector: `{namespace="ns"}`})
+	require.Error(t, c.Validate())
+	c.RetentionRules = []Rule{{Selector: `{service_name=`}}
+	require.Error(t, c.Validate())
+}
@@ -32,6 +32,9 @@ type MetadataQuery struct {
 	EndTime   time.Time
 	Tenant    []string
 	Labels    []string
+	// Step of the query: if specified, datasets downsampled
+	// to a resolution not exceeding the step are preferred.
+	Step time.Duration
 }
 
 func (q *MetadataQuery) String() string {
@@ -49,6 +52,7 @@ type metadataQuery struct {
 	tenantMap map[string]struct{}
 	matchers  []*labels.Matcher
 	labels    []string
+	step      int64 // Milliseconds.
 	index     *Index
 }
 
@@ -66,6 +70,7 @@ func newMetadataQuery(index *Index, query MetadataQuery) (*metadataQuery, error)
 		index:     index,
 		matchers:  matchers,
 		labels:    query.Labels,
+		step:      query.Step.Milliseconds(),
 	}
 	q.buildTenantMap(query.Tenant)
 	return q, nil
@@ -159,7 +164,11 @@ func (q *blockMetadataQuerier) collectMatched(
 	var mdCopy *metastorev1.BlockMeta
 	var ok bool
 	matches := make([]int32, 0, 8)
+	resolution := metadata.ResolutionFilter(md.Datasets, q.query.step)
 	for _, ds := range md.Datasets {
+		if !resolution(ds) {
+			continue
+		}
 		if _, ok := q.query.tenantMap[s.Lookup(ds.Tenant)]; !ok {
 			continue
 		}
@@ -212,6 +221,7 @@ func cloneDatasetMetadataForQuery(ds *metastorev1.Dataset) *metastorev1.Dataset
 		MaxTime:         ds.MaxTime,
 		TableOfContents: ds.TableOfContents,
 		Size:            ds.Size,
+		Resolution:      ds.Resolution,
 		//	Labels:          ds.Labels,
 	}
 }
@@ -259,6 +269,10 @@ func (q *metadataLabelQuerier) collectLabels(s *store.Shard) error {
 		md := q.shards.index.blocks.getOrCreate(s, blocks.At())
 		if q.query.overlapsUnixMilli(md.MinTime, md.MaxTime) {
 			for _, ds := range md.Datasets {
+				if ds.Resolution != 0 {
+					// Downsampled copies have the same labels.
+					continue
+				}
 				if _, ok := q.query.tenantMap[s.StringTable.Lookup(ds.Tenant)]; !ok {
 					continue
 				}
@@ -325,6 +325,83 @@ func TestIndex_Query(t *testing.T) {
 	})
 }
 
+func TestIndex_QueryResolution(t *testing.T) {
+	db := test.BoltDB(t)
+	ctx := context.Background()
+
+	minT := test.UnixMilli("2024-09-23T08:00:00.000Z")
+	maxT := test.UnixMilli("2024-09-23T09:00:00.000Z")
+
+	md := &metastorev1.BlockMeta{
+		Id:              test.ULID("2024-09-23T08:00:00.001Z"),
+		Tenant:          1,
+		CompactionLevel: 2,
+		MinTime:         minT,
+		MaxTime:         maxT,
+		CreatedBy:       2,
+		Datasets: []*metastorev1.Dataset{
+			{Tenant: 1, Name: 3, MinTime: minT, MaxTime: maxT, Labels: []int32{2, 4, 3, 5, 6}},
+			{Tenant: 1, Name: 7, MinTime: minT, MaxTime: maxT, Labels: []int32{2, 4, 7, 5, 6}},
+			{Tenant: 1, Name: 3, MinTime: minT, MaxTime: maxT, Format: 2, Resolution: 300000, Labels: []int32{2, 4, 3, 5, 6}},
+			{Tenant: 1, Name: 3, MinTime: minT, MaxTime: maxT, Format: 2, Resolution: 3600000, Labels: []int32{2, 4, 3, 5, 6}},
+		},
+		StringTable: []string{
+			"", "tenant-a", "compaction-worker", "dataset-a", "service_name", "__profile_type__", "1", "dataset-b",
+		},
+	}
+
+	idx := NewIndex(util.Logger, NewStore(), DefaultConfig)
+	tx, err := db.Begin(true)
+	require.NoError(t, err)
+	require.NoError(t, idx.Init(tx))
+	require.NoError(t, idx.InsertBlock(tx, md.CloneVT()))
+	require.NoError(t, tx.Commit())
+
+	tx, err = db.Begin(false)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, tx.Rollback())
+	}()
+
+	for _, tc := range []struct {
+		step     time.Duration
+		expected map[string]int64
+	}{
+		{step: 0, expected: map[string]int64{"dataset-a": 0, "dataset-b": 0}},
+		{step: 15 * time.Second, expected: map[string]int64{"dataset-a": 0, "dataset-b": 0}},
+		{step: 5 * time.Minute, expected: map[string]int64{"dataset-a": 300000, "dataset-b": 0}},
+		{step: 30 * time.Minute, expected: map[string]int64{"dataset-a": 300000, "dataset-b": 0}},
+		{step: 24 * time.Hour, expected: map[string]int64{"dataset-a": 3600000, "dataset-b": 0}},
+	} {
+		found, err := idx.QueryMetadata(tx, ctx, MetadataQuery{
+			Expr:      `{}`,
+			StartTime: time.UnixMilli(minT),
+			EndTime:   time.UnixMilli(maxT),
+			Tenant:    []string{"tenant-a"},
+			Step:      tc.step,
+		})
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		resolutions := make(map[string]int64)
+		for _, ds := range found[0].Datasets {
+			name := found[0].StringTable[ds.Name]
+			require.NotContains(t, resolutions, name, "step %v", tc.step)
+			resolutions[name] = ds.Resolution
+		}
+		assert.Equal(t, tc.expected, resolutions, "step %v", tc.step)
+	}
+
+	labels, err := idx.QueryMetadataLabels(tx, ctx, MetadataQuery{
+		Expr:      `{}`,
+		StartTime: time.UnixMilli(minT),
+		EndTime:   time.UnixMilli(maxT),
+		Tenant:    []string{"tenant-a"},
+		Labels:    []string{model.LabelNameServiceName},
+	})
+	require.NoError(t, err)
+	assert.Len(t, labels, 2)
+}
+
 func TestIndex_QueryConcurrency(t *testing.T) {
 	const N = 10
 	for i := 0; i < N && !t.Failed(); i++ {
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"go.etcd.io/bbolt"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 )
 
 var ErrInvalidPartitionKey = errors.New("invalid partition key")
@@ -136,3 +138,30 @@ func (q *PartitionQuery) Shards(tenant string) iter.Seq[Shard] {
 		}
 	}
 }
+
+// Shard loads the tenant shard, including the string table.
+// Nil is returned if the shard does not exist.
+func (q *PartitionQuery) Shard(tenant string, shard uint32) (*Shard, error) {
+	return loadTenantShard(q.tx, q.Partition, tenant, shard)
+}
+
+// Blocks returns metadata entries of the shard blocks. Note that the
+// entries do not include the string table: the references point to
+// the shard string table.
+func (q *PartitionQuery) Blocks(s *Shard) iter.Seq[*metastorev1.BlockMeta] {
+	return func(yield func(*metastorev1.BlockMeta) bool) {
+		blocks := s.Blocks(q.tx)
+		if blocks == nil {
+			return
+		}
+		for blocks.Next() {
+			var md metastorev1.BlockMeta
+			if err := md.UnmarshalVT(blocks.At().Value); err != nil {
+				continue
+			}
+			if !yield(&md) {
+				return
+			}
+		}
+	}
+}
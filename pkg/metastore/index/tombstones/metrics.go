@@ -27,8 +27,10 @@ func newMetrics(r prometheus.Registerer) *metrics {
 }
 
 const (
-	tombstoneTypeBlocks = "blocks"
-	tombstoneTypeShard  = "shard"
+	tombstoneTypeBlocks   = "blocks"
+	tombstoneTypeShard    = "shard"
+	tombstoneTypeDatasets = "datasets"
+	tombstoneTypeSeries   = "series"
 )
 
 func (m *metrics) incrementTombstones(t *metastorev1.Tombstones) {
@@ -38,6 +40,12 @@ func (m *metrics) incrementTombstones(t *metastorev1.Tombstones) {
 	if t.Shard != nil {
 		m.tombstones.WithLabelValues(t.Shard.Tenant, tombstoneTypeShard).Inc()
 	}
+	if t.Datasets != nil {
+		m.tombstones.WithLabelValues(t.Datasets.Tenant, tombstoneTypeDatasets).Inc()
+	}
+	if t.Series != nil {
+		m.tombstones.WithLabelValues(t.Series.Tenant, tombstoneTypeSeries).Inc()
+	}
 }
 
 func (m *metrics) decrementTombstones(t *metastorev1.Tombstones) {
@@ -47,4 +55,10 @@ func (m *metrics) decrementTombstones(t *metastorev1.Tombstones) {
 	if t.Shard != nil {
 		m.tombstones.WithLabelValues(t.Shard.Tenant, tombstoneTypeShard).Dec()
 	}
+	if t.Datasets != nil {
+		m.tombstones.WithLabelValues(t.Datasets.Tenant, tombstoneTypeDatasets).Dec()
+	}
+	if t.Series != nil {
+		m.tombstones.WithLabelValues(t.Series.Tenant, tombstoneTypeSeries).Dec()
+	}
 }
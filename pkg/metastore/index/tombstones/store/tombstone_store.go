@@ -32,6 +32,10 @@ func (e TombstoneEntry) Name() string {
 		return e.Tombstones.Blocks.Name
 	case e.Tombstones.Shard != nil:
 		return e.Tombstones.Shard.Name
+	case e.Tombstones.Datasets != nil:
+		return e.Tombstones.Datasets.Name
+	case e.Tombstones.Series != nil:
+		return e.Tombstones.Series.Name
 	default:
 		return ""
 	}
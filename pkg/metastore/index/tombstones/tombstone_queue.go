@@ -17,6 +17,10 @@ func (k *tombstoneKey) set(t *metastorev1.Tombstones) bool {
 		*k = tombstoneKey(t.Blocks.Name)
 	case t.Shard != nil:
 		*k = tombstoneKey(t.Shard.Name)
+	case t.Datasets != nil:
+		*k = tombstoneKey(t.Datasets.Name)
+	case t.Series != nil:
+		*k = tombstoneKey(t.Series.Name)
 	}
 	return len(*k) > 0
 }
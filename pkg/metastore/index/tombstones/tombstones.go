@@ -1,6 +1,8 @@
 package tombstones
 
 import (
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/raft"
@@ -25,6 +27,11 @@ type Tombstones struct {
 	blocks     map[tenantBlockKey]*tenantBlocks
 	queue      *tombstoneQueue
 	store      TombstoneStore
+	// Series tombstones are not queued: they are attached to all the
+	// compaction jobs of the tenant, until explicitly deleted. Unlike
+	// other tombstones, they are accessed by queries concurrently.
+	seriesMu sync.RWMutex
+	series   []store.TombstoneEntry
 }
 
 type tenantBlockKey struct {
@@ -91,6 +98,11 @@ func (x *Tombstones) DeleteTombstones(tx *bbolt.Tx, cmd *raft.Log, tombstones ..
 }
 
 func (x *Tombstones) deleteTombstones(tx *bbolt.Tx, _ *raft.Log, t *metastorev1.Tombstones) error {
+	if t.Series != nil {
+		// Series tombstones outlive the compaction
+		// jobs: see DeleteSeriesTombstones.
+		return nil
+	}
 	var k tombstoneKey
 	if !k.set(t) {
 		return nil
@@ -102,7 +114,61 @@ func (x *Tombstones) deleteTombstones(tx *bbolt.Tx, _ *raft.Log, t *metastorev1.
 	return x.store.DeleteTombstones(tx, e.TombstoneEntry)
 }
 
+// ListSeriesTombstones returns the series tombstones of the tenants,
+// in the order of creation. If no tenants are specified, tombstones
+// of all tenants are returned.
+func (x *Tombstones) ListSeriesTombstones(tenants ...string) []*metastorev1.Tombstones {
+	x.seriesMu.RLock()
+	defer x.seriesMu.RUnlock()
+	var tombstones []*metastorev1.Tombstones
+	for _, e := range x.series {
+		if len(tenants) == 0 || slices.Contains(tenants, e.Series.Tenant) {
+			tombstones = append(tombstones, e.Tombstones)
+		}
+	}
+	return tombstones
+}
+
+// DeleteSeriesTombstones deletes the series tombstones with the given names.
+func (x *Tombstones) DeleteSeriesTombstones(tx *bbolt.Tx, names ...string) error {
+	x.seriesMu.Lock()
+	defer x.seriesMu.Unlock()
+	for _, name := range names {
+		i := x.seriesIndex(name)
+		if i < 0 {
+			continue
+		}
+		e := x.series[i]
+		x.series = slices.Delete(x.series, i, i+1)
+		x.metrics.decrementTombstones(e.Tombstones)
+		if err := x.store.DeleteTombstones(tx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Tombstones) seriesIndex(name string) int {
+	return slices.IndexFunc(x.series, func(e store.TombstoneEntry) bool {
+		return e.Series.Name == name
+	})
+}
+
+func (x *Tombstones) putSeries(v store.TombstoneEntry) bool {
+	x.seriesMu.Lock()
+	defer x.seriesMu.Unlock()
+	if x.seriesIndex(v.Series.Name) >= 0 {
+		return false
+	}
+	x.series = append(x.series, v)
+	x.metrics.incrementTombstones(v.Tombstones)
+	return true
+}
+
 func (x *Tombstones) put(k tombstoneKey, v store.TombstoneEntry) bool {
+	if v.Tombstones.Series != nil {
+		return x.putSeries(v)
+	}
 	if _, found := x.tombstones[k]; found {
 		return false
 	}
@@ -171,6 +237,9 @@ func (x *Tombstones) Restore(tx *bbolt.Tx) error {
 	x.queue = newTombstoneQueue()
 	clear(x.tombstones)
 	clear(x.blocks)
+	x.seriesMu.Lock()
+	x.series = x.series[:0]
+	x.seriesMu.Unlock()
 	x.metrics.tombstones.Reset()
 	entries := x.store.ListEntries(tx)
 	defer func() {
@@ -88,6 +88,72 @@ func TestTombstonesIdempotence(t *testing.T) {
 	})
 }
 
+func TestSeriesTombstones(t *testing.T) {
+	db := test.BoltDB(t)
+	tombstoneStore := store.NewTombstoneStore()
+
+	ts := NewTombstones(tombstoneStore, nil)
+	tx, err := db.Begin(true)
+	require.NoError(t, err)
+	require.NoError(t, ts.Init(tx))
+	require.NoError(t, tx.Commit())
+
+	series := func(name, tenant string) *metastorev1.Tombstones {
+		return &metastorev1.Tombstones{Series: &metastorev1.SeriesTombstone{
+			Name:          name,
+			Tenant:        tenant,
+			LabelSelector: `{service_name="x"}`,
+			StartTime:     1,
+			EndTime:       2,
+		}}
+	}
+	a1 := series("series-1", "tenant-a")
+	b := series("series-2", "tenant-b")
+	a2 := series("series-3", "tenant-a")
+
+	now := time.Now()
+	for i, x := range []*metastorev1.Tombstones{a1, b, a2, a1} {
+		tx, err = db.Begin(true)
+		require.NoError(t, err)
+		cmd := &raft.Log{Index: uint64(i + 1), AppendedAt: now}
+		require.NoError(t, ts.AddTombstones(tx, cmd, x))
+		require.NoError(t, tx.Commit())
+	}
+
+	// Series tombstones are not queued for deletion.
+	assert.Equal(t, 0, countTombstones(ts))
+	assert.Equal(t, []*metastorev1.Tombstones{a1, a2}, ts.ListSeriesTombstones("tenant-a"))
+	assert.Equal(t, []*metastorev1.Tombstones{b}, ts.ListSeriesTombstones("tenant-b"))
+	assert.Equal(t, []*metastorev1.Tombstones{a1, b, a2}, ts.ListSeriesTombstones())
+
+	// Series tombstones outlive the compaction jobs.
+	tx, err = db.Begin(true)
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteTombstones(tx, new(raft.Log), a1, b))
+	require.NoError(t, tx.Commit())
+	assert.Len(t, ts.ListSeriesTombstones(), 3)
+
+	tx, err = db.Begin(true)
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteSeriesTombstones(tx, "series-1", "series-unknown"))
+	require.NoError(t, tx.Commit())
+	assert.Len(t, ts.ListSeriesTombstones(), 2)
+
+	restored := NewTombstones(tombstoneStore, nil)
+	tx, err = db.Begin(false)
+	require.NoError(t, err)
+	require.NoError(t, restored.Restore(tx))
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, 0, countTombstones(restored))
+	names := func(tombstones []*metastorev1.Tombstones) (n []string) {
+		for _, x := range tombstones {
+			n = append(n, x.Series.Name)
+		}
+		return n
+	}
+	assert.Equal(t, []string{"series-2", "series-3"}, names(restored.ListSeriesTombstones()))
+}
+
 func countTombstones(ts *Tombstones) int {
 	var c int
 	iter := ts.ListTombstones(time.Now().Add(time.Hour))
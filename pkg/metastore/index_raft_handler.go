@@ -1,6 +1,7 @@
 package metastore
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/go-kit/log"
@@ -32,6 +33,8 @@ type IndexWriter interface {
 type Tombstones interface {
 	AddTombstones(*bbolt.Tx, *raft.Log, *metastorev1.Tombstones) error
 	DeleteTombstones(*bbolt.Tx, *raft.Log, ...*metastorev1.Tombstones) error
+	DeleteSeriesTombstones(tx *bbolt.Tx, names ...string) error
+	ListSeriesTombstones(tenants ...string) []*metastorev1.Tombstones
 	Exists(tenant string, shard uint32, block string) bool
 }
 
@@ -87,6 +90,15 @@ func (m *IndexCommandHandler) TruncateIndex(tx *bbolt.Tx, cmd *raft.Log, req *ra
 		return new(raft_log.TruncateIndexResponse), nil
 	}
 	for _, tombstone := range req.Tombstones {
+		if t := tombstone.Series; t != nil {
+			// Series tombstones are only passed to
+			// TruncateIndex once they have expired.
+			if err := m.tombstones.DeleteSeriesTombstones(tx, t.Name); err != nil {
+				level.Error(m.logger).Log("msg", "failed to delete series tombstone", "err", err)
+				return nil, err
+			}
+			continue
+		}
 		// Although it's not strictly necessary, we may pass any tombstones
 		// to TruncateIndex, and the Partition member may be missing.
 		if p := tombstone.Shard; p != nil {
@@ -106,3 +118,32 @@ func (m *IndexCommandHandler) TruncateIndex(tx *bbolt.Tx, cmd *raft.Log, req *ra
 	}
 	return new(raft_log.TruncateIndexResponse), nil
 }
+
+func (m *IndexCommandHandler) DeleteSeries(tx *bbolt.Tx, cmd *raft.Log, req *raft_log.DeleteSeriesRequest) (*raft_log.DeleteSeriesResponse, error) {
+	if req.Term != cmd.Term {
+		level.Warn(m.logger).Log(
+			"msg", "rejecting series deletion request; term mismatch: leader has changed",
+			"current_term", cmd.Term,
+			"request_term", req.Term,
+		)
+		return new(raft_log.DeleteSeriesResponse), nil
+	}
+	// The name must be deterministic, as the command
+	// is applied on all the replicas independently.
+	t := req.Tombstone
+	t.Name = "series-" + strconv.FormatUint(cmd.Index, 10)
+	if err := m.tombstones.AddTombstones(tx, cmd, &metastorev1.Tombstones{Series: t}); err != nil {
+		level.Error(m.logger).Log("msg", "failed to add series tombstone", "err", err)
+		return nil, err
+	}
+	for _, tombstone := range req.Tombstones {
+		if d := tombstone.Datasets; d != nil {
+			d.Name = t.Name + "-" + d.Name
+			if err := m.tombstones.AddTombstones(tx, cmd, tombstone); err != nil {
+				level.Error(m.logger).Log("msg", "failed to add dataset tombstone", "err", err)
+				return nil, err
+			}
+		}
+	}
+	return &raft_log.DeleteSeriesResponse{Tombstone: t}, nil
+}
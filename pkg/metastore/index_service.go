@@ -3,6 +3,7 @@ package metastore
 import (
 	"context"
 	goiter "iter"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -41,30 +42,39 @@ type IndexReader interface {
 	IndexPartitionLister
 }
 
+type SeriesTombstones interface {
+	// ListSeriesTombstones returns series tombstones of the
+	// tenants specified, or of all tenants, if none specified.
+	ListSeriesTombstones(tenants ...string) []*metastorev1.Tombstones
+}
+
 func NewIndexService(
 	logger log.Logger,
 	raft Raft,
 	state State,
 	index IndexReader,
 	stats PlacementStats,
+	tombstones SeriesTombstones,
 ) *IndexService {
 	return &IndexService{
-		logger: logger,
-		raft:   raft,
-		state:  state,
-		index:  index,
-		stats:  stats,
+		logger:     logger,
+		raft:       raft,
+		state:      state,
+		index:      index,
+		stats:      stats,
+		tombstones: tombstones,
 	}
 }
 
 type IndexService struct {
 	metastorev1.IndexServiceServer
 
-	logger log.Logger
-	raft   Raft
-	state  State
-	index  IndexReader
-	stats  PlacementStats
+	logger     log.Logger
+	raft       Raft
+	state      State
+	index      IndexReader
+	stats      PlacementStats
+	tombstones SeriesTombstones
 }
 
 func (svc *IndexService) AddBlock(
@@ -163,6 +173,7 @@ func (svc *IndexService) TruncateIndex(ctx context.Context, rp retention.Policy)
 	var req raft_log.TruncateIndexRequest
 	read := func(tx *bbolt.Tx, r raftnode.ReadIndex) {
 		req.Tombstones = rp.CreateTombstones(tx, svc.index.Partitions(tx))
+		req.Tombstones = append(req.Tombstones, svc.expiredSeriesTombstones(tx, req.Tombstones)...)
 		req.Term = r.Term // The leader may change after we read the index.
 	}
 	if readErr := svc.state.ConsistentRead(ctx, read); readErr != nil {
@@ -179,3 +190,73 @@ func (svc *IndexService) TruncateIndex(ctx context.Context, rp retention.Policy)
 	}
 	return nil
 }
+
+// expiredSeriesTombstones returns series tombstones that are not needed
+// anymore: no tenant shards overlapping the tombstone time range remain
+// after the truncation, and no new data is expected in the range.
+func (svc *IndexService) expiredSeriesTombstones(tx *bbolt.Tx, truncated []*metastorev1.Tombstones) []*metastorev1.Tombstones {
+	series := svc.tombstones.ListSeriesTombstones()
+	if len(series) == 0 {
+		return nil
+	}
+	deleted := make(map[truncatedShard]struct{}, len(truncated))
+	for _, t := range truncated {
+		if s := t.Shard; s != nil {
+			deleted[truncatedShard{
+				timestamp: s.Timestamp,
+				duration:  s.Duration,
+				tenant:    s.Tenant,
+				shard:     s.Shard,
+			}] = struct{}{}
+		}
+	}
+	now := time.Now().UnixMilli()
+	var expired []*metastorev1.Tombstones
+	for _, t := range series {
+		if t.Series.EndTime >= now {
+			continue
+		}
+		if !svc.seriesDataExists(tx, t.Series, deleted) {
+			expired = append(expired, t)
+		}
+	}
+	return expired
+}
+
+type truncatedShard struct {
+	timestamp int64
+	duration  int64
+	tenant    string
+	shard     uint32
+}
+
+func (svc *IndexService) seriesDataExists(
+	tx *bbolt.Tx,
+	t *metastorev1.SeriesTombstone,
+	deleted map[truncatedShard]struct{},
+) bool {
+	for p := range svc.index.Partitions(tx) {
+		if p.EndTime().UnixMilli() < t.StartTime {
+			continue
+		}
+		q := p.Query(tx)
+		if q == nil {
+			continue
+		}
+		for shard := range q.Shards(t.Tenant) {
+			if shard.ShardIndex.MinTime > t.EndTime || shard.ShardIndex.MaxTime < t.StartTime {
+				continue
+			}
+			k := truncatedShard{
+				timestamp: shard.Partition.Timestamp.UnixNano(),
+				duration:  int64(shard.Partition.Duration),
+				tenant:    shard.Tenant,
+				shard:     shard.Shard,
+			}
+			if _, ok := deleted[k]; !ok {
+				return true
+			}
+		}
+	}
+	return false
+}
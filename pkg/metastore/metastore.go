@@ -146,6 +146,9 @@ func New(
 		m.indexHandler.TruncateIndex)
 
 	m.compactionHandler = NewCompactionCommandHandler(m.logger, m.index, m.compactor, m.compactor, m.scheduler, m.tombstones)
+	fsm.RegisterRaftCommandHandler(m.fsm,
+		fsm.RaftLogEntryType(raft_log.RaftCommand_RAFT_COMMAND_DELETE_SERIES),
+		m.indexHandler.DeleteSeries)
 	fsm.RegisterRaftCommandHandler(m.fsm,
 		fsm.RaftLogEntryType(raft_log.RaftCommand_RAFT_COMMAND_GET_COMPACTION_PLAN_UPDATE),
 		m.compactionHandler.GetCompactionPlanUpdate)
@@ -170,9 +173,9 @@ func New(
 	// Services should be registered after FSM and Raft have been initialized.
 	// Services provide an interface to interact with the metastore components.
 	m.compactionService = NewCompactionService(m.logger, m.raft)
-	m.indexService = NewIndexService(m.logger, m.raft, m.leaderRead, m.index, m.placement)
-	m.tenantService = NewTenantService(m.logger, m.followerRead, m.index)
-	m.queryService = NewQueryService(m.logger, m.followerRead, m.index)
+	m.indexService = NewIndexService(m.logger, m.raft, m.leaderRead, m.index, m.placement, m.tombstones)
+	m.tenantService = NewTenantService(m.logger, m.raft, m.followerRead, m.index)
+	m.queryService = NewQueryService(m.logger, m.followerRead, m.index, m.tombstones)
 	m.recovery = dlq.NewRecovery(logger, config.Index.Recovery, m.indexService, bucket)
 	m.cleaner = cleaner.NewCleaner(m.logger, m.overrides, config.Index.Cleaner, m.indexService)
 
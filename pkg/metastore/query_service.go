@@ -25,20 +25,23 @@ type IndexQuerier interface {
 type QueryService struct {
 	metastorev1.MetadataQueryServiceServer
 
-	logger log.Logger
-	state  State
-	index  IndexQuerier
+	logger     log.Logger
+	state      State
+	index      IndexQuerier
+	tombstones SeriesTombstones
 }
 
 func NewQueryService(
 	logger log.Logger,
 	state State,
 	index IndexQuerier,
+	tombstones SeriesTombstones,
 ) *QueryService {
 	return &QueryService{
-		logger: logger,
-		state:  state,
-		index:  index,
+		logger:     logger,
+		state:      state,
+		index:      index,
+		tombstones: tombstones,
 	}
 }
 
@@ -66,9 +69,13 @@ func (svc *QueryService) queryMetadata(
 		EndTime:   time.UnixMilli(req.EndTime),
 		Expr:      req.Query,
 		Labels:    req.Labels,
+		Step:      time.Duration(req.Step) * time.Millisecond,
 	})
 	if err == nil {
-		return &metastorev1.QueryMetadataResponse{Blocks: metas}, nil
+		return &metastorev1.QueryMetadataResponse{
+			Blocks:           metas,
+			SeriesTombstones: svc.seriesTombstones(req),
+		}, nil
 	}
 	var invalid *index.InvalidQueryError
 	if errors.As(err, &invalid) {
@@ -78,6 +85,22 @@ func (svc *QueryService) queryMetadata(
 	return nil, status.Error(codes.Internal, err.Error())
 }
 
+// seriesTombstones returns the series tombstones overlapping the query
+// time range: the series profiles may not have been deleted from the
+// blocks yet, and must be excluded from the query results.
+func (svc *QueryService) seriesTombstones(req *metastorev1.QueryMetadataRequest) []*metastorev1.SeriesTombstone {
+	if len(req.TenantId) == 0 {
+		return nil
+	}
+	var tombstones []*metastorev1.SeriesTombstone
+	for _, t := range svc.tombstones.ListSeriesTombstones(req.TenantId...) {
+		if t.Series.StartTime <= req.EndTime && t.Series.EndTime >= req.StartTime {
+			tombstones = append(tombstones, t.Series)
+		}
+	}
+	return tombstones
+}
+
 func (svc *QueryService) QueryMetadataLabels(
 	ctx context.Context,
 	req *metastorev1.QueryMetadataLabelsRequest,
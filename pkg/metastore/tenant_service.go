@@ -2,17 +2,28 @@ package metastore
 
 import (
 	"context"
+	"slices"
+	"strconv"
 
 	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
 	"go.etcd.io/bbolt"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1/raft_log"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
+	"github.com/grafana/pyroscope/pkg/metastore/fsm"
+	indexstore "github.com/grafana/pyroscope/pkg/metastore/index/store"
 	"github.com/grafana/pyroscope/pkg/metastore/raftnode"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 )
 
 type TenantIndex interface {
+	IndexPartitionLister
 	GetTenantStats(tx *bbolt.Tx, tenant string) *metastorev1.TenantStats
 }
 
@@ -20,17 +31,20 @@ type TenantService struct {
 	metastorev1.TenantServiceServer
 
 	logger log.Logger
+	raft   Raft
 	state  State
 	index  TenantIndex
 }
 
 func NewTenantService(
 	logger log.Logger,
+	raft Raft,
 	state State,
 	index TenantIndex,
 ) *TenantService {
 	return &TenantService{
 		logger: logger,
+		raft:   raft,
 		state:  state,
 		index:  index,
 	}
@@ -56,3 +70,124 @@ func (svc *TenantService) DeleteTenant(
 	// TODO(kolesnikovae): Implement.
 	return new(metastorev1.DeleteTenantResponse), nil
 }
+
+// DeleteSeries creates a series tombstone: the series profiles are
+// excluded from the query results, and the blocks that include the
+// series are rewritten without the profiles.
+func (svc *TenantService) DeleteSeries(
+	ctx context.Context,
+	req *metastorev1.DeleteSeriesRequest,
+) (*metastorev1.DeleteSeriesResponse, error) {
+	if req.TenantId == "" {
+		return nil, status.Error(codes.InvalidArgument, "tenant ID is required")
+	}
+	if req.StartTime > req.EndTime {
+		return nil, status.Error(codes.InvalidArgument, "start time must not be after end time")
+	}
+	matchers, err := parser.ParseMetricSelector(req.LabelSelector)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	proposal := &raft_log.DeleteSeriesRequest{
+		Tombstone: &metastorev1.SeriesTombstone{
+			Tenant:        req.TenantId,
+			LabelSelector: req.LabelSelector,
+			StartTime:     req.StartTime,
+			EndTime:       req.EndTime,
+		},
+	}
+	read := func(tx *bbolt.Tx, r raftnode.ReadIndex) {
+		proposal.Tombstones = seriesRewriteTombstones(tx, svc.index.Partitions(tx), proposal.Tombstone, matchers)
+		proposal.Term = r.Term // The leader may change after we read the index.
+	}
+	if readErr := svc.state.ConsistentRead(ctx, read); readErr != nil {
+		return nil, status.Error(codes.Unavailable, readErr.Error())
+	}
+	resp, err := svc.raft.Propose(fsm.RaftLogEntryType(raft_log.RaftCommand_RAFT_COMMAND_DELETE_SERIES), proposal)
+	if err != nil {
+		if !raftnode.IsRaftLeadershipError(err) {
+			level.Error(svc.logger).Log("msg", "failed to delete series", "err", err)
+		}
+		return nil, err
+	}
+	t := resp.(*raft_log.DeleteSeriesResponse).Tombstone
+	if t == nil {
+		// The request was rejected by the state machine.
+		return nil, status.Error(codes.Aborted, "leader has changed")
+	}
+	return &metastorev1.DeleteSeriesResponse{Tombstone: t}, nil
+}
+
+// seriesRewriteTombstones creates tombstones for the blocks that may
+// include the series, one per tenant shard and compaction level. The
+// tombstone names are relative to the series tombstone name.
+func seriesRewriteTombstones(
+	tx *bbolt.Tx,
+	partitions func(func(indexstore.Partition) bool),
+	t *metastorev1.SeriesTombstone,
+	matchers []*labels.Matcher,
+) []*metastorev1.Tombstones {
+	// Only the labels of the dataset label sets can
+	// be used to find datasets that include the series.
+	matchers = slices.DeleteFunc(slices.Clone(matchers), func(m *labels.Matcher) bool {
+		return m.Name != phlaremodel.LabelNameServiceName && m.Name != phlaremodel.LabelNameProfileType
+	})
+	var tombstones []*metastorev1.Tombstones
+	for p := range partitions {
+		if p.EndTime().UnixMilli() < t.StartTime {
+			// Blocks of the partition have been created
+			// before the series time range.
+			continue
+		}
+		q := p.Query(tx)
+		if q == nil {
+			continue
+		}
+		for shard := range q.Shards(t.Tenant) {
+			if shard.ShardIndex.MinTime > t.EndTime || shard.ShardIndex.MaxTime < t.StartTime {
+				continue
+			}
+			s, err := q.Shard(shard.Tenant, shard.Shard)
+			if err != nil || s == nil {
+				continue
+			}
+			lm := metadata.NewLabelMatcher(s.StringTable.Strings, matchers)
+			if !lm.IsValid() {
+				continue
+			}
+			var levels []*metastorev1.DatasetTombstones
+			for md := range q.Blocks(s) {
+				if md.MinTime > t.EndTime || md.MaxTime < t.StartTime || !includesSeries(md, lm) {
+					continue
+				}
+				i := slices.IndexFunc(levels, func(x *metastorev1.DatasetTombstones) bool {
+					return x.CompactionLevel == md.CompactionLevel
+				})
+				if i < 0 {
+					i = len(levels)
+					levels = append(levels, &metastorev1.DatasetTombstones{
+						Name:            shard.TombstoneName() + "-L" + strconv.FormatUint(uint64(md.CompactionLevel), 10),
+						Shard:           shard.Shard,
+						Tenant:          shard.Tenant,
+						CompactionLevel: md.CompactionLevel,
+					})
+				}
+				levels[i].Blocks = append(levels[i].Blocks, md.Id)
+			}
+			for _, d := range levels {
+				tombstones = append(tombstones, &metastorev1.Tombstones{Datasets: d})
+			}
+		}
+	}
+	return tombstones
+}
+
+func includesSeries(md *metastorev1.BlockMeta, lm *metadata.LabelMatcher) bool {
+	for _, ds := range md.Datasets {
+		// Datasets without labels may include any series.
+		if ds.Name != 0 && (len(ds.Labels) == 0 || lm.Matches(ds.Labels)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,372 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"google.golang.org/grpc"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/tenant"
+	"github.com/grafana/pyroscope/pkg/util"
+	httputil "github.com/grafana/pyroscope/pkg/util/http"
+)
+
+const (
+	defaultBackfillStep = time.Minute
+	// The value of the pyroscope_instance label of the backfilled series.
+	// The label distinguishes backfilled series from the series recorded at
+	// compaction, so that samples of the two never collide.
+	backfillInstance = "backfill"
+	// Backfilled series may have many samples: they are sent in batches.
+	maxBackfillSamplesPerSend = 10 << 10
+	// The time range is observed in chunks, so that only samples
+	// of a single chunk are held in memory.
+	defaultBackfillChunk = time.Hour
+	// MaxBackfillRange is the longest time range a single backfill
+	// HTTP request may cover: longer ranges must be split by the
+	// client, as the request is handled synchronously.
+	MaxBackfillRange = 6 * time.Hour
+)
+
+// BlockLister lists metadata of the blocks to be backfilled.
+// It is implemented by metastorev1.MetadataQueryServiceClient.
+type BlockLister interface {
+	QueryMetadata(context.Context, *metastorev1.QueryMetadataRequest, ...grpc.CallOption) (*metastorev1.QueryMetadataResponse, error)
+}
+
+type BackfillRequest struct {
+	Tenant string
+	Rule   *phlaremodel.RecordingRule
+	Start  time.Time
+	End    time.Time
+	// Samples are recorded at the profile timestamps aligned to the step.
+	Step time.Duration
+}
+
+type BackfillStats struct {
+	Blocks   int `json:"blocks"`
+	Datasets int `json:"datasets"`
+	Series   int `json:"series"`
+	Samples  int `json:"samples"`
+}
+
+// Backfiller evaluates a recording rule over the existing blocks.
+//
+// Only compacted blocks are evaluated: level 0 blocks are evaluated by
+// the compaction worker. The samples are recorded at the step aligned
+// profile timestamps, and the time range is extended to the step
+// boundaries, therefore, repeated backfills of the same time range
+// produce identical samples.
+//
+// Note that the time range must not overlap with the period when the
+// rule has been in effect: otherwise, the profiles are accounted twice.
+type Backfiller struct {
+	logger   log.Logger
+	storage  objstore.Bucket
+	blocks   BlockLister
+	ruler    Ruler
+	exporter Exporter
+	chunk    time.Duration
+}
+
+func NewBackfiller(
+	logger log.Logger,
+	storage objstore.Bucket,
+	blocks BlockLister,
+	ruler Ruler,
+	exporter Exporter,
+) *Backfiller {
+	return &Backfiller{
+		logger:   logger,
+		storage:  storage,
+		blocks:   blocks,
+		ruler:    ruler,
+		exporter: exporter,
+		chunk:    defaultBackfillChunk,
+	}
+}
+
+func (b *Backfiller) Backfill(ctx context.Context, req BackfillRequest) (*BackfillStats, error) {
+	if req.Rule == nil {
+		return nil, errors.New("recording rule is required")
+	}
+	step := req.Step
+	if step <= 0 {
+		step = defaultBackfillStep
+	}
+	if step < time.Millisecond {
+		return nil, errors.New("step must be at least 1ms")
+	}
+	start, end := alignTimeRange(req.Start, req.End, step)
+	if start >= end {
+		return nil, errors.New("invalid time range")
+	}
+
+	stats := new(BackfillStats)
+	exporter := newBatchExporter(b.exporter, stats)
+	// Blocks and datasets that have been accounted in the stats.
+	seen := make(map[string]struct{})
+	// Chunks are aligned to the step: samples of a step never
+	// span multiple chunks.
+	chunk := max(b.chunk.Milliseconds()/step.Milliseconds(), 1) * step.Milliseconds()
+	for s := start; s < end; s += chunk {
+		if err := b.backfillRange(ctx, req, step, s, min(s+chunk, end), exporter, seen); err != nil {
+			return nil, err
+		}
+	}
+	b.exporter.Flush()
+
+	level.Info(b.logger).Log(
+		"msg", "recording rule backfill finished",
+		"tenant", req.Tenant,
+		"start", time.UnixMilli(start),
+		"end", time.UnixMilli(end),
+		"blocks", stats.Blocks,
+		"datasets", stats.Datasets,
+		"series", stats.Series,
+		"samples", stats.Samples,
+	)
+	return stats, nil
+}
+
+// backfillRange observes the blocks of the time range and sends
+// the recorded samples. An error is returned if the samples could
+// not be sent.
+func (b *Backfiller) backfillRange(
+	ctx context.Context,
+	req BackfillRequest,
+	step time.Duration,
+	start, end int64,
+	exporter *batchExporter,
+	seen map[string]struct{},
+) error {
+	resp, err := b.blocks.QueryMetadata(ctx, &metastorev1.QueryMetadataRequest{
+		TenantId:  []string{req.Tenant},
+		StartTime: start,
+		EndTime:   end,
+		Query:     datasetSelector(req.Rule),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query metadata: %w", err)
+	}
+
+	observer := NewStepSampleObserver(step, exporter, staticRuler{req.Rule},
+		labels.Label{Name: "pyroscope_instance", Value: backfillInstance})
+	startNanos := time.UnixMilli(start).UnixNano()
+	endNanos := time.UnixMilli(end).UnixNano()
+	for _, md := range resp.Blocks {
+		if md.CompactionLevel == 0 {
+			continue
+		}
+		// A block may span multiple chunks: it is only
+		// accounted in the stats once.
+		if _, ok := seen[md.Id]; !ok {
+			seen[md.Id] = struct{}{}
+			exporter.stats.Blocks++
+		}
+		obj := block.NewObject(b.storage, md)
+		for i, ds := range md.Datasets {
+			if ds.Format != uint32(block.DatasetFormat0) {
+				continue
+			}
+			if k := md.Id + "/" + strconv.Itoa(i); !contains(seen, k) {
+				seen[k] = struct{}{}
+				exporter.stats.Datasets++
+			}
+			// Every dataset has its own symbols.
+			observer.ResetDataset()
+			if err = block.Observe(ctx, block.NewDataset(ds, obj), observer, startNanos, endNanos); err != nil {
+				return fmt.Errorf("failed to observe block %s: %w", md.Id, err)
+			}
+		}
+	}
+
+	// The data is only sent once all the blocks of the time range have
+	// been observed: profiles of the same step may reside in different
+	// blocks.
+	observer.Close()
+	return exporter.err
+}
+
+func contains(m map[string]struct{}, k string) bool {
+	_, ok := m[k]
+	return ok
+}
+
+// alignTimeRange returns the time range in milliseconds,
+// extended to the step boundaries.
+func alignTimeRange(start, end time.Time, step time.Duration) (int64, int64) {
+	s, e, d := start.UnixMilli(), end.UnixMilli(), step.Milliseconds()
+	s -= s % d
+	if r := e % d; r > 0 {
+		e += d - r
+	}
+	return s, e
+}
+
+// datasetSelector returns the metadata query selecting datasets that
+// the rule may target. Datasets are labeled with the service name and
+// profile type.
+func datasetSelector(rule *phlaremodel.RecordingRule) string {
+	matchers := make([]string, 0, 2)
+	for _, m := range rule.Matchers {
+		switch m.Name {
+		case phlaremodel.LabelNameServiceName, phlaremodel.LabelNameProfileType:
+			matchers = append(matchers, m.String())
+		}
+	}
+	if len(matchers) == 0 {
+		matchers = append(matchers, phlaremodel.LabelNameServiceName+`!=""`)
+	}
+	return "{" + strings.Join(matchers, ",") + "}"
+}
+
+// ServeHTTP handles recording rule backfill requests. The rule is looked
+// up by the metric name among the recording rules of the tenant.
+func (b *Backfiller) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := tenant.ExtractTenantIDFromContext(r.Context())
+	if err != nil {
+		httputil.ErrorWithStatus(w, err, http.StatusBadRequest)
+		return
+	}
+	req, err := b.parseRequest(tenantID, r)
+	if err != nil {
+		httputil.ErrorWithStatus(w, err, http.StatusBadRequest)
+		return
+	}
+	if req.Rule == nil {
+		err = fmt.Errorf("recording rule %q not found", r.FormValue("metric_name"))
+		httputil.ErrorWithStatus(w, err, http.StatusNotFound)
+		return
+	}
+	if req.End.Sub(req.Start) > MaxBackfillRange {
+		err = fmt.Errorf("time range exceeds the limit of %s: split the backfill into multiple requests", MaxBackfillRange)
+		httputil.ErrorWithStatus(w, err, http.StatusBadRequest)
+		return
+	}
+	stats, err := b.Backfill(r.Context(), req)
+	if err != nil {
+		httputil.Error(w, err)
+		return
+	}
+	util.WriteJSONResponse(w, stats)
+}
+
+func (b *Backfiller) parseRequest(tenantID string, r *http.Request) (req BackfillRequest, err error) {
+	req.Tenant = tenantID
+	metricName := r.FormValue("metric_name")
+	if metricName == "" {
+		return req, errors.New("metric_name is required")
+	}
+	start, err := strconv.ParseInt(r.FormValue("start"), 10, 64)
+	if err != nil {
+		return req, fmt.Errorf("invalid start: %w", err)
+	}
+	end, err := strconv.ParseInt(r.FormValue("end"), 10, 64)
+	if err != nil {
+		return req, fmt.Errorf("invalid end: %w", err)
+	}
+	req.Start, req.End = time.UnixMilli(start), time.UnixMilli(end)
+	if s := r.FormValue("step"); s != "" {
+		if req.Step, err = time.ParseDuration(s); err != nil {
+			return req, fmt.Errorf("invalid step: %w", err)
+		}
+	}
+	for _, rule := range b.ruler.RecordingRules(tenantID) {
+		if rule.ExternalLabels.Get(model.MetricNameLabel) == metricName {
+			req.Rule = rule
+			break
+		}
+	}
+	return req, nil
+}
+
+// staticRuler provides the rule to be backfilled to the observer.
+type staticRuler struct{ rule *phlaremodel.RecordingRule }
+
+func (r staticRuler) RecordingRules(string) []*phlaremodel.RecordingRule {
+	return []*phlaremodel.RecordingRule{r.rule}
+}
+
+// batchExporter splits series into batches of limited number of samples.
+// The first error is retained: the observer does not propagate errors.
+type batchExporter struct {
+	exporter Exporter
+	stats    *BackfillStats
+	series   map[string]struct{}
+	err      error
+}
+
+func newBatchExporter(exporter Exporter, stats *BackfillStats) *batchExporter {
+	return &batchExporter{
+		exporter: exporter,
+		stats:    stats,
+		series:   make(map[string]struct{}),
+	}
+}
+
+func (e *batchExporter) Send(tenant string, series []prompb.TimeSeries) error {
+	if err := e.send(tenant, series); err != nil {
+		if e.err == nil {
+			e.err = fmt.Errorf("failed to send series: %w", err)
+		}
+		return err
+	}
+	return nil
+}
+
+func (e *batchExporter) send(tenant string, series []prompb.TimeSeries) error {
+	batch := make([]prompb.TimeSeries, 0, len(series))
+	var samples int
+	for _, s := range series {
+		// The series are sent once per chunk.
+		if k := labelsKey(s.Labels); !contains(e.series, k) {
+			e.series[k] = struct{}{}
+			e.stats.Series++
+		}
+		e.stats.Samples += len(s.Samples)
+		for len(s.Samples) > 0 {
+			n := min(len(s.Samples), maxBackfillSamplesPerSend-samples)
+			batch = append(batch, prompb.TimeSeries{Labels: s.Labels, Samples: s.Samples[:n]})
+			s.Samples = s.Samples[n:]
+			if samples += n; samples == maxBackfillSamplesPerSend {
+				if err := e.exporter.Send(tenant, batch); err != nil {
+					return err
+				}
+				batch = make([]prompb.TimeSeries, 0, len(series))
+				samples = 0
+			}
+		}
+	}
+	if len(batch) > 0 {
+		return e.exporter.Send(tenant, batch)
+	}
+	return nil
+}
+
+func (e *batchExporter) Flush() { e.exporter.Flush() }
+
+func labelsKey(ls []prompb.Label) string {
+	var b strings.Builder
+	for _, l := range ls {
+		b.WriteString(l.Name)
+		b.WriteByte(0)
+		b.WriteString(l.Value)
+		b.WriteByte(0)
+	}
+	return b.String()
+}
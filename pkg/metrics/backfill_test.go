@@ -0,0 +1,221 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mockmetastorev1"
+	"github.com/grafana/pyroscope/pkg/test/mocks/mockmetrics"
+)
+
+func Test_Backfiller_Backfill(t *testing.T) {
+	ctx := context.Background()
+	bucket := &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}
+	metas := loadBlocks(t, bucket, "../querybackend/testdata/samples")
+
+	// The metastore only returns the matching datasets.
+	var matched []*metastorev1.BlockMeta
+	for _, md := range metas {
+		md = md.CloneVT()
+		md.Datasets = slices.DeleteFunc(md.Datasets, func(ds *metastorev1.Dataset) bool {
+			return md.StringTable[ds.Name] != "test-app"
+		})
+		if len(md.Datasets) > 0 {
+			matched = append(matched, md)
+		}
+	}
+	blocks := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	blocks.On("QueryMetadata", mock.Anything, mock.MatchedBy(func(req *metastorev1.QueryMetadataRequest) bool {
+		return req.Query == `{service_name="test-app"}`
+	})).Return(&metastorev1.QueryMetadataResponse{Blocks: matched}, nil)
+
+	var sent [][]prompb.TimeSeries
+	exporter := new(mockmetrics.MockExporter)
+	exporter.On("Send", "anonymous", mock.Anything).Run(func(args mock.Arguments) {
+		sent[len(sent)-1] = append(sent[len(sent)-1], args.Get(1).([]prompb.TimeSeries)...)
+	}).Return(nil)
+	exporter.On("Flush").Return()
+
+	rule := &phlaremodel.RecordingRule{
+		Matchers:       []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "service_name", "test-app")},
+		ExternalLabels: labels.Labels{{Name: "__name__", Value: "test_total"}},
+	}
+	minTime, maxTime := metas[0].MinTime, metas[0].MaxTime
+	for _, md := range metas {
+		minTime = min(minTime, md.MinTime)
+		maxTime = max(maxTime, md.MaxTime)
+	}
+	start, end := time.UnixMilli(minTime), time.UnixMilli(maxTime+1)
+
+	b := NewBackfiller(log.NewNopLogger(), bucket, blocks, nil, exporter)
+	const step = time.Minute
+	for _, tc := range []struct {
+		req   BackfillRequest
+		chunk time.Duration
+	}{
+		{req: BackfillRequest{Tenant: "anonymous", Rule: rule, Start: start, End: end, Step: step}},
+		// The time range is extended to the step boundaries.
+		{req: BackfillRequest{Tenant: "anonymous", Rule: rule, Start: start.Truncate(step), End: end.Add(time.Second), Step: step}},
+		// The time range is split into chunks of two steps.
+		{req: BackfillRequest{Tenant: "anonymous", Rule: rule, Start: start, End: end, Step: step}, chunk: 2 * step},
+	} {
+		b.chunk = defaultBackfillChunk
+		if tc.chunk > 0 {
+			b.chunk = tc.chunk
+		}
+		sent = append(sent, nil)
+		stats, err := b.Backfill(ctx, tc.req)
+		require.NoError(t, err)
+		// Level 0 blocks are skipped.
+		require.Equal(t, 2, stats.Blocks)
+		require.Equal(t, 2, stats.Datasets)
+		require.Equal(t, 1, stats.Series)
+	}
+
+	require.Len(t, sent, 3)
+	require.Len(t, sent[0], 1)
+	// Reruns produce identical samples.
+	require.Equal(t, sent[0], sent[1])
+	// Chunks produce identical samples, split into multiple series.
+	require.Greater(t, len(sent[2]), 1)
+	var chunked []prompb.Sample
+	for _, series := range sent[2] {
+		require.Equal(t, sent[0][0].Labels, series.Labels)
+		chunked = append(chunked, series.Samples...)
+	}
+	require.Equal(t, sent[0][0].Samples, chunked)
+
+	series := sent[0][0]
+	require.Equal(t, []prompb.Label{
+		{Name: "__name__", Value: "test_total"},
+		{Name: "pyroscope_instance", Value: "backfill"},
+	}, series.Labels)
+	var total float64
+	for i, s := range series.Samples {
+		require.Zero(t, s.Timestamp%step.Milliseconds())
+		if i > 0 {
+			require.Greater(t, s.Timestamp, series.Samples[i-1].Timestamp)
+		}
+		total += s.Value
+	}
+	require.NotZero(t, total)
+	require.Equal(t, float64(totalValue(t, bucket, metas, "test-app")), total)
+}
+
+func Test_Backfiller_Backfill_SendError(t *testing.T) {
+	ctx := context.Background()
+	bucket := &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}
+	metas := loadBlocks(t, bucket, "../querybackend/testdata/samples")
+	blocks := new(mockmetastorev1.MockMetadataQueryServiceClient)
+	blocks.On("QueryMetadata", mock.Anything, mock.Anything).
+		Return(&metastorev1.QueryMetadataResponse{Blocks: metas}, nil)
+
+	exporter := new(mockmetrics.MockExporter)
+	exporter.On("Send", "anonymous", mock.Anything).Return(errors.New("queue is full"))
+
+	rule := &phlaremodel.RecordingRule{
+		Matchers:       []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "service_name", "test-app")},
+		ExternalLabels: labels.Labels{{Name: "__name__", Value: "test_total"}},
+	}
+	minTime, maxTime := metas[0].MinTime, metas[0].MaxTime
+	for _, md := range metas {
+		minTime = min(minTime, md.MinTime)
+		maxTime = max(maxTime, md.MaxTime)
+	}
+
+	b := NewBackfiller(log.NewNopLogger(), bucket, blocks, nil, exporter)
+	_, err := b.Backfill(ctx, BackfillRequest{
+		Tenant: "anonymous",
+		Rule:   rule,
+		Start:  time.UnixMilli(minTime),
+		End:    time.UnixMilli(maxTime + 1),
+	})
+	require.ErrorContains(t, err, "queue is full")
+}
+
+func Test_batchExporter(t *testing.T) {
+	exporter := new(mockmetrics.MockExporter)
+	var batches [][]prompb.TimeSeries
+	exporter.On("Send", "tenant", mock.Anything).Run(func(args mock.Arguments) {
+		batches = append(batches, args.Get(1).([]prompb.TimeSeries))
+	}).Return(nil)
+
+	samples := make([]prompb.Sample, maxBackfillSamplesPerSend+1)
+	stats := new(BackfillStats)
+	e := newBatchExporter(exporter, stats)
+	require.NoError(t, e.Send("tenant", []prompb.TimeSeries{
+		{Labels: []prompb.Label{{Name: "__name__", Value: "a"}}, Samples: samples[:1]},
+		{Labels: []prompb.Label{{Name: "__name__", Value: "b"}}, Samples: samples},
+	}))
+
+	require.Len(t, batches, 2)
+	require.Len(t, batches[0], 2)
+	require.Len(t, batches[0][1].Samples, maxBackfillSamplesPerSend-1)
+	require.Len(t, batches[1], 1)
+	require.Len(t, batches[1][0].Samples, 2)
+	require.Equal(t, &BackfillStats{Series: 2, Samples: maxBackfillSamplesPerSend + 2}, stats)
+}
+
+func loadBlocks(t *testing.T, bucket objstore.Bucket, dir string) []*metastorev1.BlockMeta {
+	var metas []*metastorev1.BlockMeta
+	require.NoError(t, filepath.WalkDir(dir, func(path string, e os.DirEntry, err error) error {
+		if err != nil || e.IsDir() {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var md metastorev1.BlockMeta
+		if err = metadata.Decode(b, &md); err != nil {
+			return err
+		}
+		md.Size = uint64(len(b))
+		metas = append(metas, &md)
+		return bucket.Upload(context.Background(), block.ObjectPath(&md), bytes.NewReader(b))
+	}))
+	return metas
+}
+
+// totalValue returns the total value of the profiles
+// of the service in the compacted blocks.
+func totalValue(t *testing.T, bucket objstore.Bucket, metas []*metastorev1.BlockMeta, service string) (total int64) {
+	for _, md := range metas {
+		if md.CompactionLevel == 0 {
+			continue
+		}
+		for _, ds := range md.Datasets {
+			if md.StringTable[ds.Name] != service {
+				continue
+			}
+			d := block.NewDataset(ds, block.NewObject(bucket, md))
+			require.NoError(t, d.Open(context.Background(), block.SectionProfiles, block.SectionTSDB))
+			rows, err := block.NewProfileRowIterator(d)
+			require.NoError(t, err)
+			for rows.Next() {
+				total += rows.At().Row.TotalValue()
+			}
+			require.NoError(t, rows.Err())
+			require.NoError(t, rows.Close())
+		}
+	}
+	return total
+}
@@ -3,30 +3,130 @@ package metrics
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
 )
 
+const defaultSinkName = "default"
+
 type Config struct {
 	Enabled     bool `yaml:"enabled"`
 	RulesSource struct {
 		ClientAddress string `yaml:"client_address"`
 	} `yaml:"rules_source"`
-	RemoteWriteAddress string `yaml:"remote_write_address"`
+	// RemoteWriteAddress, if set, defines the remote_write sink named "default".
+	RemoteWriteAddress string       `yaml:"remote_write_address"`
+	Sinks              []SinkConfig `yaml:"sinks" doc:"hidden"`
+	DefaultSink        string       `yaml:"default_sink"`
+	Queue              QueueConfig  `yaml:"queue"`
+}
+
+type QueueConfig struct {
+	Dir          string        `yaml:"dir"`
+	MaxSizeBytes int64         `yaml:"max_size_bytes"`
+	MinBackoff   time.Duration `yaml:"min_backoff"`
+	MaxBackoff   time.Duration `yaml:"max_backoff"`
+	FlushTimeout time.Duration `yaml:"flush_timeout"`
 }
 
 func (c *Config) Validate() error {
 	if !c.Enabled {
 		return nil
 	}
-	if c.RemoteWriteAddress == "" {
-		return errors.New("remote write address is required")
+	sinks := c.sinks()
+	if len(sinks) == 0 {
+		return errors.New("at least one sink is required: remote write address or sinks must be specified")
+	}
+	names := make(map[string]struct{}, len(sinks))
+	urls := make(map[string]string, len(sinks))
+	for _, s := range sinks {
+		if s.Name == "" {
+			return errors.New("sink name is required")
+		}
+		if _, ok := names[s.Name]; ok {
+			return fmt.Errorf("duplicate sink name %q", s.Name)
+		}
+		names[s.Name] = struct{}{}
+		if !slices.Contains(sinkTypes(), s.Type) {
+			return fmt.Errorf("sink %q: unknown type %q, supported types: %v", s.Name, s.Type, sinkTypes())
+		}
+		if s.Type != SinkTypeRemoteWrite && s.Type != SinkTypeOTLP {
+			continue
+		}
+		if err := validateSinkURL(s.URL); err != nil {
+			return fmt.Errorf("sink %q: %w", s.Name, err)
+		}
+		// Remote write client metrics are registered per URL.
+		key := s.Type + " " + s.URL
+		if other, ok := urls[key]; ok {
+			return fmt.Errorf("sink %q: url %q is already used by sink %q", s.Name, s.URL, other)
+		}
+		urls[key] = s.Name
+	}
+	if _, ok := names[c.DefaultSink]; !ok {
+		return fmt.Errorf("default sink %q is not defined", c.DefaultSink)
+	}
+	return c.Queue.Validate()
+}
+
+func validateSinkURL(s string) error {
+	if s == "" {
+		return errors.New("url is required")
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid url %q: an absolute http or https url is expected", s)
 	}
 	return nil
 }
 
+// sinks returns the configured sinks, including the
+// one defined by the remote write address.
+func (c *Config) sinks() []SinkConfig {
+	if c.RemoteWriteAddress == "" {
+		return c.Sinks
+	}
+	sinks := make([]SinkConfig, 0, len(c.Sinks)+1)
+	sinks = append(sinks, SinkConfig{
+		Name: defaultSinkName,
+		Type: SinkTypeRemoteWrite,
+		URL:  c.RemoteWriteAddress,
+	})
+	return append(sinks, c.Sinks...)
+}
+
 func (c *Config) RegisterFlags(f *flag.FlagSet) {
 	const prefix = "compaction-worker.metrics-exporter."
 
 	f.BoolVar(&c.Enabled, prefix+"enabled", false, "This parameter specifies whether the metrics exporter is enabled.")
 	f.StringVar(&c.RulesSource.ClientAddress, prefix+"rules-source.client-address", "", "The address to use for the recording rules client connection.")
 	f.StringVar(&c.RemoteWriteAddress, prefix+"remote-write-address", "", "The address to use for metrics tenant.")
+	f.StringVar(&c.DefaultSink, prefix+"default-sink", defaultSinkName, "The name of the sink to use for tenants that have no sink specified.")
+	c.Queue.RegisterFlagsWithPrefix(prefix+"queue.", f)
+}
+
+func (c *QueueConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&c.Dir, prefix+"dir", "./data/metrics-exporter", "Directory where the series pending delivery are stored.")
+	f.Int64Var(&c.MaxSizeBytes, prefix+"max-size-bytes", 256<<20, "Maximum size of the queue of each sink. The oldest entries are dropped once the limit is exceeded.")
+	f.DurationVar(&c.MinBackoff, prefix+"min-backoff", time.Second, "Minimum delay before a failed delivery is retried.")
+	f.DurationVar(&c.MaxBackoff, prefix+"max-backoff", time.Minute, "Maximum delay before a failed delivery is retried.")
+	f.DurationVar(&c.FlushTimeout, prefix+"flush-timeout", 30*time.Second, "Maximum time to wait for the queue to be delivered at shutdown. The remaining entries are delivered after restart.")
+}
+
+func (c *QueueConfig) Validate() error {
+	if c.Dir == "" {
+		return errors.New("queue directory is required")
+	}
+	if c.MaxSizeBytes <= 0 {
+		return errors.New("queue max size must be positive")
+	}
+	if c.MinBackoff <= 0 || c.MaxBackoff < c.MinBackoff {
+		return errors.New("invalid queue backoff")
+	}
+	return nil
 }
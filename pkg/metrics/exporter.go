@@ -1,164 +1,86 @@
 package metrics
 
 import (
-	"context"
 	"fmt"
-	"net/http"
-	"net/url"
-	"strconv"
 	"sync"
-	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
-	"github.com/gogo/protobuf/proto"
-	"github.com/grafana/dskit/instrument"
-	"github.com/klauspost/compress/snappy"
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/common/config"
-	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/prompb"
-	"github.com/prometheus/prometheus/storage/remote"
-
-	"github.com/grafana/pyroscope/pkg/tenant"
 )
 
-type StaticExporter struct {
-	client remote.WriteClient
-	wg     sync.WaitGroup
-
-	logger log.Logger
-
-	metrics *clientMetrics
+// SinkRouter resolves the name of the sink the tenant
+// series are sent to. An empty name denotes the default sink.
+type SinkRouter interface {
+	MetricsExporterSink(tenant string) string
 }
 
-const (
-	metricsExporterUserAgent = "pyroscope-metrics-exporter"
-)
-
-func NewExporter(remoteWriteAddress string, logger log.Logger, reg prometheus.Registerer) (Exporter, error) {
-	metrics := newMetrics(reg, remoteWriteAddress)
-	client, err := newClient(remoteWriteAddress, metrics)
-	if err != nil {
-		return nil, err
-	}
-	return &StaticExporter{
-		client:  client,
-		wg:      sync.WaitGroup{},
-		logger:  logger,
-		metrics: metrics,
-	}, nil
+// SinkExporter routes the series of each tenant to one of the
+// configured sinks. Every sink has its own on-disk queue: a sink outage
+// does not lose the recorded series, nor affects other sinks.
+type SinkExporter struct {
+	logger      log.Logger
+	router      SinkRouter
+	defaultSink string
+	queues      map[string]*queue
 }
 
-func (e *StaticExporter) Send(tenantId string, data []prompb.TimeSeries) error {
-	e.wg.Add(1)
-	go func(data []prompb.TimeSeries) {
-		defer e.wg.Done()
-		p := &prompb.WriteRequest{Timeseries: data}
-		buf := proto.NewBuffer(nil)
-		if err := buf.Marshal(p); err != nil {
-			level.Error(e.logger).Log("msg", "unable to marshal prompb.WriteRequest", "err", err)
-			return
+func NewExporter(cfg Config, router SinkRouter, logger log.Logger, reg prometheus.Registerer) (Exporter, error) {
+	e := &SinkExporter{
+		logger:      logger,
+		router:      router,
+		defaultSink: cfg.DefaultSink,
+		queues:      make(map[string]*queue),
+	}
+	metrics := newQueueMetrics(reg)
+	for _, sc := range cfg.sinks() {
+		sink, err := newSink(sc, logger, reg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create sink %q: %w", sc.Name, err)
 		}
-
-		ctx := tenant.InjectTenantID(context.Background(), tenantId)
-		_, err := e.client.Store(ctx, snappy.Encode(nil, buf.Bytes()), 0)
+		q, err := newQueue(sc.Name, cfg.Queue, sink, logger, metrics)
 		if err != nil {
-			level.Error(e.logger).Log("msg", "unable to store prompb.WriteRequest", "err", err)
-			return
+			return nil, err
 		}
-		e.metrics.seriesSent.WithLabelValues(tenantId).Add(float64(len(data)))
-	}(data)
-	return nil
-}
-
-func (e *StaticExporter) Flush() {
-	e.wg.Wait()
-}
-
-func newClient(remoteUrl string, m *clientMetrics) (remote.WriteClient, error) {
-	wURL, err := url.Parse(remoteUrl)
-	if err != nil {
-		return nil, err
+		e.queues[sc.Name] = q
 	}
-
-	client, err := remote.NewWriteClient("exporter", &remote.ClientConfig{
-		URL:     &config.URL{URL: wURL},
-		Timeout: model.Duration(time.Second * 10),
-		Headers: map[string]string{
-			"User-Agent": metricsExporterUserAgent,
-		},
-		RetryOnRateLimit: false,
-	})
-	if err != nil {
-		return nil, err
+	if _, ok := e.queues[e.defaultSink]; !ok {
+		return nil, fmt.Errorf("default sink %q is not defined", e.defaultSink)
 	}
-	t := client.(*remote.Client).Client.Transport
-	client.(*remote.Client).Client.Transport = &RoundTripper{m, t}
-	return client, nil
-}
-
-type clientMetrics struct {
-	requestDuration *prometheus.HistogramVec
-	requestBodySize *prometheus.CounterVec
-	seriesSent      *prometheus.CounterVec
+	return e, nil
 }
 
-func newMetrics(reg prometheus.Registerer, remoteUrl string) *clientMetrics {
-	m := &clientMetrics{
-		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
-			Namespace: "pyroscope",
-			Subsystem: "metrics_exporter",
-			Name:      "client_request_duration_seconds",
-			Help:      "Time (in seconds) spent on remote_write",
-			Buckets:   instrument.DefBuckets,
-		}, []string{"route", "status_code", "tenant"}),
-		requestBodySize: prometheus.NewCounterVec(prometheus.CounterOpts{
-			Namespace: "pyroscope",
-			Subsystem: "metrics_exporter",
-			Name:      "request_message_bytes_total",
-			Help:      "Size (in bytes) of messages sent on remote_write.",
-		}, []string{"route", "status_code", "tenant"}),
-		seriesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
-			Namespace: "pyroscope",
-			Subsystem: "metrics_exporter",
-			Name:      "series_sent_total",
-			Help:      "Number of series sent on remote_write.",
-		}, []string{"tenant"}),
+func (e *SinkExporter) Send(tenantID string, data []prompb.TimeSeries) error {
+	name := e.defaultSink
+	if e.router != nil {
+		if s := e.router.MetricsExporterSink(tenantID); s != "" {
+			name = s
+		}
 	}
-	if reg != nil {
-		remoteUrlReg := prometheus.WrapRegistererWith(prometheus.Labels{"url": remoteUrl}, reg)
-		remoteUrlReg.MustRegister(
-			m.requestDuration,
-			m.requestBodySize,
-			m.seriesSent,
-		)
+	q, ok := e.queues[name]
+	if !ok {
+		err := fmt.Errorf("sink %q is not defined", name)
+		level.Error(e.logger).Log("msg", "unable to export series", "tenant", tenantID, "err", err)
+		return err
 	}
-	return m
-}
-
-type RoundTripper struct {
-	metrics *clientMetrics
-	next    http.RoundTripper
-}
-
-func (m *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	tenantId, err := tenant.ExtractTenantIDFromContext(req.Context())
-	if err != nil {
-		return nil, fmt.Errorf("unable to get tenant ID from context: %w", err)
+	if err := q.push(tenantID, data); err != nil {
+		level.Error(e.logger).Log("msg", "unable to enqueue series", "tenant", tenantID, "sink", name, "err", err)
+		return err
 	}
-	req.Header.Set("X-Scope-OrgId", tenantId)
-
-	start := time.Now()
-	resp, err := m.next.RoundTrip(req)
-	duration := time.Since(start)
+	return nil
+}
 
-	statusCode := ""
-	if resp != nil {
-		statusCode = strconv.Itoa(resp.StatusCode)
+// Flush waits for all the queues to be drained, or for the flush timeout
+// to expire. The remaining series are delivered later, or after restart.
+func (e *SinkExporter) Flush() {
+	var wg sync.WaitGroup
+	for _, q := range e.queues {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.flush()
+		}()
 	}
-
-	m.metrics.requestDuration.WithLabelValues(req.RequestURI, statusCode, tenantId).Observe(duration.Seconds())
-	m.metrics.requestBodySize.WithLabelValues(req.RequestURI, statusCode, tenantId).Add(float64(req.ContentLength))
-	return resp, err
+	wg.Wait()
 }
@@ -0,0 +1,247 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+)
+
+type testSink struct {
+	mu   sync.Mutex
+	errs []error
+	// If set, the sink fails after errs are exhausted.
+	err  error
+	sent map[string][]prompb.TimeSeries
+}
+
+func (s *testSink) Send(_ context.Context, tenant string, series []prompb.TimeSeries) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return err
+	}
+	if s.err != nil {
+		return s.err
+	}
+	if s.sent == nil {
+		s.sent = make(map[string][]prompb.TimeSeries)
+	}
+	s.sent[tenant] = append(s.sent[tenant], series...)
+	return nil
+}
+
+func (s *testSink) series(tenant string) []prompb.TimeSeries {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent[tenant]
+}
+
+type testRouter map[string]string
+
+func (r testRouter) MetricsExporterSink(tenant string) string { return r[tenant] }
+
+func testSeries(name string, t int64, v float64) []prompb.TimeSeries {
+	return []prompb.TimeSeries{{
+		Labels:  []prompb.Label{{Name: "__name__", Value: name}},
+		Samples: []prompb.Sample{{Timestamp: t, Value: v}},
+	}}
+}
+
+func newTestExporter(t *testing.T, dir string, router SinkRouter, sinks map[string]*testSink) *SinkExporter {
+	t.Helper()
+	RegisterSinkType("test", func(cfg SinkConfig, _ log.Logger, _ prometheus.Registerer) (Sink, error) {
+		return sinks[cfg.Name], nil
+	})
+	cfg := Config{
+		Enabled:     true,
+		DefaultSink: "a",
+		Queue: QueueConfig{
+			Dir:          dir,
+			MaxSizeBytes: 1 << 20,
+			MinBackoff:   time.Millisecond,
+			MaxBackoff:   10 * time.Millisecond,
+			FlushTimeout: 5 * time.Second,
+		},
+	}
+	for name := range sinks {
+		cfg.Sinks = append(cfg.Sinks, SinkConfig{Name: name, Type: "test"})
+	}
+	require.NoError(t, cfg.Validate())
+	e, err := NewExporter(cfg, router, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	return e.(*SinkExporter)
+}
+
+func Test_SinkExporter_Routing(t *testing.T) {
+	sinks := map[string]*testSink{"a": {}, "b": {}}
+	e := newTestExporter(t, t.TempDir(), testRouter{"tenant-b": "b", "tenant-c": "c"}, sinks)
+
+	require.NoError(t, e.Send("tenant-a", testSeries("a", 1, 1)))
+	require.NoError(t, e.Send("tenant-b", testSeries("b", 1, 1)))
+	require.Error(t, e.Send("tenant-c", testSeries("c", 1, 1)))
+	e.Flush()
+
+	require.Equal(t, testSeries("a", 1, 1), sinks["a"].series("tenant-a"))
+	require.Empty(t, sinks["a"].series("tenant-b"))
+	require.Equal(t, testSeries("b", 1, 1), sinks["b"].series("tenant-b"))
+	require.Empty(t, sinks["b"].series("tenant-a"))
+}
+
+func Test_SinkExporter_Retry(t *testing.T) {
+	sink := &testSink{errs: []error{
+		errors.New("unavailable"),
+		errors.New("unavailable"),
+		Permanent(errors.New("bad request")),
+	}}
+	e := newTestExporter(t, t.TempDir(), nil, map[string]*testSink{"a": sink})
+
+	// The first entry is retried twice and then dropped.
+	require.NoError(t, e.Send("tenant", testSeries("x", 1, 1)))
+	require.NoError(t, e.Send("tenant", testSeries("x", 2, 2)))
+	e.Flush()
+
+	require.Equal(t, testSeries("x", 2, 2), sink.series("tenant"))
+	require.Zero(t, e.queues["a"].pending())
+}
+
+func Test_SinkExporter_Recovery(t *testing.T) {
+	dir := t.TempDir()
+	sink := &testSink{err: errors.New("unavailable")}
+	e := newTestExporter(t, dir, nil, map[string]*testSink{"a": sink})
+	e.queues["a"].config.FlushTimeout = 0
+	require.NoError(t, e.Send("tenant", testSeries("x", 1, 1)))
+	e.Flush()
+	require.Empty(t, sink.series("tenant"))
+
+	// The queue of the new exporter is recovered from disk.
+	recovered := new(testSink)
+	e = newTestExporter(t, dir, nil, map[string]*testSink{"a": recovered})
+	e.Flush()
+	require.Equal(t, testSeries("x", 1, 1), recovered.series("tenant"))
+	entries, err := os.ReadDir(filepath.Join(dir, "a"))
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
+
+func Test_queue_Overflow(t *testing.T) {
+	dir := t.TempDir()
+	b, err := encodeQueueEntry("tenant", testSeries("x", 1, 1))
+	require.NoError(t, err)
+	sink := &testSink{errs: []error{errors.New("unavailable")}}
+	q, err := newQueue("a", QueueConfig{
+		Dir:          dir,
+		MaxSizeBytes: int64(2 * len(b)),
+		MinBackoff:   time.Hour,
+		MaxBackoff:   time.Hour,
+	}, sink, log.NewNopLogger(), newQueueMetrics(nil))
+	require.NoError(t, err)
+
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, q.push("tenant", testSeries("x", i, 1)))
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	require.Equal(t, []queueEntry{{seq: 1, size: int64(len(b))}, {seq: 2, size: int64(len(b))}}, q.entries)
+}
+
+func Test_queueEntry(t *testing.T) {
+	series := testSeries("x", 1, 1)
+	b, err := encodeQueueEntry("tenant", series)
+	require.NoError(t, err)
+	tenant, decoded, err := decodeQueueEntry(b)
+	require.NoError(t, err)
+	require.Equal(t, "tenant", tenant)
+	require.Equal(t, series, decoded)
+
+	_, _, err = decodeQueueEntry(b[:5])
+	require.Error(t, err)
+}
+
+func Test_fileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "series.json")
+	sink, err := newFileSink(SinkConfig{Name: "file", Type: SinkTypeFile, Path: path}, log.NewNopLogger(), nil)
+	require.NoError(t, err)
+	require.NoError(t, sink.Send(context.Background(), "tenant", testSeries("x", 1, 1)))
+	// Simulate the partial output of a failed attempt, which is retried.
+	partial, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = partial.WriteString(`{"tenant":"ten`)
+	require.NoError(t, err)
+	require.NoError(t, partial.Close())
+	require.NoError(t, sink.Send(context.Background(), "tenant", testSeries("y", 2, 2)))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []fileSinkEntry
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e fileSinkEntry
+		require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+		entries = append(entries, e)
+	}
+	require.Equal(t, []fileSinkEntry{
+		{Tenant: "tenant", Labels: map[string]string{"__name__": "x"}, Samples: []fileSinkSample{{Timestamp: 1, Value: 1}}},
+		{Tenant: "tenant", Labels: map[string]string{"__name__": "y"}, Samples: []fileSinkSample{{Timestamp: 2, Value: 2}}},
+	}, entries)
+}
+
+func Test_otlpMetricsRequest(t *testing.T) {
+	series := append(testSeries("x", 1, 1), testSeries("x", 2, 2)...)
+	series[1].Labels = append(series[1].Labels, prompb.Label{Name: "service_name", Value: "svc"})
+	req := otlpMetricsRequest(series)
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 1)
+	require.Equal(t, "x", metrics[0].Name)
+	points := metrics[0].GetGauge().DataPoints
+	require.Len(t, points, 2)
+	require.Empty(t, points[0].Attributes)
+	require.Equal(t, "service_name", points[1].Attributes[0].Key)
+	require.Equal(t, uint64(2e6), points[1].TimeUnixNano)
+	require.Equal(t, 2.0, points[1].GetAsDouble())
+}
+
+func Test_Config_Validate(t *testing.T) {
+	valid := func() Config {
+		return Config{
+			Enabled:            true,
+			RemoteWriteAddress: "http://localhost:9090/api/v1/write",
+			Sinks:              []SinkConfig{{Name: "debug", Type: SinkTypeFile, Path: "/tmp/series.json"}},
+			DefaultSink:        defaultSinkName,
+			Queue:              QueueConfig{Dir: "/tmp", MaxSizeBytes: 1, MinBackoff: 1, MaxBackoff: 1},
+		}
+	}
+	c := valid()
+	require.NoError(t, c.Validate())
+
+	for _, fn := range []func(*Config){
+		func(c *Config) { c.RemoteWriteAddress, c.Sinks = "", nil },
+		func(c *Config) { c.Sinks[0].Name = defaultSinkName },
+		func(c *Config) { c.Sinks[0].Name = "" },
+		func(c *Config) { c.Sinks[0].Type = "unknown" },
+		func(c *Config) { c.DefaultSink = "unknown" },
+		func(c *Config) { c.Queue.Dir = "" },
+		func(c *Config) { c.RemoteWriteAddress = "localhost:9090" },
+		func(c *Config) { c.RemoteWriteAddress = "http://" },
+		func(c *Config) {
+			c.Sinks = append(c.Sinks, SinkConfig{Name: "dup", Type: SinkTypeRemoteWrite, URL: c.RemoteWriteAddress})
+		},
+	} {
+		c = valid()
+		fn(&c)
+		require.Error(t, c.Validate())
+	}
+}
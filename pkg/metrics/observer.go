@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"sort"
+	"time"
 
 	"github.com/parquet-go/parquet-go"
 	"github.com/prometheus/common/model"
@@ -17,6 +18,7 @@ type SampleObserver struct {
 	state *observerState
 
 	recordingTime  int64
+	step           int64
 	externalLabels labels.Labels
 
 	exporter Exporter
@@ -29,24 +31,37 @@ type observerState struct {
 	recordings []*recording
 
 	// dataset state
-	dataset           string
-	targetRecordings  []*recording
-	targetStrings     map[string][]*recording
-	targetLocations   map[uint32]map[*recording]struct{}
+	dataset          string
+	targetRecordings []*recording
+	// Recordings filtered by function name or regex, and the lookup
+	// of the matching recordings by function name.
+	targetFunctions []*recording
+	targetStrings   map[string][]*recording
+	// Recordings filtered by call site.
+	targetCallSites []*recording
+	// Location => recording => whether the location leaf line matches.
+	targetLocations   map[uint32]map[*recording]bool
 	seenLocations     int
 	targetStacktraces map[uint32]map[*recording]struct{}
 
 	// series state
 	fingerprint   model.Fingerprint
+	timestamp     int64
 	recordSymbols bool
 }
 
 type recording struct {
 	rule  *phlaremodel.RecordingRule
-	data  map[model.Fingerprint]*prompb.TimeSeries
+	data  map[seriesKey]*prompb.TimeSeries
 	state *recordingState
 }
 
+// seriesKey identifies a single sample of an exported series.
+type seriesKey struct {
+	fingerprint model.Fingerprint
+	timestamp   int64
+}
+
 type recordingState struct {
 	matches bool
 	sample  *prompb.Sample
@@ -74,12 +89,39 @@ func NewSampleObserver(recordingTime int64, exporter Exporter, ruler Ruler, labe
 			recordings:        make([]*recording, 0),
 			targetRecordings:  make([]*recording, 0),
 			targetStrings:     make(map[string][]*recording),
-			targetLocations:   make(map[uint32]map[*recording]struct{}),
+			targetLocations:   make(map[uint32]map[*recording]bool),
 			targetStacktraces: make(map[uint32]map[*recording]struct{}),
 		},
 	}
 }
 
+// NewStepSampleObserver creates an observer that records samples at the
+// profile timestamps aligned to the step, instead of a fixed recording
+// time. Therefore, observing the same profiles always results in the
+// same samples, no matter how the profiles are split between blocks.
+func NewStepSampleObserver(step time.Duration, exporter Exporter, ruler Ruler, labels ...labels.Label) *SampleObserver {
+	o := NewSampleObserver(0, exporter, ruler, labels...)
+	o.step = step.Milliseconds()
+	return o
+}
+
+// ResetDataset forces the dataset state to be initialized on the next
+// evaluation. This is required when the observed symbols change, while
+// the dataset is the same, e.g., when datasets of different blocks are
+// observed one after another.
+func (o *SampleObserver) ResetDataset() {
+	o.state.dataset = ""
+	o.state.fingerprint = 0
+}
+
+func (o *SampleObserver) timestamp(row block.ProfileEntry) int64 {
+	if o.step <= 0 {
+		return o.recordingTime
+	}
+	t := time.Unix(0, row.Timestamp).UnixMilli()
+	return t - t%o.step
+}
+
 func (o *SampleObserver) initTenantState(tenant string) {
 	o.state.tenant = tenant
 	recordingRules := o.ruler.RecordingRules(tenant)
@@ -87,7 +129,7 @@ func (o *SampleObserver) initTenantState(tenant string) {
 	for _, rule := range recordingRules {
 		o.state.recordings = append(o.state.recordings, &recording{
 			rule:  rule,
-			data:  make(map[model.Fingerprint]*prompb.TimeSeries),
+			data:  make(map[seriesKey]*prompb.TimeSeries),
 			state: &recordingState{},
 		})
 	}
@@ -99,26 +141,43 @@ func (o *SampleObserver) initTenantState(tenant string) {
 func (o *SampleObserver) initDatasetState(dataset string) {
 	// New dataset imply new symbols, and new subset of rules that can target the dataset
 	o.state.targetStrings = make(map[string][]*recording)
-	o.state.targetLocations = make(map[uint32]map[*recording]struct{})
+	o.state.targetLocations = make(map[uint32]map[*recording]bool)
 	o.state.targetStacktraces = make(map[uint32]map[*recording]struct{})
 	o.state.seenLocations = 0
 	o.state.dataset = dataset
 	o.state.targetRecordings = o.state.targetRecordings[:0]
+	o.state.targetFunctions = o.state.targetFunctions[:0]
+	o.state.targetCallSites = o.state.targetCallSites[:0]
 	for _, rec := range o.state.recordings {
 		// storing the subset of the recording that matter to this dataset:
 		if rec.matchesServiceName(dataset) {
 			o.state.targetRecordings = append(o.state.targetRecordings, rec)
-			if rec.rule.FunctionName != "" {
-				// create a lookup for functions names that matter
-				if _, exists := o.state.targetStrings[rec.rule.FunctionName]; !exists {
-					o.state.targetStrings[rec.rule.FunctionName] = make([]*recording, 0)
-				}
-				o.state.targetStrings[rec.rule.FunctionName] = append(o.state.targetStrings[rec.rule.FunctionName], rec)
+			switch {
+			case len(rec.rule.CallSite) > 0:
+				o.state.targetCallSites = append(o.state.targetCallSites, rec)
+			case rec.rule.HasStacktraceFilter():
+				o.state.targetFunctions = append(o.state.targetFunctions, rec)
 			}
 		}
 	}
 }
 
+// functionRecordings returns recordings whose function filter matches
+// the function name. The result is memoized for the dataset.
+func (o *SampleObserver) functionRecordings(name string) []*recording {
+	recs, ok := o.state.targetStrings[name]
+	if ok {
+		return recs
+	}
+	for _, rec := range o.state.targetFunctions {
+		if rec.rule.MatchesFunction(name) {
+			recs = append(recs, rec)
+		}
+	}
+	o.state.targetStrings[name] = recs
+	return recs
+}
+
 // Evaluate manages three kind of states.
 //   - Per tenant state:
 //     Gets initialized on new tenant. It fetches tenant's rules and creates a new recording for each rule.
@@ -149,17 +208,18 @@ func (o *SampleObserver) Evaluate(row block.ProfileEntry) func() {
 	}
 
 	// Detect a series switch
-	if o.state.fingerprint != row.Fingerprint {
-		// New series. Handle state.
-		o.initSeriesState(row)
+	if t := o.timestamp(row); o.state.fingerprint != row.Fingerprint || o.state.timestamp != t {
+		// New series, or new step of the series. Handle state.
+		o.initSeriesState(row, t)
 	}
 	return func() {
 		o.observe(row)
 	}
 }
 
-func (o *SampleObserver) initSeriesState(row block.ProfileEntry) {
+func (o *SampleObserver) initSeriesState(row block.ProfileEntry, timestamp int64) {
 	o.state.fingerprint = row.Fingerprint
+	o.state.timestamp = timestamp
 	o.state.recordSymbols = false
 
 	labelsMap := map[string]string{}
@@ -168,8 +228,8 @@ func (o *SampleObserver) initSeriesState(row block.ProfileEntry) {
 	}
 
 	for _, rec := range o.state.targetRecordings {
-		rec.initState(labelsMap, o.externalLabels, o.recordingTime)
-		if rec.state.matches && rec.rule.FunctionName != "" {
+		rec.initState(labelsMap, o.externalLabels, timestamp)
+		if rec.state.matches && rec.rule.HasStacktraceFilter() {
 			o.state.recordSymbols = true
 		}
 	}
@@ -183,45 +243,58 @@ func (o *SampleObserver) ObserveSymbols(strings []string, functions []schemav1.I
 		return
 	}
 
-	for ; o.state.seenLocations < len(locations); o.state.seenLocations++ {
-		for _, line := range locations[o.state.seenLocations].Line {
-			recs, hit := o.state.targetStrings[strings[functions[line.FunctionId].Name]]
-			if hit {
+	if len(o.state.targetFunctions) > 0 {
+		for ; o.state.seenLocations < len(locations); o.state.seenLocations++ {
+			for j, line := range locations[o.state.seenLocations].Line {
+				recs := o.functionRecordings(strings[functions[line.FunctionId].Name])
+				if len(recs) == 0 {
+					continue
+				}
 				targetLocation, exists := o.state.targetLocations[uint32(o.state.seenLocations)]
 				if !exists {
-					targetLocation = make(map[*recording]struct{})
+					targetLocation = make(map[*recording]bool)
 					o.state.targetLocations[uint32(o.state.seenLocations)] = targetLocation
 				}
 				for _, rec := range recs {
-					targetLocation[rec] = struct{}{}
+					// The first line of the location is the leaf.
+					targetLocation[rec] = targetLocation[rec] || j == 0
 				}
 			}
 		}
 	}
-	if len(o.state.targetLocations) == 0 {
+	if len(o.state.targetLocations) == 0 && len(o.state.targetCallSites) == 0 {
 		return
 	}
 	for i, stacktrace := range stacktraceValues {
-		for _, locationId := range stacktrace {
-			recs, hit := o.state.targetLocations[uint32(locationId)]
-			if hit {
-				targetStacktrace, exists := o.state.targetStacktraces[stacktraceIds[i]]
-				if !exists {
-					targetStacktrace = make(map[*recording]struct{})
-					o.state.targetStacktraces[stacktraceIds[i]] = targetStacktrace
-				}
-				for rec := range recs {
-					targetStacktrace[rec] = struct{}{}
+		// The first location of the stack trace is the leaf.
+		for j, locationId := range stacktrace {
+			for rec, leaf := range o.state.targetLocations[uint32(locationId)] {
+				if !rec.rule.Self || (j == 0 && leaf) {
+					o.addTargetStacktrace(stacktraceIds[i], rec)
 				}
 			}
 		}
+		for _, rec := range o.state.targetCallSites {
+			if rec.matchesCallSite(strings, functions, locations, stacktrace) {
+				o.addTargetStacktrace(stacktraceIds[i], rec)
+			}
+		}
 	}
 }
 
+func (o *SampleObserver) addTargetStacktrace(id uint32, rec *recording) {
+	targetStacktrace, exists := o.state.targetStacktraces[id]
+	if !exists {
+		targetStacktrace = make(map[*recording]struct{})
+		o.state.targetStacktraces[id] = targetStacktrace
+	}
+	targetStacktrace[rec] = struct{}{}
+}
+
 func (o *SampleObserver) observe(row block.ProfileEntry) {
 	// Totals are computed as follows: for every rule that matches the series, we add the TotalValue
 	for _, rec := range o.state.targetRecordings {
-		if rec.state.matches && rec.rule.FunctionName == "" {
+		if rec.state.matches && !rec.rule.HasStacktraceFilter() {
 			rec.state.sample.Value += float64(row.Row.TotalValue())
 		}
 	}
@@ -245,9 +318,7 @@ func (o *SampleObserver) flush() {
 	}
 	timeSeries := make([]prompb.TimeSeries, 0)
 	for _, rec := range o.state.recordings {
-		for _, series := range rec.data {
-			timeSeries = append(timeSeries, *series)
-		}
+		timeSeries = rec.appendTimeSeries(timeSeries)
 	}
 	if len(timeSeries) > 0 {
 		_ = o.exporter.Send(o.state.tenant, timeSeries)
@@ -272,14 +343,37 @@ func (r *recording) initState(labelsMap map[string]string, externalLabels labels
 	sort.Sort(exportedLabels)
 	aggregatedFp := model.Fingerprint(exportedLabels.Hash())
 
-	series, ok := r.data[aggregatedFp]
+	k := seriesKey{fingerprint: aggregatedFp, timestamp: recordingTime}
+	series, ok := r.data[k]
 	if !ok {
 		series = newTimeSeries(exportedLabels, recordingTime)
-		r.data[aggregatedFp] = series
+		r.data[k] = series
 	}
 	r.state.sample = &series.Samples[0]
 }
 
+// appendTimeSeries appends the recorded series to dst. Samples of the
+// same series are merged into a single series, ordered by timestamp.
+func (r *recording) appendTimeSeries(dst []prompb.TimeSeries) []prompb.TimeSeries {
+	offset := len(dst)
+	series := make(map[model.Fingerprint]int, len(r.data))
+	for k, s := range r.data {
+		i, ok := series[k.fingerprint]
+		if !ok {
+			series[k.fingerprint] = len(dst)
+			dst = append(dst, *s)
+			continue
+		}
+		dst[i].Samples = append(dst[i].Samples, s.Samples...)
+	}
+	for _, s := range dst[offset:] {
+		sort.Slice(s.Samples, func(i, j int) bool {
+			return s.Samples[i].Timestamp < s.Samples[j].Timestamp
+		})
+	}
+	return dst
+}
+
 func newTimeSeries(exportedLabels labels.Labels, recordingTime int64) *prompb.TimeSeries {
 	// prompb.Labels don't implement sort interface, so we need to use labels.Labels and transform it later
 	pbLabels := make([]prompb.Label, 0, len(exportedLabels))
@@ -317,6 +411,39 @@ func generateExportedLabels(labelsMap map[string]string, rec *recording, externa
 	return exportedLabels
 }
 
+// matchesCallSite reports whether the stack trace has the call site
+// prefix. If only self values are recorded, the stack trace leaf must
+// be the call site function at exactly the call site depth.
+func (r *recording) matchesCallSite(strings []string, functions []schemav1.InMemoryFunction, locations []schemav1.InMemoryLocation, stacktrace []int32) bool {
+	callSite := r.rule.CallSite
+	if len(stacktrace) == 0 {
+		return false
+	}
+	var pos int
+	for i := len(stacktrace) - 1; i >= 0 && pos < len(callSite); i-- {
+		lines := locations[stacktrace[i]].Line
+		for j := len(lines) - 1; j >= 0 && pos < len(callSite); j-- {
+			if strings[functions[lines[j].FunctionId].Name] != callSite[pos] {
+				return false
+			}
+			pos++
+		}
+	}
+	if pos < len(callSite) {
+		return false
+	}
+	if !r.rule.Self {
+		return true
+	}
+	// The prefix matches, therefore the stack trace only ends
+	// at the call site if it has no frames beyond the prefix.
+	var depth int
+	for _, loc := range stacktrace {
+		depth += len(locations[loc].Line)
+	}
+	return depth == len(callSite)
+}
+
 func (r *recording) matchesServiceName(dataset string) bool {
 	for _, matcher := range r.rule.Matchers {
 		if matcher.Name == "service_name" && !matcher.Matches(dataset) {
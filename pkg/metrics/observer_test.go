@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"reflect"
+	"regexp"
 	"sort"
 	"testing"
 
@@ -10,11 +11,13 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/prompb"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	phlareparquet "github.com/grafana/pyroscope/pkg/parquet"
 	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
 	"github.com/grafana/pyroscope/pkg/test/mocks/mockmetrics"
 )
@@ -98,6 +101,75 @@ func Test_Observer_observe(t *testing.T) {
 	exporter.AssertNotCalled(t, "Send", "tenant2", mock.Anything)
 }
 
+func Test_Observer_observeSymbols(t *testing.T) {
+	exporter := new(mockmetrics.MockExporter)
+	ruler := new(mockmetrics.MockRuler)
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "a", "1")}
+	rule := func(name string, r *phlaremodel.RecordingRule) *phlaremodel.RecordingRule {
+		r.Matchers = matchers
+		r.ExternalLabels = labels.Labels{{Name: "__name__", Value: name}}
+		return r
+	}
+	ruler.On("RecordingRules", mock.Anything).Return([]*phlaremodel.RecordingRule{
+		rule("foo_total", &phlaremodel.RecordingRule{FunctionName: "foo"}),
+		rule("b_self", &phlaremodel.RecordingRule{FunctionRegexp: regexp.MustCompile("^(?:b.*)$"), Self: true}),
+		rule("handler_total", &phlaremodel.RecordingRule{CallSite: []string{"main", "handler"}}),
+		rule("handler_gc_self", &phlaremodel.RecordingRule{CallSite: []string{"main", "handler", "gc"}, Self: true}),
+		rule("all", &phlaremodel.RecordingRule{}),
+	})
+
+	strings := []string{"", "main", "handler", "gc", "foo", "bar"}
+	functions := make([]v1.InMemoryFunction, len(strings))
+	for i := range functions {
+		functions[i] = v1.InMemoryFunction{Id: uint64(i), Name: uint32(i)}
+	}
+	line := func(fn uint32) v1.InMemoryLine { return v1.InMemoryLine{FunctionId: fn} }
+	locations := []v1.InMemoryLocation{
+		{Line: []v1.InMemoryLine{line(1)}},          // 0: main
+		{Line: []v1.InMemoryLine{line(2)}},          // 1: handler
+		{Line: []v1.InMemoryLine{line(3)}},          // 2: gc
+		{Line: []v1.InMemoryLine{line(4)}},          // 3: foo
+		{Line: []v1.InMemoryLine{line(5)}},          // 4: bar
+		{Line: []v1.InMemoryLine{line(5), line(4)}}, // 5: bar inlined into foo
+	}
+	// Leaf first.
+	stacktraces := [][]int32{
+		{2, 1, 0},    // 1: main;handler;gc
+		{3, 1, 0},    // 2: main;handler;foo
+		{4, 3, 0},    // 3: main;foo;bar
+		{5, 0},       // 4: main;foo;bar (inlined)
+		{3, 2, 1, 0}, // 5: main;handler;gc;foo
+		{2, 2, 1, 0}, // 6: main;handler;gc;gc
+	}
+	stacktraceIDs := []uint32{1, 2, 3, 4, 5, 6}
+
+	rows, err := phlareparquet.ReadAll(v1.NewInMemoryProfilesRowReader([]v1.InMemoryProfile{{
+		Samples:    v1.NewSamplesFromMap(map[uint32]uint64{1: 1, 2: 2, 3: 4, 4: 8, 5: 16, 6: 32}),
+		TotalValue: 63,
+	}}))
+	require.NoError(t, err)
+	entry := entriesOf([][]any{{"tenant1", [][]string{{"a", "1"}}, int64(63)}})[0]
+	entry.Row = v1.ProfileRow(rows[0])
+
+	exporter.On("Send", "tenant1", mock.MatchedBy(func(series []prompb.TimeSeries) bool {
+		return len(series) == 5 && sameSeries(series, []prompb.TimeSeries{
+			timeSeriesOf([]any{[][]string{{"__name__", "foo_total"}}, 2 + 4 + 8 + 16, blockTime}),
+			timeSeriesOf([]any{[][]string{{"__name__", "b_self"}}, 4 + 8, blockTime}),
+			timeSeriesOf([]any{[][]string{{"__name__", "handler_total"}}, 1 + 2 + 16 + 32, blockTime}),
+			timeSeriesOf([]any{[][]string{{"__name__", "handler_gc_self"}}, 1, blockTime}),
+			timeSeriesOf([]any{[][]string{{"__name__", "all"}}, 63, blockTime}),
+		})
+	})).Return(nil).Once()
+
+	observer := NewSampleObserver(blockTime, exporter, ruler)
+	observe := observer.Evaluate(entry)
+	observer.ObserveSymbols(strings, functions, locations, stacktraces, stacktraceIDs)
+	observe()
+	observer.Close()
+
+	exporter.AssertExpectations(t)
+}
+
 func sameSeries(series1 []prompb.TimeSeries, series2 []prompb.TimeSeries) bool {
 	for _, s := range series1 {
 		found := false
@@ -0,0 +1,334 @@
+package metrics
+
+import (
+	"cmp"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/backoff"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+const (
+	queueEntrySuffix = ".bin"
+	queueTempSuffix  = ".tmp"
+)
+
+// queue is a bounded on-disk queue of series pending delivery to a sink.
+//
+// Every Send call produces a queue entry: a file containing the tenant
+// and the snappy-compressed write request. Entries are delivered in
+// order by a worker goroutine that is started on demand and exits once
+// the queue is drained. Failed deliveries are retried with backoff,
+// unless the error is permanent. Once the queue size exceeds the limit,
+// the oldest entries are dropped. Entries that have not been delivered
+// before the process exits are recovered at start.
+type queue struct {
+	name    string
+	dir     string
+	config  QueueConfig
+	sink    Sink
+	logger  log.Logger
+	metrics *queueMetrics
+
+	mu      sync.Mutex
+	entries []queueEntry
+	size    int64
+	seq     uint64
+	running bool
+	idle    chan struct{}
+}
+
+type queueEntry struct {
+	seq  uint64
+	size int64
+}
+
+func newQueue(name string, config QueueConfig, sink Sink, logger log.Logger, metrics *queueMetrics) (*queue, error) {
+	q := &queue{
+		name:    name,
+		dir:     filepath.Join(config.Dir, name),
+		config:  config,
+		sink:    sink,
+		logger:  log.With(logger, "sink", name),
+		metrics: metrics,
+	}
+	if err := os.MkdirAll(q.dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := q.recover(); err != nil {
+		return nil, fmt.Errorf("failed to recover queue %s: %w", q.dir, err)
+	}
+	return q, nil
+}
+
+func (q *queue) recover() error {
+	files, err := os.ReadDir(q.dir)
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() {
+			continue
+		}
+		if strings.HasSuffix(name, queueTempSuffix) {
+			_ = os.Remove(filepath.Join(q.dir, name))
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, queueEntrySuffix), 10, 64)
+		if err != nil || !strings.HasSuffix(name, queueEntrySuffix) {
+			level.Warn(q.logger).Log("msg", "unexpected file in exporter queue directory", "file", name)
+			continue
+		}
+		info, err := f.Info()
+		if err != nil {
+			return err
+		}
+		q.entries = append(q.entries, queueEntry{seq: seq, size: info.Size()})
+		q.size += info.Size()
+	}
+	slices.SortFunc(q.entries, func(a, b queueEntry) int {
+		return cmp.Compare(a.seq, b.seq)
+	})
+	if n := len(q.entries); n > 0 {
+		q.seq = q.entries[n-1].seq + 1
+		level.Info(q.logger).Log("msg", "recovered exporter queue", "entries", n, "size", q.size)
+	}
+	q.updateMetrics()
+	q.mu.Lock()
+	q.startWorker()
+	q.mu.Unlock()
+	return nil
+}
+
+// push adds the series to the queue. The call returns once the
+// entry is persisted; it does not wait for the delivery.
+func (q *queue) push(tenantID string, series []prompb.TimeSeries) error {
+	b, err := encodeQueueEntry(tenantID, series)
+	if err != nil {
+		return err
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	e := queueEntry{seq: q.seq, size: int64(len(b))}
+	q.seq++
+	tmp := q.path(e.seq) + queueTempSuffix
+	if err = os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, q.path(e.seq)); err != nil {
+		return err
+	}
+	q.entries = append(q.entries, e)
+	q.size += e.size
+	for q.size > q.config.MaxSizeBytes && len(q.entries) > 0 {
+		level.Warn(q.logger).Log("msg", "exporter queue is full, dropping the oldest entry", "size", q.size)
+		q.removeLocked(q.entries[0].seq, "overflow")
+	}
+	q.updateMetrics()
+	q.startWorker()
+	return nil
+}
+
+// flush waits for the queue to be drained, or for the flush timeout
+// to expire: the remaining entries are delivered later.
+func (q *queue) flush() {
+	q.mu.Lock()
+	running, idle := q.running, q.idle
+	q.mu.Unlock()
+	if !running {
+		return
+	}
+	t := time.NewTimer(q.config.FlushTimeout)
+	defer t.Stop()
+	select {
+	case <-idle:
+	case <-t.C:
+		level.Warn(q.logger).Log("msg", "exporter queue flush timed out, pending entries will be retried", "size", q.pending())
+	}
+}
+
+func (q *queue) pending() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.entries)
+}
+
+func (q *queue) startWorker() {
+	if q.running || len(q.entries) == 0 {
+		return
+	}
+	q.running = true
+	q.idle = make(chan struct{})
+	go q.run()
+}
+
+func (q *queue) run() {
+	b := backoff.New(context.Background(), backoff.Config{
+		MinBackoff: q.config.MinBackoff,
+		MaxBackoff: q.config.MaxBackoff,
+	})
+	for {
+		q.mu.Lock()
+		if len(q.entries) == 0 {
+			q.running = false
+			close(q.idle)
+			q.mu.Unlock()
+			return
+		}
+		seq := q.entries[0].seq
+		q.mu.Unlock()
+
+		err := q.deliver(seq)
+		switch {
+		case err == nil:
+			q.remove(seq, "")
+			b.Reset()
+		case isPermanent(err):
+			level.Error(q.logger).Log("msg", "failed to deliver series, dropping", "err", err)
+			q.remove(seq, "permanent_error")
+			b.Reset()
+		default:
+			level.Warn(q.logger).Log("msg", "failed to deliver series, retrying", "err", err)
+			q.metrics.retries.WithLabelValues(q.name).Inc()
+			b.Wait()
+		}
+	}
+}
+
+func (q *queue) deliver(seq uint64) error {
+	b, err := os.ReadFile(q.path(seq))
+	if errors.Is(err, os.ErrNotExist) {
+		// The entry has been dropped.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	tenantID, series, err := decodeQueueEntry(b)
+	if err != nil {
+		return Permanent(err)
+	}
+	return q.sink.Send(context.Background(), tenantID, series)
+}
+
+func (q *queue) remove(seq uint64, reason string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.removeLocked(seq, reason)
+	q.updateMetrics()
+}
+
+func (q *queue) removeLocked(seq uint64, reason string) {
+	i := slices.IndexFunc(q.entries, func(e queueEntry) bool { return e.seq == seq })
+	if i < 0 {
+		return
+	}
+	q.size -= q.entries[i].size
+	q.entries = slices.Delete(q.entries, i, i+1)
+	if err := os.Remove(q.path(seq)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		level.Warn(q.logger).Log("msg", "failed to remove exporter queue entry", "err", err)
+	}
+	if reason != "" {
+		q.metrics.dropped.WithLabelValues(q.name, reason).Inc()
+	}
+}
+
+func (q *queue) updateMetrics() {
+	q.metrics.entries.WithLabelValues(q.name).Set(float64(len(q.entries)))
+	q.metrics.size.WithLabelValues(q.name).Set(float64(q.size))
+}
+
+func (q *queue) path(seq uint64) string {
+	return filepath.Join(q.dir, fmt.Sprintf("%020d", seq)+queueEntrySuffix)
+}
+
+// encodeQueueEntry encodes the queue entry as the uvarint length of
+// the tenant ID, followed by the tenant ID and the snappy-compressed
+// write request.
+func encodeQueueEntry(tenantID string, series []prompb.TimeSeries) ([]byte, error) {
+	req := prompb.WriteRequest{Timeseries: series}
+	p, err := req.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	b := binary.AppendUvarint(nil, uint64(len(tenantID)))
+	b = append(b, tenantID...)
+	return append(b, snappy.Encode(nil, p)...), nil
+}
+
+func decodeQueueEntry(b []byte) (string, []prompb.TimeSeries, error) {
+	n, off := binary.Uvarint(b)
+	if off <= 0 || uint64(len(b)-off) < n {
+		return "", nil, errors.New("invalid queue entry header")
+	}
+	tenantID := string(b[off : off+int(n)])
+	p, err := snappy.Decode(nil, b[off+int(n):])
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid queue entry: %w", err)
+	}
+	var req prompb.WriteRequest
+	if err = req.Unmarshal(p); err != nil {
+		return "", nil, fmt.Errorf("invalid queue entry: %w", err)
+	}
+	return tenantID, req.Timeseries, nil
+}
+
+type queueMetrics struct {
+	entries *prometheus.GaugeVec
+	size    *prometheus.GaugeVec
+	dropped *prometheus.CounterVec
+	retries *prometheus.CounterVec
+}
+
+func newQueueMetrics(reg prometheus.Registerer) *queueMetrics {
+	m := &queueMetrics{
+		entries: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "queue_entries",
+			Help:      "Number of entries pending delivery.",
+		}, []string{"sink"}),
+		size: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "queue_size_bytes",
+			Help:      "Size (in bytes) of the entries pending delivery.",
+		}, []string{"sink"}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "queue_entries_dropped_total",
+			Help:      "Number of entries dropped without delivery.",
+		}, []string{"sink", "reason"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "queue_retries_total",
+			Help:      "Number of failed deliveries to be retried.",
+		}, []string{"sink"}),
+	}
+	if reg != nil {
+		reg.MustRegister(
+			m.entries,
+			m.size,
+			m.dropped,
+			m.retries,
+		)
+	}
+	return m
+}
@@ -0,0 +1,86 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// Sink is a destination of the recorded series.
+//
+// Send must be synchronous: the series are only removed from the
+// exporter queue once Send succeeds. A sink should return an error
+// wrapped with Permanent, if retrying the request is pointless.
+type Sink interface {
+	Send(ctx context.Context, tenant string, series []prompb.TimeSeries) error
+}
+
+type SinkConfig struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"`
+	// URL of the remote endpoint: remote_write and otlp sinks.
+	URL string `yaml:"url"`
+	// Path to the output file: file sink.
+	Path string `yaml:"path"`
+}
+
+const (
+	SinkTypeRemoteWrite = "remote_write"
+	SinkTypeOTLP        = "otlp"
+	SinkTypeFile        = "file"
+)
+
+// SinkFactory creates a sink of the type it is registered for.
+type SinkFactory func(cfg SinkConfig, logger log.Logger, reg prometheus.Registerer) (Sink, error)
+
+var sinkFactories = map[string]SinkFactory{
+	SinkTypeRemoteWrite: newRemoteWriteSink,
+	SinkTypeOTLP:        newOTLPSink,
+	SinkTypeFile:        newFileSink,
+}
+
+// RegisterSinkType makes the sink type available in the configuration.
+// The function is not safe for concurrent use and is supposed to be
+// called at initialization.
+func RegisterSinkType(typ string, factory SinkFactory) {
+	sinkFactories[typ] = factory
+}
+
+func sinkTypes() []string {
+	types := make([]string, 0, len(sinkFactories))
+	for t := range sinkFactories {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+func newSink(cfg SinkConfig, logger log.Logger, reg prometheus.Registerer) (Sink, error) {
+	factory, ok := sinkFactories[cfg.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+	return factory(cfg, logger, reg)
+}
+
+type permanentError struct{ error }
+
+func (e permanentError) Unwrap() error { return e.error }
+
+// Permanent marks the error as non-retryable.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+func isPermanent(err error) bool {
+	var p permanentError
+	return errors.As(err, &p)
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/prompb"
+)
+
+// fileSink appends series to a local file as JSON lines.
+// It is mostly useful for debugging recording rules.
+type fileSink struct {
+	mu   sync.Mutex
+	path string
+	// offset is the size of the file after the last successful write.
+	// Anything past it was left by a failed attempt, which the queue
+	// retries with the same series.
+	offset int64
+}
+
+type fileSinkEntry struct {
+	Tenant  string            `json:"tenant"`
+	Labels  map[string]string `json:"labels"`
+	Samples []fileSinkSample  `json:"samples"`
+}
+
+type fileSinkSample struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+func newFileSink(cfg SinkConfig, _ log.Logger, _ prometheus.Registerer) (Sink, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("sink %q: path is required", cfg.Name)
+	}
+	s := &fileSink{path: cfg.Path}
+	info, err := os.Stat(cfg.Path)
+	switch {
+	case err == nil:
+		s.offset = info.Size()
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("sink %q: %w", cfg.Name, err)
+	}
+	return s, nil
+}
+
+func (s *fileSink) Send(_ context.Context, tenantID string, series []prompb.TimeSeries) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ts := range series {
+		e := fileSinkEntry{
+			Tenant:  tenantID,
+			Labels:  make(map[string]string, len(ts.Labels)),
+			Samples: make([]fileSinkSample, len(ts.Samples)),
+		}
+		for _, l := range ts.Labels {
+			e.Labels[l.Name] = l.Value
+		}
+		for i, sample := range ts.Samples {
+			e.Samples[i] = fileSinkSample{Timestamp: sample.Timestamp, Value: sample.Value}
+		}
+		if err := enc.Encode(e); err != nil {
+			return Permanent(err)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// Discard the partial output of a previous failed attempt.
+	if err = f.Truncate(s.offset); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if _, err = f.WriteAt(buf.Bytes(), s.offset); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	s.offset += int64(buf.Len())
+	return nil
+}
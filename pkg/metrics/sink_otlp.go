@@ -0,0 +1,111 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+const otlpSinkTimeout = 10 * time.Second
+
+// otlpSink sends series as OTLP gauges over HTTP.
+type otlpSink struct {
+	url    string
+	client *http.Client
+}
+
+func newOTLPSink(cfg SinkConfig, _ log.Logger, _ prometheus.Registerer) (Sink, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("sink %q: url is required", cfg.Name)
+	}
+	return &otlpSink{
+		url:    cfg.URL,
+		client: &http.Client{Timeout: otlpSinkTimeout},
+	}, nil
+}
+
+func (s *otlpSink) Send(ctx context.Context, tenantID string, series []prompb.TimeSeries) error {
+	b, err := proto.Marshal(otlpMetricsRequest(series))
+	if err != nil {
+		return Permanent(fmt.Errorf("unable to marshal metrics request: %w", err))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("User-Agent", metricsExporterUserAgent)
+	req.Header.Set("X-Scope-OrgID", tenantID)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 == 2 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	err = fmt.Errorf("server returned HTTP status %s: %s", resp.Status, bytes.TrimSpace(body))
+	if resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests {
+		return err
+	}
+	return Permanent(err)
+}
+
+// otlpMetricsRequest converts series to OTLP gauges: the series are
+// grouped by the metric name, other labels become data point attributes.
+func otlpMetricsRequest(series []prompb.TimeSeries) *colmetricspb.ExportMetricsServiceRequest {
+	metrics := make([]*metricspb.Metric, 0, len(series))
+	byName := make(map[string]*metricspb.Gauge)
+	for _, s := range series {
+		var name string
+		attrs := make([]*commonpb.KeyValue, 0, len(s.Labels))
+		for _, l := range s.Labels {
+			if l.Name == model.MetricNameLabel {
+				name = l.Value
+				continue
+			}
+			attrs = append(attrs, &commonpb.KeyValue{
+				Key:   l.Name,
+				Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: l.Value}},
+			})
+		}
+		gauge, ok := byName[name]
+		if !ok {
+			gauge = new(metricspb.Gauge)
+			byName[name] = gauge
+			metrics = append(metrics, &metricspb.Metric{
+				Name: name,
+				Data: &metricspb.Metric_Gauge{Gauge: gauge},
+			})
+		}
+		for _, sample := range s.Samples {
+			gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+				Attributes:   attrs,
+				TimeUnixNano: uint64(time.UnixMilli(sample.Timestamp).UnixNano()),
+				Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: sample.Value},
+			})
+		}
+	}
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			ScopeMetrics: []*metricspb.ScopeMetrics{{
+				Scope:   &commonpb.InstrumentationScope{Name: metricsExporterUserAgent},
+				Metrics: metrics,
+			}},
+		}},
+	}
+}
@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/gogo/protobuf/proto"
+	"github.com/grafana/dskit/instrument"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage/remote"
+
+	"github.com/grafana/pyroscope/pkg/tenant"
+)
+
+const (
+	metricsExporterUserAgent = "pyroscope-metrics-exporter"
+)
+
+type remoteWriteSink struct {
+	client  remote.WriteClient
+	metrics *clientMetrics
+}
+
+func newRemoteWriteSink(cfg SinkConfig, _ log.Logger, reg prometheus.Registerer) (Sink, error) {
+	metrics := newMetrics(reg, cfg.URL)
+	client, err := newClient(cfg.URL, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return &remoteWriteSink{
+		client:  client,
+		metrics: metrics,
+	}, nil
+}
+
+func (s *remoteWriteSink) Send(ctx context.Context, tenantID string, data []prompb.TimeSeries) error {
+	p := &prompb.WriteRequest{Timeseries: data}
+	buf := proto.NewBuffer(nil)
+	if err := buf.Marshal(p); err != nil {
+		return Permanent(fmt.Errorf("unable to marshal prompb.WriteRequest: %w", err))
+	}
+	ctx = tenant.InjectTenantID(ctx, tenantID)
+	if _, err := s.client.Store(ctx, snappy.Encode(nil, buf.Bytes()), 0); err != nil {
+		var recoverable remote.RecoverableError
+		if !errors.As(err, &recoverable) {
+			err = Permanent(err)
+		}
+		return fmt.Errorf("unable to store prompb.WriteRequest: %w", err)
+	}
+	s.metrics.seriesSent.WithLabelValues(tenantID).Add(float64(len(data)))
+	return nil
+}
+
+func newClient(remoteUrl string, m *clientMetrics) (remote.WriteClient, error) {
+	wURL, err := url.Parse(remoteUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := remote.NewWriteClient("exporter", &remote.ClientConfig{
+		URL:     &config.URL{URL: wURL},
+		Timeout: model.Duration(time.Second * 10),
+		Headers: map[string]string{
+			"User-Agent": metricsExporterUserAgent,
+		},
+		// Requests are retried by the exporter queue.
+		RetryOnRateLimit: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := client.(*remote.Client).Client.Transport
+	client.(*remote.Client).Client.Transport = &RoundTripper{m, t}
+	return client, nil
+}
+
+type clientMetrics struct {
+	requestDuration *prometheus.HistogramVec
+	requestBodySize *prometheus.CounterVec
+	seriesSent      *prometheus.CounterVec
+}
+
+func newMetrics(reg prometheus.Registerer, remoteUrl string) *clientMetrics {
+	m := &clientMetrics{
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "client_request_duration_seconds",
+			Help:      "Time (in seconds) spent on remote_write",
+			Buckets:   instrument.DefBuckets,
+		}, []string{"route", "status_code", "tenant"}),
+		requestBodySize: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "request_message_bytes_total",
+			Help:      "Size (in bytes) of messages sent on remote_write.",
+		}, []string{"route", "status_code", "tenant"}),
+		seriesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "metrics_exporter",
+			Name:      "series_sent_total",
+			Help:      "Number of series sent on remote_write.",
+		}, []string{"tenant"}),
+	}
+	if reg != nil {
+		remoteUrlReg := prometheus.WrapRegistererWith(prometheus.Labels{"url": remoteUrl}, reg)
+		remoteUrlReg.MustRegister(
+			m.requestDuration,
+			m.requestBodySize,
+			m.seriesSent,
+		)
+	}
+	return m
+}
+
+type RoundTripper struct {
+	metrics *clientMetrics
+	next    http.RoundTripper
+}
+
+func (m *RoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	tenantId, err := tenant.ExtractTenantIDFromContext(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tenant ID from context: %w", err)
+	}
+	req.Header.Set("X-Scope-OrgId", tenantId)
+
+	start := time.Now()
+	resp, err := m.next.RoundTrip(req)
+	duration := time.Since(start)
+
+	statusCode := ""
+	if resp != nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.metrics.requestDuration.WithLabelValues(req.RequestURI, statusCode, tenantId).Observe(duration.Seconds())
+	m.metrics.requestBodySize.WithLabelValues(req.RequestURI, statusCode, tenantId).Add(float64(req.ContentLength))
+	return resp, err
+}
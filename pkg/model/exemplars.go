@@ -0,0 +1,99 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// ExemplarsMerger retains the profile exemplars with the biggest
+// total value within each step. Similarly to RangeSeries, steps are
+// spaced by step from start: an exemplar belongs to the step t, if
+// its timestamp is in the range (t-step, t]. Unlike a limit of the top N series, the limit
+// of exemplars per step can be applied to partial results.
+type ExemplarsMerger struct {
+	mu    sync.Mutex
+	start int64
+	step  int64
+	limit int
+	steps map[int64][]*typesv1.ProfileExemplar
+}
+
+// NewExemplarsMerger creates a new merger that keeps up to limit
+// exemplars per step. The start and step are specified in milliseconds.
+func NewExemplarsMerger(start, step int64, limit int) *ExemplarsMerger {
+	return &ExemplarsMerger{
+		start: start,
+		step:  max(step, 1),
+		limit: max(limit, 1),
+		steps: make(map[int64][]*typesv1.ProfileExemplar),
+	}
+}
+
+func (m *ExemplarsMerger) MergeExemplars(exemplars []*typesv1.ProfileExemplar) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range exemplars {
+		m.add(e)
+	}
+}
+
+func (m *ExemplarsMerger) Add(e *typesv1.ProfileExemplar) {
+	m.mu.Lock()
+	m.add(e)
+	m.mu.Unlock()
+}
+
+func (m *ExemplarsMerger) add(e *typesv1.ProfileExemplar) {
+	t := alignToStep(e.Timestamp, m.start, m.step)
+	s := append(m.steps[t], e)
+	// Truncate periodically to bound memory usage.
+	if len(s) >= 2*m.limit {
+		s = m.top(s)
+	}
+	m.steps[t] = s
+}
+
+// Exemplars returns the retained exemplars ordered by timestamp.
+func (m *ExemplarsMerger) Exemplars() []*typesv1.ProfileExemplar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var size int
+	for t, s := range m.steps {
+		s = m.top(s)
+		m.steps[t] = s
+		size += len(s)
+	}
+	exemplars := make([]*typesv1.ProfileExemplar, 0, size)
+	for _, s := range m.steps {
+		exemplars = append(exemplars, s...)
+	}
+	slices.SortFunc(exemplars, func(a, b *typesv1.ProfileExemplar) int {
+		return cmp.Or(
+			cmp.Compare(a.Timestamp, b.Timestamp),
+			cmp.Compare(a.ProfileId, b.ProfileId),
+			CompareLabelPairs(a.Labels, b.Labels),
+		)
+	})
+	return exemplars
+}
+
+func (m *ExemplarsMerger) top(s []*typesv1.ProfileExemplar) []*typesv1.ProfileExemplar {
+	if len(s) <= m.limit {
+		return s
+	}
+	// Ties are resolved deterministically, so that
+	// the result does not depend on the merge order.
+	slices.SortFunc(s, func(a, b *typesv1.ProfileExemplar) int {
+		return cmp.Or(
+			cmp.Compare(b.Value, a.Value),
+			cmp.Compare(a.Timestamp, b.Timestamp),
+			cmp.Compare(a.ProfileId, b.ProfileId),
+			CompareLabelPairs(a.Labels, b.Labels),
+		)
+	})
+	clear(s[m.limit:])
+	return s[:m.limit]
+}
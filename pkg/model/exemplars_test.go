@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_ExemplarsMerger(t *testing.T) {
+	m := NewExemplarsMerger(0, 10, 2)
+	m.MergeExemplars([]*typesv1.ProfileExemplar{
+		{ProfileId: "a", Timestamp: 1, Value: 1},
+		{ProfileId: "b", Timestamp: 5, Value: 3},
+		{ProfileId: "c", Timestamp: 10, Value: 2},
+		{ProfileId: "d", Timestamp: 11, Value: 1},
+	})
+	m.MergeExemplars([]*typesv1.ProfileExemplar{
+		{ProfileId: "e", Timestamp: 9, Value: 4},
+		{ProfileId: "f", Timestamp: 20, Value: 1},
+	})
+	m.Add(&typesv1.ProfileExemplar{ProfileId: "g", Timestamp: 21, Value: 5})
+
+	expected := []*typesv1.ProfileExemplar{
+		{ProfileId: "b", Timestamp: 5, Value: 3},
+		{ProfileId: "e", Timestamp: 9, Value: 4},
+		{ProfileId: "d", Timestamp: 11, Value: 1},
+		{ProfileId: "f", Timestamp: 20, Value: 1},
+		{ProfileId: "g", Timestamp: 21, Value: 5},
+	}
+	assert.Equal(t, expected, m.Exemplars())
+}
+
+func Test_ExemplarsMerger_StepsAlignedToStart(t *testing.T) {
+	m := NewExemplarsMerger(5, 10, 1)
+	m.MergeExemplars([]*typesv1.ProfileExemplar{
+		{ProfileId: "a", Timestamp: 1, Value: 1},
+		{ProfileId: "b", Timestamp: 6, Value: 2},
+		{ProfileId: "c", Timestamp: 15, Value: 1},
+		{ProfileId: "d", Timestamp: 16, Value: 1},
+	})
+
+	// Steps: (-inf, 5], (5, 15], (15, 25].
+	expected := []*typesv1.ProfileExemplar{
+		{ProfileId: "a", Timestamp: 1, Value: 1},
+		{ProfileId: "b", Timestamp: 6, Value: 2},
+		{ProfileId: "d", Timestamp: 16, Value: 1},
+	}
+	assert.Equal(t, expected, m.Exemplars())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/grafana/pyroscope/pkg/og/structs/cappedarr"
+	"github.com/grafana/pyroscope/pkg/util/minheap"
 
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 )
@@ -158,6 +159,141 @@ func NewFlamegraphDiffFromBytes(left, right []byte, maxNodes int64) (*querierv1.
 	return NewFlamegraphDiff(l, r, maxNodes)
 }
 
+// TruncateTrees truncates the trees consistently, so that they can be
+// compared: the minimum node value is calculated over the union of the
+// trees, where the value of a node is the maximum of its values in the
+// trees, and a node is retained in both trees, if it is retained in
+// either of them. Truncated nodes are accounted in the "other" nodes.
+// The function modifies the trees in place.
+func TruncateTrees(left, right *Tree, maxNodes int64) {
+	minVal := unionMinValue(left, right, maxNodes)
+	if minVal == 0 {
+		return
+	}
+	// Virtual root nodes.
+	leftRoot := &node{children: left.root}
+	rghtRoot := &node{children: right.root}
+	nodes := []nodePair{{left: leftRoot, rght: rghtRoot}}
+	var p nodePair
+	for len(nodes) > 0 {
+		p, nodes = nodes[len(nodes)-1], nodes[:len(nodes)-1]
+		var otherLeft, otherRght int64
+		var li, ri int
+		unionChildren(p, func(c nodePair) {
+			if max(c.left.totalValue(), c.rght.totalValue()) >= minVal || c.name() == truncatedNodeName {
+				if c.left != nil {
+					p.left.children[li] = c.left
+					li++
+				}
+				if c.rght != nil {
+					p.rght.children[ri] = c.rght
+					ri++
+				}
+				nodes = append(nodes, c)
+				return
+			}
+			otherLeft += c.left.totalValue()
+			otherRght += c.rght.totalValue()
+		})
+		if p.left != nil {
+			p.left.children = p.left.children[:li]
+			appendOther(p.left, otherLeft)
+		}
+		if p.rght != nil {
+			p.rght.children = p.rght.children[:ri]
+			appendOther(p.rght, otherRght)
+		}
+	}
+	left.root = leftRoot.children
+	right.root = rghtRoot.children
+}
+
+func appendOther(n *node, v int64) {
+	if v > 0 {
+		o := n.insert(truncatedNodeName)
+		o.total += v
+		o.self += v
+	}
+}
+
+// unionMinValue returns the minimum value a node must have to be
+// retained in the union of the trees.
+func unionMinValue(left, right *Tree, maxNodes int64) int64 {
+	if maxNodes < 1 {
+		return 0
+	}
+	h := make([]int64, 0, maxNodes)
+	nodes := []nodePair{{left: &node{children: left.root}, rght: &node{children: right.root}}}
+	var p nodePair
+	for len(nodes) > 0 {
+		p, nodes = nodes[len(nodes)-1], nodes[:len(nodes)-1]
+		unionChildren(p, func(c nodePair) {
+			v := max(c.left.totalValue(), c.rght.totalValue())
+			if len(h) >= int(maxNodes) {
+				if v <= h[0] {
+					return
+				}
+				h = minheap.Pop(h)
+			}
+			h = minheap.Push(h, v)
+			nodes = append(nodes, c)
+		})
+	}
+	if len(h) < int(maxNodes) {
+		return 0
+	}
+	return h[0]
+}
+
+// nodePair represents a node of the union of two trees:
+// either of the nodes may be nil.
+type nodePair struct {
+	left, rght *node
+}
+
+func (p nodePair) name() string {
+	if p.left != nil {
+		return p.left.name
+	}
+	return p.rght.name
+}
+
+func (n *node) totalValue() int64 {
+	if n == nil {
+		return 0
+	}
+	return n.total
+}
+
+// unionChildren calls fn for each child of the union node in the
+// order of names. The children are expected to be sorted by name.
+// It is safe to modify the children slices within the callback,
+// as long as the elements that have not been visited are preserved.
+func unionChildren(p nodePair, fn func(nodePair)) {
+	var lc, rc []*node
+	if p.left != nil {
+		lc = p.left.children
+	}
+	if p.rght != nil {
+		rc = p.rght.children
+	}
+	var i, j int
+	for i < len(lc) || j < len(rc) {
+		switch {
+		case j == len(rc) || (i < len(lc) && lc[i].name < rc[j].name):
+			i++
+			fn(nodePair{left: lc[i-1]})
+		case i == len(lc) || rc[j].name < lc[i].name:
+			j++
+			fn(nodePair{rght: rc[j-1]})
+		default:
+			i++
+			j++
+			fn(nodePair{left: lc[i-1], rght: rc[j-1]})
+		}
+	}
+}
+
 // combineTree aligns 2 trees by making them having the same structure with the
 // same number of nodes
 // It also makes the tree have a single root
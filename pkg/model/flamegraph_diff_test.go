@@ -123,3 +123,51 @@ func Test_Diff_No_Common_Root(t *testing.T) {
 	_, err := NewFlamegraphDiff(tr, tr2, 1024)
 	assert.NoError(t, err)
 }
+
+func Test_TruncateTrees(t *testing.T) {
+	left := new(Tree)
+	left.InsertStack(10, "a", "b")
+	left.InsertStack(1, "a", "c")
+	left.InsertStack(1, "e", "f")
+
+	right := new(Tree)
+	right.InsertStack(1, "a", "b")
+	right.InsertStack(10, "a", "c")
+	right.InsertStack(1, "a", "d")
+
+	// The union tree consists of a, b, c, d, e, f nodes. Three nodes
+	// are retained: a, b, and c. Note that c is retained in the left
+	// tree, although its value is small, and b is retained in the
+	// right tree for the same reason.
+	TruncateTrees(left, right, 3)
+
+	expectedLeft := new(Tree)
+	expectedLeft.InsertStack(10, "a", "b")
+	expectedLeft.InsertStack(1, "a", "c")
+	expectedLeft.InsertStack(1, "other")
+
+	expectedRight := new(Tree)
+	expectedRight.InsertStack(1, "a", "b")
+	expectedRight.InsertStack(10, "a", "c")
+	expectedRight.InsertStack(1, "a", "other")
+
+	assert.Equal(t, expectedLeft.String(), left.String())
+	assert.Equal(t, expectedRight.String(), right.String())
+	assert.Equal(t, expectedLeft.String(), MustUnmarshalTree(left.Bytes(0)).String())
+	assert.Equal(t, expectedRight.String(), MustUnmarshalTree(right.Bytes(0)).String())
+}
+
+func Test_TruncateTrees_NoTruncation(t *testing.T) {
+	left := new(Tree)
+	left.InsertStack(10, "a", "b")
+	right := new(Tree)
+	right.InsertStack(1, "a", "c")
+
+	expectedLeft, expectedRight := left.String(), right.String()
+	TruncateTrees(left, right, 4)
+	assert.Equal(t, expectedLeft, left.String())
+	assert.Equal(t, expectedRight, right.String())
+	TruncateTrees(left, right, 0)
+	assert.Equal(t, expectedLeft, left.String())
+	assert.Equal(t, expectedRight, right.String())
+}
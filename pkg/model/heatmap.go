@@ -0,0 +1,123 @@
+package model
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"sort"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// DefaultHeatmapBuckets is the number of the heatmap value
+// buckets used, if the number is not specified explicitly.
+const DefaultHeatmapBuckets = 20
+
+// HeatmapBounds returns the bounds of the value buckets that cover the
+// values of all the series points. The number of bounds is buckets+1.
+//
+// If the log scale is used, the bounds are spaced logarithmically
+// between the minimum and maximum positive values; non-positive
+// values fall into the first bucket.
+func HeatmapBounds(series []*typesv1.Series, buckets int, logScale bool) []float64 {
+	if buckets <= 0 {
+		buckets = DefaultHeatmapBuckets
+	}
+	lo, hi := math.Inf(1), math.Inf(-1)
+	var points int
+	for _, s := range series {
+		for _, p := range s.Points {
+			points++
+			if logScale && p.Value <= 0 {
+				continue
+			}
+			lo = min(lo, p.Value)
+			hi = max(hi, p.Value)
+		}
+	}
+	if points == 0 {
+		return nil
+	}
+	if lo > hi {
+		// Log scale, and there are no positive values.
+		lo, hi = 1, 1
+	}
+	bounds := make([]float64, buckets+1)
+	if logScale {
+		if hi == lo {
+			hi = lo * 2
+		}
+		r := math.Log(hi / lo)
+		for i := range bounds {
+			bounds[i] = lo * math.Exp(r*float64(i)/float64(buckets))
+		}
+	} else {
+		if hi == lo {
+			hi = lo + 1
+		}
+		w := (hi - lo) / float64(buckets)
+		for i := range bounds {
+			bounds[i] = lo + w*float64(i)
+		}
+	}
+	// Avoid rounding errors: the maximum
+	// value must fall into the last bucket.
+	bounds[0], bounds[buckets] = lo, hi
+	return bounds
+}
+
+// NewHeatmap builds heatmap series from the points of individual
+// profiles: for each step, the number of points that fall into each
+// of the value buckets is counted. Similarly to RangeSeries, points
+// are assigned to the steps spaced by step from start: a point belongs
+// to the step t, if its timestamp is in the range (t-step, t].
+func NewHeatmap(series []*typesv1.Series, bounds []float64, start, step int64) []*querierv1.HeatmapSeries {
+	buckets := len(bounds) - 1
+	if buckets < 1 {
+		return nil
+	}
+	result := make([]*querierv1.HeatmapSeries, 0, len(series))
+	for _, s := range series {
+		columns := make(map[int64][]int64)
+		for _, p := range s.Points {
+			ts := alignToStep(p.Timestamp, start, step)
+			counts, ok := columns[ts]
+			if !ok {
+				counts = make([]int64, buckets)
+				columns[ts] = counts
+			}
+			counts[heatmapBucket(bounds, p.Value)]++
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		hs := &querierv1.HeatmapSeries{
+			Labels:  s.Labels,
+			Columns: make([]*querierv1.HeatmapColumn, 0, len(columns)),
+		}
+		for ts, counts := range columns {
+			hs.Columns = append(hs.Columns, &querierv1.HeatmapColumn{
+				Timestamp: ts,
+				Counts:    counts,
+			})
+		}
+		slices.SortFunc(hs.Columns, func(a, b *querierv1.HeatmapColumn) int {
+			return cmp.Compare(a.Timestamp, b.Timestamp)
+		})
+		result = append(result, hs)
+	}
+	return result
+}
+
+func alignToStep(ts, start, step int64) int64 {
+	if step <= 0 || ts <= start {
+		return start
+	}
+	return start + (ts-start+step-1)/step*step
+}
+
+func heatmapBucket(bounds []float64, v float64) int {
+	i := sort.Search(len(bounds), func(i int) bool { return bounds[i] > v }) - 1
+	return max(0, min(i, len(bounds)-2))
+}
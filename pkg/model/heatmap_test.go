@@ -0,0 +1,48 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_HeatmapBounds(t *testing.T) {
+	series := []*typesv1.Series{
+		{Points: []*typesv1.Point{{Value: 0}, {Value: 10}}},
+		{Points: []*typesv1.Point{{Value: 1000}}},
+	}
+	assert.Nil(t, HeatmapBounds(nil, 4, false))
+	assert.Equal(t, []float64{0, 250, 500, 750, 1000}, HeatmapBounds(series, 4, false))
+	assert.InDeltaSlice(t, []float64{10, 100, 1000}, HeatmapBounds(series, 2, true), 1e-9)
+	assert.Len(t, HeatmapBounds(series, 0, false), DefaultHeatmapBuckets+1)
+
+	single := []*typesv1.Series{{Points: []*typesv1.Point{{Value: 5}}}}
+	assert.Equal(t, []float64{5, 6}, HeatmapBounds(single, 1, false))
+	assert.Equal(t, []float64{5, 10}, HeatmapBounds(single, 1, true))
+}
+
+func Test_NewHeatmap(t *testing.T) {
+	lbs := []*typesv1.LabelPair{{Name: "pod", Value: "a"}}
+	series := []*typesv1.Series{{
+		Labels: lbs,
+		Points: []*typesv1.Point{
+			{Timestamp: 1, Value: 0},
+			{Timestamp: 10, Value: 10},
+			{Timestamp: 11, Value: 5},
+			{Timestamp: 25, Value: 10},
+		},
+	}}
+	bounds := HeatmapBounds(series, 2, false)
+	expected := []*querierv1.HeatmapSeries{{
+		Labels: lbs,
+		Columns: []*querierv1.HeatmapColumn{
+			{Timestamp: 10, Counts: []int64{1, 1}},
+			{Timestamp: 20, Counts: []int64{0, 1}},
+			{Timestamp: 30, Counts: []int64{0, 1}},
+		},
+	}}
+	assert.Equal(t, expected, NewHeatmap(series, bounds, 0, 10))
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"fmt"
+	"regexp"
 
 	prometheusmodel "github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
@@ -14,7 +15,31 @@ type RecordingRule struct {
 	Matchers       []*labels.Matcher
 	GroupBy        []string
 	ExternalLabels labels.Labels
+
+	// Stack trace filter: at most one of FunctionName,
+	// FunctionRegexp, and CallSite is set.
 	FunctionName   string
+	FunctionRegexp *regexp.Regexp
+	// CallSite is the stack trace prefix, root at CallSite[0].
+	CallSite []string
+	// Self specifies that only stack traces, where the filter
+	// function is the leaf, are accounted.
+	Self bool
+}
+
+// HasStacktraceFilter reports whether the rule requires
+// stack trace symbols to be evaluated.
+func (r *RecordingRule) HasStacktraceFilter() bool {
+	return r.FunctionName != "" || r.FunctionRegexp != nil || len(r.CallSite) > 0
+}
+
+// MatchesFunction reports whether the function name matches
+// the rule's function name or regular expression.
+func (r *RecordingRule) MatchesFunction(name string) bool {
+	if r.FunctionRegexp != nil {
+		return r.FunctionRegexp.MatchString(name)
+	}
+	return r.FunctionName != "" && r.FunctionName == name
 }
 
 func NewRecordingRule(rule *settingsv1.RecordingRule) (*RecordingRule, error) {
@@ -41,18 +66,14 @@ func NewRecordingRule(rule *settingsv1.RecordingRule) (*RecordingRule, error) {
 	if profileTypeMatcher.Type != labels.MatchEqual {
 		return nil, fmt.Errorf("__profile_type__ matcher is not an equality")
 	}
-	var functionName string
-	if rule.StacktraceFilter != nil {
-		if rule.StacktraceFilter.FunctionName != nil {
-			functionName = rule.StacktraceFilter.FunctionName.FunctionName
-		}
-	}
 
 	r := &RecordingRule{
 		Matchers:       matchers,
 		GroupBy:        rule.GroupBy,
 		ExternalLabels: make(labels.Labels, 0, len(rule.ExternalLabels)+1),
-		FunctionName:   functionName,
+	}
+	if err = r.setStacktraceFilter(rule.StacktraceFilter); err != nil {
+		return nil, fmt.Errorf("invalid stacktrace filter: %w", err)
 	}
 
 	// ensure __name__ is unique
@@ -74,6 +95,75 @@ func NewRecordingRule(rule *settingsv1.RecordingRule) (*RecordingRule, error) {
 	return r, nil
 }
 
+func (r *RecordingRule) setStacktraceFilter(f *settingsv1.StacktraceFilter) error {
+	if err := ValidateStacktraceFilter(f); err != nil {
+		return err
+	}
+	switch {
+	case f.GetFunctionName() != nil:
+		r.FunctionName = f.FunctionName.FunctionName
+		r.Self = f.FunctionName.MetricType == settingsv1.MetricType_SELF
+	case f.GetFunctionNameRegex() != nil:
+		r.FunctionRegexp = regexp.MustCompile(anchoredRegexp(f.FunctionNameRegex.FunctionNameRegex))
+		r.Self = f.FunctionNameRegex.MetricType == settingsv1.MetricType_SELF
+	case f.GetCallSite() != nil:
+		for _, loc := range f.CallSite.StackTraceSelector.CallSite {
+			r.CallSite = append(r.CallSite, loc.Name)
+		}
+		r.Self = f.CallSite.MetricType == settingsv1.MetricType_SELF
+	}
+	return nil
+}
+
+// ValidateStacktraceFilter checks that at most one filter is set,
+// and the filter is valid.
+func ValidateStacktraceFilter(f *settingsv1.StacktraceFilter) error {
+	if f == nil {
+		return nil
+	}
+	var n int
+	if f.FunctionName != nil {
+		n++
+		if f.FunctionName.FunctionName == "" {
+			return fmt.Errorf("function name is empty")
+		}
+	}
+	if f.FunctionNameRegex != nil {
+		n++
+		if f.FunctionNameRegex.FunctionNameRegex == "" {
+			return fmt.Errorf("function name regex is empty")
+		}
+		if _, err := regexp.Compile(anchoredRegexp(f.FunctionNameRegex.FunctionNameRegex)); err != nil {
+			return fmt.Errorf("function name regex is invalid: %w", err)
+		}
+	}
+	if f.CallSite != nil {
+		n++
+		callSite := f.CallSite.StackTraceSelector.GetCallSite()
+		if len(callSite) == 0 {
+			return fmt.Errorf("call site is empty")
+		}
+		for _, loc := range callSite {
+			if loc.GetName() == "" {
+				return fmt.Errorf("call site location name is empty")
+			}
+		}
+		if f.CallSite.StackTraceSelector.GoPgo != nil {
+			return fmt.Errorf("go pgo selector is not supported")
+		}
+	}
+	if n > 1 {
+		return fmt.Errorf("only one of function name, function name regex, and call site can be set")
+	}
+	return nil
+}
+
+// anchoredRegexp makes the expression match the full string,
+// consistently with the label matchers.
+func anchoredRegexp(expr string) string {
+	return "^(?:" + expr + ")$"
+}
+
 func parseMatchers(matchers []string) ([]*labels.Matcher, error) {
 	parsed := make([]*labels.Matcher, 0, len(matchers))
 	for _, m := range matchers {
@@ -2,6 +2,7 @@ package model
 
 import (
 	"math"
+	"slices"
 	"sort"
 
 	"github.com/prometheus/common/model"
@@ -69,12 +70,28 @@ type TimeSeriesAggregator interface {
 	GetTimestamp() int64
 }
 
-func NewTimeSeriesAggregator(aggregation *typesv1.TimeSeriesAggregationType) TimeSeriesAggregator {
+// NewTimeSeriesAggregator creates an aggregator of the points that
+// belong to the same step. The step duration is in milliseconds and
+// is only used by the rate aggregation.
+func NewTimeSeriesAggregator(aggregation *typesv1.TimeSeriesAggregationType, step int64) TimeSeriesAggregator {
 	if aggregation == nil {
 		return &sumTimeSeriesAggregator{ts: -1}
 	}
-	if *aggregation == typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_AVERAGE {
+	switch *aggregation {
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_AVERAGE:
 		return &avgTimeSeriesAggregator{ts: -1}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MIN:
+		return &valuesTimeSeriesAggregator{ts: -1, fn: slices.Min[[]float64]}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MAX:
+		return &valuesTimeSeriesAggregator{ts: -1, fn: slices.Max[[]float64]}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P50:
+		return &valuesTimeSeriesAggregator{ts: -1, fn: quantile(0.5)}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P95:
+		return &valuesTimeSeriesAggregator{ts: -1, fn: quantile(0.95)}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99:
+		return &valuesTimeSeriesAggregator{ts: -1, fn: quantile(0.99)}
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE:
+		return &rateTimeSeriesAggregator{sumTimeSeriesAggregator: sumTimeSeriesAggregator{ts: -1}, step: step}
 	}
 	return &sumTimeSeriesAggregator{ts: -1}
 }
@@ -142,6 +159,71 @@ func (a *avgTimeSeriesAggregator) GetAndReset() *typesv1.Point {
 func (a *avgTimeSeriesAggregator) IsEmpty() bool       { return a.ts == -1 }
 func (a *avgTimeSeriesAggregator) GetTimestamp() int64 { return a.ts }
 
+// valuesTimeSeriesAggregator retains all the values within
+// the step and aggregates them with the function provided.
+type valuesTimeSeriesAggregator struct {
+	ts          int64
+	values      []float64
+	fn          func([]float64) float64
+	annotations []*typesv1.ProfileAnnotation
+}
+
+func (a *valuesTimeSeriesAggregator) Add(ts int64, point *TimeSeriesValue) {
+	a.ts = ts
+	a.values = append(a.values, point.Value)
+	a.annotations = append(a.annotations, point.Annotations...)
+}
+
+func (a *valuesTimeSeriesAggregator) GetAndReset() *typesv1.Point {
+	value := a.fn(a.values)
+	tsCopy := a.ts
+	annotationsCopy := make([]*typesv1.ProfileAnnotation, len(a.annotations))
+	copy(annotationsCopy, a.annotations)
+	a.ts = -1
+	a.values = a.values[:0]
+	a.annotations = a.annotations[:0]
+	return &typesv1.Point{
+		Timestamp:   tsCopy,
+		Value:       value,
+		Annotations: annotationsCopy,
+	}
+}
+
+func (a *valuesTimeSeriesAggregator) IsEmpty() bool       { return a.ts == -1 }
+func (a *valuesTimeSeriesAggregator) GetTimestamp() int64 { return a.ts }
+
+// quantile returns a function that calculates the φ-quantile of the
+// values, interpolating linearly between the two closest ranks. The
+// values slice is sorted in place.
+func quantile(q float64) func([]float64) float64 {
+	return func(values []float64) float64 {
+		if len(values) == 0 {
+			return math.NaN()
+		}
+		slices.Sort(values)
+		rank := q * float64(len(values)-1)
+		l := int(math.Floor(rank))
+		h := int(math.Ceil(rank))
+		w := rank - float64(l)
+		return values[l]*(1-w) + values[h]*w
+	}
+}
+
+// rateTimeSeriesAggregator sums the values within the step
+// and normalizes the sum to a per-second rate.
+type rateTimeSeriesAggregator struct {
+	sumTimeSeriesAggregator
+	step int64
+}
+
+func (a *rateTimeSeriesAggregator) GetAndReset() *typesv1.Point {
+	p := a.sumTimeSeriesAggregator.GetAndReset()
+	if a.step > 0 {
+		p.Value /= float64(a.step) / 1000
+	}
+	return p
+}
+
 // RangeSeries aggregates profiles into series.
 // Series contains points spaced by step from start to end.
 // Profiles from the same step are aggregated into one point.
@@ -161,7 +243,7 @@ Outer:
 			point := it.At()
 			aggregator, ok := aggregators[point.LabelsHash]
 			if !ok {
-				aggregator = NewTimeSeriesAggregator(aggregation)
+				aggregator = NewTimeSeriesAggregator(aggregation, step)
 				aggregators[point.LabelsHash] = aggregator
 			}
 			if point.Ts > currentStep {
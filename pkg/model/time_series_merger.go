@@ -10,18 +10,27 @@ import (
 )
 
 func MergeSeries(aggregation *typesv1.TimeSeriesAggregationType, series ...[]*typesv1.Series) []*typesv1.Series {
-	var m *TimeSeriesMerger
-	if aggregation == nil || *aggregation == typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM {
-		m = NewTimeSeriesMerger(true)
-	} else {
-		m = NewTimeSeriesMerger(false)
-	}
+	m := NewTimeSeriesMerger(IsSumAggregation(aggregation))
 	for _, s := range series {
 		m.MergeTimeSeries(s)
 	}
 	return m.TimeSeries()
 }
 
+// IsSumAggregation reports whether points of the series with matching
+// timestamps can be summed without affecting the aggregation result.
+func IsSumAggregation(aggregation *typesv1.TimeSeriesAggregationType) bool {
+	if aggregation == nil {
+		return true
+	}
+	switch *aggregation {
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM,
+		typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE:
+		return true
+	}
+	return false
+}
+
 // TopSeries returns the top k series by sum of values.
 // If k is zero, all series are returned.
 // Note that even if len(c) <= k or k == 0, the returned
@@ -3,6 +3,8 @@ package model
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/iter"
 	"github.com/grafana/pyroscope/pkg/testhelper"
@@ -154,3 +156,38 @@ func Test_RangeSeriesAvg(t *testing.T) {
 		})
 	}
 }
+
+func Test_RangeSeriesAggregations(t *testing.T) {
+	in := []TimeSeriesValue{
+		{Ts: 2000, Value: 4},
+		{Ts: 2000, Value: 1},
+		{Ts: 2000, Value: 3},
+		{Ts: 2000, Value: 2},
+		{Ts: 2000, Value: 5},
+		{Ts: 4000, Value: 10},
+	}
+	for _, tc := range []struct {
+		aggregation typesv1.TimeSeriesAggregationType
+		values      []float64
+	}{
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MIN, values: []float64{1, 10}},
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MAX, values: []float64{5, 10}},
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P50, values: []float64{3, 10}},
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P95, values: []float64{4.8, 10}},
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99, values: []float64{4.96, 10}},
+		// The step is 2s.
+		{aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE, values: []float64{7.5, 5}},
+	} {
+		t.Run(tc.aggregation.String(), func(t *testing.T) {
+			out := RangeSeries(iter.NewSliceIterator(in), 2000, 4000, 2000, &tc.aggregation)
+			if len(out) != 1 {
+				t.Fatalf("expected 1 series, got %d", len(out))
+			}
+			values := make([]float64, len(out[0].Points))
+			for i, p := range out[0].Points {
+				values[i] = p.Value
+			}
+			assert.InDeltaSlice(t, tc.values, values, 1e-9)
+		})
+	}
+}
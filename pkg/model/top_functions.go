@@ -0,0 +1,131 @@
+package model
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// TopFunctionsMerger aggregates self and total values of functions
+// across multiple trees or partial results. The merger is safe for
+// concurrent use.
+type TopFunctionsMerger struct {
+	mu        sync.Mutex
+	functions map[string]*typesv1.TopFunction
+	total     int64
+}
+
+func NewTopFunctionsMerger() *TopFunctionsMerger {
+	return &TopFunctionsMerger{functions: make(map[string]*typesv1.TopFunction)}
+}
+
+// MergeTree accounts all the functions present in the tree.
+//
+// The total value of a function is only accounted once per stack
+// trace: a recursive call does not contribute to the function total
+// value if the function is already present in the call chain.
+func (m *TopFunctionsMerger) MergeTree(t *Tree) {
+	if t == nil || len(t.root) == 0 {
+		return
+	}
+	type frame struct {
+		n    *node
+		exit bool
+	}
+	functions := make(map[string]*typesv1.TopFunction)
+	onStack := make(map[string]int)
+	nodes := make([]frame, 0, defaultDFSSize)
+	for _, n := range t.root {
+		nodes = append(nodes, frame{n: n})
+	}
+	var f frame
+	for len(nodes) > 0 {
+		f, nodes = nodes[len(nodes)-1], nodes[:len(nodes)-1]
+		if f.exit {
+			onStack[f.n.name]--
+			continue
+		}
+		fn, ok := functions[f.n.name]
+		if !ok {
+			fn = &typesv1.TopFunction{Name: f.n.name}
+			functions[f.n.name] = fn
+		}
+		fn.Self += f.n.self
+		if onStack[f.n.name] == 0 {
+			fn.Total += f.n.total
+		}
+		onStack[f.n.name]++
+		nodes = append(nodes, frame{n: f.n, exit: true})
+		for _, c := range f.n.children {
+			nodes = append(nodes, frame{n: c})
+		}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.total += t.Total()
+	for name, fn := range functions {
+		m.merge(name, fn)
+	}
+}
+
+// MergeTopFunctions merges a partial result: functions are
+// expected to be unique within the slice.
+func (m *TopFunctionsMerger) MergeTopFunctions(total int64, functions []*typesv1.TopFunction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.total += total
+	for _, fn := range functions {
+		m.merge(fn.Name, fn)
+	}
+}
+
+func (m *TopFunctionsMerger) merge(name string, fn *typesv1.TopFunction) {
+	d, ok := m.functions[name]
+	if !ok {
+		m.functions[name] = fn.CloneVT()
+		return
+	}
+	d.Self += fn.Self
+	d.Total += fn.Total
+}
+
+// Total returns the sum of the values of all the stack traces merged.
+func (m *TopFunctionsMerger) Total() int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.total
+}
+
+// Top returns the top k functions by self value. If k is zero,
+// all the functions are returned. Functions with equal self
+// values are ordered by total value and then by name.
+func (m *TopFunctionsMerger) Top(k int) []*typesv1.TopFunction {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	functions := make([]*typesv1.TopFunction, 0, len(m.functions))
+	for _, fn := range m.functions {
+		functions = append(functions, fn)
+	}
+	return TopFunctions(functions, k)
+}
+
+// TopFunctions sorts the functions in place by self value in descending
+// order and returns the first k functions. If k is zero, all the functions
+// are returned.
+func TopFunctions(functions []*typesv1.TopFunction, k int) []*typesv1.TopFunction {
+	slices.SortFunc(functions, func(a, b *typesv1.TopFunction) int {
+		if c := cmp.Compare(b.Self, a.Self); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Total, a.Total); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	if k > 0 && len(functions) > k {
+		return functions[:k]
+	}
+	return functions
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func Test_TopFunctionsMerger(t *testing.T) {
+	a := new(Tree)
+	a.InsertStack(1, "main", "foo", "bar")
+	a.InsertStack(2, "main", "foo")
+	// Recursive calls are accounted once.
+	a.InsertStack(3, "main", "baz", "baz", "baz")
+
+	b := new(Tree)
+	b.InsertStack(4, "main", "bar")
+
+	m := NewTopFunctionsMerger()
+	m.MergeTree(a)
+	m.MergeTree(b)
+	m.MergeTopFunctions(5, []*typesv1.TopFunction{{Name: "qux", Self: 5, Total: 5}})
+
+	assert.Equal(t, int64(15), m.Total())
+	expected := []*typesv1.TopFunction{
+		{Name: "bar", Self: 5, Total: 5},
+		{Name: "qux", Self: 5, Total: 5},
+		{Name: "baz", Self: 3, Total: 3},
+		{Name: "foo", Self: 2, Total: 3},
+		{Name: "main", Self: 0, Total: 10},
+	}
+	assert.Equal(t, expected, m.Top(0))
+	assert.Equal(t, expected[:2], m.Top(2))
+}
+
+func Test_TopFunctionsMerger_Empty(t *testing.T) {
+	m := NewTopFunctionsMerger()
+	m.MergeTree(new(Tree))
+	assert.Zero(t, m.Total())
+	assert.Empty(t, m.Top(10))
+}
@@ -453,6 +453,27 @@ func TreeFromBackendProfile(profile *profilev1.Profile, maxNodes int64) ([]byte,
 
 // TreeFromBackendProfileSampleType converts a pprof profile to a tree format with maxNodes limit
 func TreeFromBackendProfileSampleType(profile *profilev1.Profile, maxNodes int64, sampleType int) ([]byte, error) {
+	t, err := stacktraceTreeFromBackendProfile(profile, maxNodes, sampleType)
+	if err != nil {
+		return nil, err
+	}
+	b := bytes.NewBuffer(nil)
+	b.Grow(100 << 10)
+	t.Bytes(b, maxNodes, profile.StringTable)
+	return b.Bytes(), nil
+}
+
+// NewTreeFromBackendProfile converts the first sample type of
+// a pprof profile to a tree. The tree is not truncated.
+func NewTreeFromBackendProfile(profile *profilev1.Profile) (*Tree, error) {
+	t, err := stacktraceTreeFromBackendProfile(profile, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	return t.Tree(0, profile.StringTable), nil
+}
+
+func stacktraceTreeFromBackendProfile(profile *profilev1.Profile, maxNodes int64, sampleType int) (*StacktraceTree, error) {
 	t := NewStacktraceTree(int(maxNodes * 2))
 	stack := make([]int32, 0, 64)
 	m := make(map[uint64]int32)
@@ -488,8 +509,5 @@ func TreeFromBackendProfileSampleType(profile *profilev1.Profile, maxNodes int64
 		t.Insert(stack, profile.Sample[i].Value[sampleType])
 	}
 
-	b := bytes.NewBuffer(nil)
-	b.Grow(100 << 10)
-	t.Bytes(b, maxNodes, profile.StringTable)
-	return b.Bytes(), nil
+	return t, nil
 }
@@ -0,0 +1,423 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/grafana/dskit/flagext"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CachingBucketConfig configures caching of the object reads.
+type CachingBucketConfig struct {
+	Enabled bool                   `yaml:"enabled" category:"experimental"`
+	Memory  MemoryCacheConfig      `yaml:"memory"`
+	Disk    DiskCacheConfig        `yaml:"disk"`
+	TTL     flagext.StringSliceCSV `yaml:"ttl" category:"experimental"`
+}
+
+type MemoryCacheConfig struct {
+	MaxSizeBytes int64 `yaml:"max_size_bytes" category:"experimental"`
+	MaxRangeSize int64 `yaml:"max_range_size" category:"experimental"`
+}
+
+type DiskCacheConfig struct {
+	Path         string `yaml:"path" category:"experimental"`
+	MaxSizeBytes int64  `yaml:"max_size_bytes" category:"experimental"`
+}
+
+// DefaultCacheTTL lists the immutable objects cached by default:
+// v2 blocks and segments, and the files of v1 blocks.
+var DefaultCacheTTL = flagext.StringSliceCSV{
+	`^(blocks|segments)/=24h`,
+	`^[^/]+/phlaredb/[0-9A-Z]{26}/=24h`,
+}
+
+func (cfg *CachingBucketConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.BoolVar(&cfg.Enabled, prefix+"enabled", false, "Enable caching of the object storage reads by the query backend, store-gateway and compaction worker.")
+	f.Int64Var(&cfg.Memory.MaxSizeBytes, prefix+"memory.max-size-bytes", 256<<20, "Maximum size of the in-memory cache, in bytes. If set to 0, the in-memory cache is disabled.")
+	f.Int64Var(&cfg.Memory.MaxRangeSize, prefix+"memory.max-range-size", 64<<10, "Ranges not larger than this size are cached in memory, larger ranges and whole objects are cached on disk.")
+	f.StringVar(&cfg.Disk.Path, prefix+"disk.path", "", "Directory of the on-disk cache. The directory is cleared on start. If empty, the on-disk cache is disabled.")
+	f.Int64Var(&cfg.Disk.MaxSizeBytes, prefix+"disk.max-size-bytes", 10<<30, "Maximum size of the on-disk cache, in bytes.")
+	cfg.TTL = append(flagext.StringSliceCSV{}, DefaultCacheTTL...)
+	f.Var(&cfg.TTL, prefix+"ttl", "Comma-separated list of pattern=ttl pairs: objects with paths matching the regular expression are cached for the given duration. The first matching pattern applies; other objects are not cached. Only immutable objects must be cached.")
+}
+
+func (cfg *CachingBucketConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Disk.Path != "" && cfg.Disk.MaxSizeBytes <= 0 {
+		return errors.New("on-disk cache size must be positive")
+	}
+	_, err := parseCacheTTL(cfg.TTL)
+	return err
+}
+
+type cacheTTLRule struct {
+	pattern *regexp.Regexp
+	ttl     time.Duration
+}
+
+func parseCacheTTL(rules []string) ([]cacheTTLRule, error) {
+	parsed := make([]cacheTTLRule, 0, len(rules))
+	for _, r := range rules {
+		i := strings.LastIndex(r, "=")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid cache ttl rule %q: expected pattern=ttl", r)
+		}
+		pattern, err := regexp.Compile(r[:i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache ttl rule %q: %w", r, err)
+		}
+		ttl, err := time.ParseDuration(r[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache ttl rule %q: %w", r, err)
+		}
+		parsed = append(parsed, cacheTTLRule{pattern: pattern, ttl: ttl})
+	}
+	return parsed, nil
+}
+
+// CachingBucket caches reads of immutable objects. Small ranges, such as
+// metadata, TSDB index and symbol headers, are cached in memory. Larger
+// ranges and whole objects are cached on the local disk.
+//
+// Cached entries are not invalidated when the object is overwritten,
+// and only whole objects are invalidated on deletion, therefore only
+// immutable objects should be cached.
+type CachingBucket struct {
+	Bucket
+
+	rules        []cacheTTLRule
+	memory       *ristretto.Cache[string, []byte]
+	maxRangeSize int64
+	disk         *diskCache
+	metrics      *cachingBucketMetrics
+}
+
+func NewCachingBucket(bkt Bucket, cfg CachingBucketConfig, reg prometheus.Registerer) (*CachingBucket, error) {
+	rules, err := parseCacheTTL(cfg.TTL)
+	if err != nil {
+		return nil, err
+	}
+	b := &CachingBucket{
+		Bucket:       bkt,
+		rules:        rules,
+		maxRangeSize: cfg.Memory.MaxRangeSize,
+		metrics:      newCachingBucketMetrics(reg),
+	}
+	if cfg.Memory.MaxSizeBytes > 0 {
+		b.memory, err = ristretto.NewCache(&ristretto.Config[string, []byte]{
+			// Assuming the average range size is 1KiB.
+			NumCounters: max(cfg.Memory.MaxSizeBytes>>10, 1<<10) * 10,
+			MaxCost:     cfg.Memory.MaxSizeBytes,
+			BufferItems: 64,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create in-memory cache: %w", err)
+		}
+	}
+	if cfg.Disk.Path != "" {
+		if b.disk, err = newDiskCache(cfg.Disk.Path, cfg.Disk.MaxSizeBytes); err != nil {
+			return nil, fmt.Errorf("failed to create on-disk cache: %w", err)
+		}
+	}
+	return b, nil
+}
+
+// ttl returns the caching TTL of the object, or zero,
+// if the object should not be cached.
+func (b *CachingBucket) ttl(name string) time.Duration {
+	for _, r := range b.rules {
+		if r.pattern.MatchString(name) {
+			return r.ttl
+		}
+	}
+	return 0
+}
+
+func (b *CachingBucket) GetRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error) {
+	ttl := b.ttl(name)
+	if ttl <= 0 || off < 0 || length <= 0 {
+		return b.Bucket.GetRange(ctx, name, off, length)
+	}
+	key := name + ":" + strconv.FormatInt(off, 10) + ":" + strconv.FormatInt(length, 10)
+	if b.memory != nil && length <= b.maxRangeSize {
+		return b.getMemory(key, ttl, func() (io.ReadCloser, error) {
+			return b.Bucket.GetRange(ctx, name, off, length)
+		})
+	}
+	if b.disk != nil {
+		return b.getDisk(key, length, ttl, func() (io.ReadCloser, error) {
+			return b.Bucket.GetRange(ctx, name, off, length)
+		})
+	}
+	return b.Bucket.GetRange(ctx, name, off, length)
+}
+
+func (b *CachingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	ttl := b.ttl(name)
+	if ttl <= 0 || b.disk == nil {
+		return b.Bucket.Get(ctx, name)
+	}
+	return b.getDisk(name, 0, ttl, func() (io.ReadCloser, error) {
+		return b.Bucket.Get(ctx, name)
+	})
+}
+
+func (b *CachingBucket) ReaderAt(ctx context.Context, name string) (ReaderAtCloser, error) {
+	return &ReaderAt{
+		GetRangeReader: b,
+		name:           name,
+		ctx:            ctx,
+	}, nil
+}
+
+func (b *CachingBucket) Delete(ctx context.Context, name string) error {
+	if b.disk != nil {
+		b.disk.remove(name)
+	}
+	return b.Bucket.Delete(ctx, name)
+}
+
+func (b *CachingBucket) Close() error {
+	if b.memory != nil {
+		b.memory.Close()
+	}
+	return b.Bucket.Close()
+}
+
+// ReaderWithExpectedErrs implements objstore.Bucket.
+func (b *CachingBucket) ReaderWithExpectedErrs(fn IsOpFailureExpectedFunc) BucketReader {
+	return b.WithExpectedErrs(fn)
+}
+
+// WithExpectedErrs implements objstore.Bucket.
+func (b *CachingBucket) WithExpectedErrs(fn IsOpFailureExpectedFunc) Bucket {
+	if ib, ok := b.Bucket.(InstrumentedBucket); ok {
+		c := *b
+		c.Bucket = ib.WithExpectedErrs(fn)
+		return &c
+	}
+	return b
+}
+
+func (b *CachingBucket) getMemory(key string, ttl time.Duration, get func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if data, ok := b.memory.Get(key); ok {
+		b.metrics.requests.WithLabelValues("memory", "hit").Inc()
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	b.metrics.requests.WithLabelValues("memory", "miss").Inc()
+	rc, err := get()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		return nil, err
+	}
+	b.memory.SetWithTTL(key, data, int64(len(data)), ttl)
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// getDisk returns the reader of the cached file. If the file is not
+// cached, the object is read, and cached once the reader has read the
+// expected number of bytes or reached the end of the object.
+func (b *CachingBucket) getDisk(key string, expected int64, ttl time.Duration, get func() (io.ReadCloser, error)) (io.ReadCloser, error) {
+	if f, ok := b.disk.get(key); ok {
+		b.metrics.requests.WithLabelValues("disk", "hit").Inc()
+		return f, nil
+	}
+	b.metrics.requests.WithLabelValues("disk", "miss").Inc()
+	rc, err := get()
+	if err != nil {
+		return nil, err
+	}
+	f, err := b.disk.create()
+	if err != nil {
+		// The object is read without caching.
+		return rc, nil
+	}
+	return &cachingReader{
+		ReadCloser: rc,
+		file:       f,
+		expected:   expected,
+		commit: func(f *os.File, size int64) {
+			b.disk.commit(key, f, size, ttl)
+		},
+	}, nil
+}
+
+// cachingReader writes the data read to the file, which is committed
+// to the cache, if the reader is exhausted, or the expected number of
+// bytes has been read.
+type cachingReader struct {
+	io.ReadCloser
+	file     *os.File
+	size     int64
+	expected int64
+	eof      bool
+	failed   bool
+	commit   func(*os.File, int64)
+}
+
+func (r *cachingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 && !r.failed {
+		if _, werr := r.file.Write(p[:n]); werr != nil {
+			r.failed = true
+		}
+		r.size += int64(n)
+	}
+	if errors.Is(err, io.EOF) {
+		r.eof = true
+	}
+	return n, err
+}
+
+func (r *cachingReader) Close() error {
+	err := r.ReadCloser.Close()
+	complete := r.eof || (r.expected > 0 && r.size == r.expected)
+	if complete && !r.failed {
+		r.commit(r.file, r.size)
+	} else {
+		_ = r.file.Close()
+		_ = os.Remove(r.file.Name())
+	}
+	return err
+}
+
+// diskCache is a size-bounded LRU cache of files in a local directory.
+type diskCache struct {
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	size    int64
+	entries *simplelru.LRU[string, diskCacheEntry]
+}
+
+type diskCacheEntry struct {
+	path    string
+	size    int64
+	expires time.Time
+}
+
+func newDiskCache(dir string, maxSize int64) (*diskCache, error) {
+	// The cache index is kept in memory: the entries
+	// left from the previous run are not tracked.
+	if err := os.RemoveAll(dir); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	c := &diskCache{dir: dir, maxSize: maxSize}
+	var err error
+	c.entries, err = simplelru.NewLRU[string, diskCacheEntry](math.MaxInt, c.evicted)
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// evicted is called with the lock held.
+func (c *diskCache) evicted(_ string, e diskCacheEntry) {
+	c.size -= e.size
+	_ = os.Remove(e.path)
+}
+
+func (c *diskCache) get(key string) (*os.File, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	// The file remains readable after removal
+	// from the cache, until it is closed.
+	f, err := os.Open(e.path)
+	if err != nil {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return f, true
+}
+
+func (c *diskCache) create() (*os.File, error) {
+	return os.CreateTemp(c.dir, "tmp-")
+}
+
+func (c *diskCache) commit(key string, f *os.File, size int64, ttl time.Duration) {
+	tmp := f.Name()
+	if err := f.Close(); err != nil || size > c.maxSize {
+		_ = os.Remove(tmp)
+		return
+	}
+	sum := sha256.Sum256([]byte(key))
+	path := filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return
+	}
+	if e, ok := c.entries.Peek(key); ok {
+		// The file has been replaced.
+		c.size -= e.size
+	}
+	c.entries.Add(key, diskCacheEntry{path: path, size: size, expires: time.Now().Add(ttl)})
+	c.size += size
+	for c.size > c.maxSize {
+		c.entries.RemoveOldest()
+	}
+}
+
+func (c *diskCache) remove(key string) {
+	c.mu.Lock()
+	c.entries.Remove(key)
+	c.mu.Unlock()
+}
+
+type cachingBucketMetrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newCachingBucketMetrics(reg prometheus.Registerer) *cachingBucketMetrics {
+	m := &cachingBucketMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "objstore_cache",
+			Name:      "requests_total",
+			Help:      "Total number of object reads served by the cache tier, by result (hit or miss).",
+		}, []string{"tier", "result"}),
+	}
+	if reg != nil {
+		reg.MustRegister(m.requests)
+	}
+	return m
+}
@@ -0,0 +1,121 @@
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+
+	"github.com/grafana/dskit/flagext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+)
+
+type countingBucket struct {
+	Bucket
+	reads atomic.Int64
+}
+
+func (b *countingBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	b.reads.Add(1)
+	return b.Bucket.Get(ctx, name)
+}
+
+func (b *countingBucket) GetRange(ctx context.Context, name string, off, length int64) (io.ReadCloser, error) {
+	b.reads.Add(1)
+	return b.Bucket.GetRange(ctx, name, off, length)
+}
+
+func newTestCachingBucket(t *testing.T) (*CachingBucket, *countingBucket) {
+	t.Helper()
+	storage := &countingBucket{Bucket: NewBucket(memory.NewInMemBucket())}
+	ctx := context.Background()
+	require.NoError(t, storage.Upload(ctx, "blocks/a", bytes.NewReader([]byte("0123456789"))))
+	require.NoError(t, storage.Upload(ctx, "mutable/a", bytes.NewReader([]byte("0123456789"))))
+	b, err := NewCachingBucket(storage, CachingBucketConfig{
+		Enabled: true,
+		Memory: MemoryCacheConfig{
+			MaxSizeBytes: 1 << 20,
+			MaxRangeSize: 4,
+		},
+		Disk: DiskCacheConfig{
+			Path:         t.TempDir(),
+			MaxSizeBytes: 16,
+		},
+		TTL: flagext.StringSliceCSV{"^blocks/=1h"},
+	}, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = b.Close() })
+	return b, storage
+}
+
+func readAll(t *testing.T, rc io.ReadCloser, err error) string {
+	t.Helper()
+	require.NoError(t, err)
+	b, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	return string(b)
+}
+
+func Test_CachingBucket_GetRange(t *testing.T) {
+	b, storage := newTestCachingBucket(t)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name   string
+		off    int64
+		length int64
+		data   string
+		reads  int64
+	}{
+		// Served from memory.
+		{name: "blocks/a", off: 1, length: 2, data: "12", reads: 1},
+		// Served from disk.
+		{name: "blocks/a", off: 2, length: 8, data: "23456789", reads: 1},
+		// Not cached.
+		{name: "mutable/a", off: 1, length: 2, data: "12", reads: 2},
+	} {
+		before := storage.reads.Load()
+		for i := 0; i < 2; i++ {
+			rc, err := b.GetRange(ctx, tc.name, tc.off, tc.length)
+			assert.Equal(t, tc.data, readAll(t, rc, err))
+			b.memory.Wait()
+		}
+		assert.Equal(t, tc.reads, storage.reads.Load()-before, tc)
+	}
+}
+
+func Test_CachingBucket_Get(t *testing.T) {
+	b, storage := newTestCachingBucket(t)
+	ctx := context.Background()
+
+	rc, err := b.Get(ctx, "blocks/a")
+	assert.Equal(t, "0123456789", readAll(t, rc, err))
+	rc, err = b.Get(ctx, "blocks/a")
+	assert.Equal(t, "0123456789", readAll(t, rc, err))
+	assert.Equal(t, int64(1), storage.reads.Load())
+
+	// Partially read objects are not cached.
+	rc, err = b.GetRange(ctx, "blocks/a", 0, 8)
+	require.NoError(t, err)
+	_, err = rc.Read(make([]byte, 2))
+	require.NoError(t, err)
+	require.NoError(t, rc.Close())
+	rc, err = b.GetRange(ctx, "blocks/a", 0, 8)
+	assert.Equal(t, "01234567", readAll(t, rc, err))
+	assert.Equal(t, int64(3), storage.reads.Load())
+
+	// The cache size is exceeded: the least recently used object is evicted.
+	rc, err = b.Get(ctx, "blocks/a")
+	assert.Equal(t, "0123456789", readAll(t, rc, err))
+	assert.Equal(t, int64(4), storage.reads.Load())
+
+	// Deleted objects are removed from the cache.
+	require.NoError(t, b.Delete(ctx, "blocks/a"))
+	_, err = b.Get(ctx, "blocks/a")
+	assert.True(t, b.IsObjNotFoundErr(err))
+}
@@ -2173,7 +2173,8 @@ func (b *singleBlockQuerier) downsampleResolutions() []time.Duration {
 
 func downsampleAggregation(v typesv1.TimeSeriesAggregationType) string {
 	switch v {
-	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM:
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM,
+		typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE:
 		return "sum"
 	}
 	return ""
@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dolthub/swiss"
 	"github.com/go-kit/log"
@@ -160,6 +161,25 @@ func NewDownsampler(path string, logger log.Logger) (*Downsampler, error) {
 	}, nil
 }
 
+// Output describes a downsampled profile table.
+type Output struct {
+	Path       string
+	Resolution time.Duration
+}
+
+// Outputs returns the downsampled profile tables in the order of increasing
+// resolution. The files are complete only after the downsampler is closed.
+func (d *Downsampler) Outputs() []Output {
+	outputs := make([]Output, len(configs))
+	for i, c := range configs {
+		outputs[i] = Output{
+			Path:       d.profileWriters[i].file.Name(),
+			Resolution: time.Duration(c.interval.durationSeconds) * time.Second,
+		}
+	}
+	return outputs
+}
+
 func (d *Downsampler) flush(s *state, w *profilesWriter, c downsampleConfig) error {
 	level.Debug(d.logger).Log(
 		"msg", "flushing downsampled profile",
@@ -287,6 +307,9 @@ func (d *Downsampler) Close() error {
 		if err != nil {
 			return err
 		}
+		if err = d.profileWriters[i].file.Close(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -214,6 +214,9 @@ func mergeByLabels[T Profile](
 	return seriesBuilder.Build(), profiles.Err()
 }
 
+// mergeByLabelsWithStackTraceSelector builds the time series of the call
+// site values. Stack trace transformations are not applied: the querier
+// rejects time series queries that specify them.
 func mergeByLabelsWithStackTraceSelector[T Profile](
 	ctx context.Context,
 	profileSource Source,
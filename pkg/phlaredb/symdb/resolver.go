@@ -61,6 +61,9 @@ func WithResolverMaxNodes(n int64) ResolverOption {
 // Only stack traces that belong to the callSite (have the prefix provided)
 // will be selected. If empty, the filter is ignored.
 // Subtree root location is the last element.
+//
+// The focus, ignore, hide, show, and prune recursion transformations
+// are applied to the stack traces before truncation.
 func WithResolverStackTraceSelector(sts *typesv1.StackTraceSelector) ResolverOption {
 	return func(r *Resolver) {
 		r.sts = sts
@@ -232,10 +235,17 @@ func (r *Resolver) partition(partition uint64) *lazyPartition {
 func (r *Resolver) Tree() (*model.Tree, error) {
 	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.Tree")
 	defer span.Finish()
+	if err := ValidateStackTraceSelector(r.sts); err != nil {
+		return nil, err
+	}
 	var lock sync.Mutex
 	tree := new(model.Tree)
 	err := r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
-		resolved, err := symbols.Tree(ctx, appender, r.maxNodes)
+		var selection *SelectedStackTraces
+		if r.sts != nil {
+			selection = SelectStackTraces(symbols, r.sts)
+		}
+		resolved, err := symbols.Tree(ctx, appender, r.maxNodes, selection)
 		if err != nil {
 			return err
 		}
@@ -250,6 +260,9 @@ func (r *Resolver) Tree() (*model.Tree, error) {
 func (r *Resolver) Pprof() (*googlev1.Profile, error) {
 	span, ctx := opentracing.StartSpanFromContext(r.ctx, "Resolver.Pprof")
 	defer span.Finish()
+	if err := ValidateStackTraceSelector(r.sts); err != nil {
+		return nil, err
+	}
 	var p pprof.ProfileMerge
 	err := r.withSymbols(ctx, func(symbols *Symbols, appender *SampleAppender) error {
 		resolved, err := symbols.Pprof(ctx, appender, r.maxNodes, SelectStackTraces(symbols, r.sts))
@@ -292,6 +305,7 @@ func (r *Symbols) Tree(
 	ctx context.Context,
 	appender *SampleAppender,
 	maxNodes int64,
+	selection *SelectedStackTraces,
 ) (*model.Tree, error) {
-	return buildTree(ctx, r, appender, maxNodes)
+	return buildTree(ctx, r, appender, maxNodes, selection)
 }
@@ -38,7 +38,9 @@ func buildPprof(
 	// Truncation is applicable when there is an explicit
 	// limit on the number of the nodes in the profile, or
 	// if stack traces should be filtered by the call site.
-	case maxNodes > 0 || len(selection.callSite) > 0:
+	// Stack trace transformations, such as focus or hide,
+	// must be applied before truncation.
+	case maxNodes > 0 || len(selection.callSite) > 0 || selection.hasTransform():
 		b = &pprofTree{maxNodes: maxNodes, selection: selection}
 	}
 	b.init(symbols, samples)
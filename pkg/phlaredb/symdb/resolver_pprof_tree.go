@@ -30,6 +30,9 @@ type pprofTree struct {
 	// stacks simultaneously.
 	functionsBuf []int32
 	locationsBuf []uint64
+	// Transformed stack trace, if the selection specifies
+	// stack trace transformations.
+	stackBuf []int32
 
 	selection *SelectedStackTraces
 	fnNames   func(locations []int32) ([]int32, bool)
@@ -67,6 +70,13 @@ func (r *pprofTree) init(symbols *Symbols, samples schemav1.Samples) {
 func (r *pprofTree) InsertStacktrace(stacktraceID uint32, locations []int32) {
 	value := int64(r.samples.Values[r.cur])
 	r.cur++
+	if r.selection.hasTransform() {
+		var ok bool
+		if r.stackBuf, ok = transformStackTrace(r.selection, r.stackBuf, locations); !ok {
+			return
+		}
+		locations = r.stackBuf
+	}
 	functions, ok := r.fnNames(locations)
 	if ok {
 		functionNodeIdx := r.functionTree.Insert(functions, value)
@@ -156,6 +166,11 @@ func (r *pprofTree) addSample(n truncatedStacktraceSample) {
 		return
 	}
 	r.locationsBuf = r.symbols.Stacktraces.LookupLocations(r.locationsBuf, n.stacktraceID)
+	if r.selection.hasTransform() {
+		// The stack trace has been selected, therefore the
+		// transformation is to be applied in the same way.
+		r.locationsBuf, _ = transformStackTrace(r.selection, r.locationsBuf, r.locationsBuf)
+	}
 	if off > 0 {
 		// Some functions were truncated.
 		r.locationsBuf = truncateLocations(r.locationsBuf, r.functionsBuf, off, r.symbols)
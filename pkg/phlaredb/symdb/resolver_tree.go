@@ -18,15 +18,21 @@ func buildTree(
 	symbols *Symbols,
 	appender *SampleAppender,
 	maxNodes int64,
+	selection *SelectedStackTraces,
 ) (*model.Tree, error) {
+	if selection != nil && !selection.HasValidCallSite() {
+		return new(model.Tree), nil
+	}
 	// If the number of samples is large (> 128K) and the StacktraceResolver
 	// implements the range iterator, we will be building the tree based on
 	// the parent pointer tree of the partition (a copy of). The only exception
 	// is when the number of nodes is not limited, or is close to the number of
 	// nodes in the original tree: the optimization is still beneficial in terms
-	// of CPU, but is very expensive in terms of memory.
+	// of CPU, but is very expensive in terms of memory. The stack traces are
+	// taken as is, therefore the optimization is not applicable if they are
+	// to be filtered or transformed.
 	iterator, ok := symbols.Stacktraces.(StacktraceIDRangeIterator)
-	if ok && shouldCopyTree(appender, maxNodes) {
+	if ok && shouldCopyTree(appender, maxNodes) && !selection.hasStackTraceFilter() {
 		ranges := iterator.SplitStacktraceIDRanges(appender)
 		return buildTreeFromParentPointerTrees(ctx, ranges, symbols, maxNodes)
 	}
@@ -36,7 +42,7 @@ func buildTree(
 	samples := appender.Samples()
 	t := treeSymbolsFromPool()
 	defer t.reset()
-	t.init(symbols, samples)
+	t.init(symbols, samples, selection)
 	if err := symbols.Stacktraces.ResolveStacktraceLocations(ctx, t, samples.StacktraceIDs); err != nil {
 		return nil, err
 	}
@@ -50,11 +56,13 @@ func shouldCopyTree(appender *SampleAppender, maxNodes int64) bool {
 }
 
 type treeSymbols struct {
-	symbols *Symbols
-	samples *schemav1.Samples
-	tree    *model.StacktraceTree
-	lines   []int32
-	cur     int
+	symbols   *Symbols
+	samples   *schemav1.Samples
+	selection *SelectedStackTraces
+	tree      *model.StacktraceTree
+	stack     []int32
+	lines     []int32
+	cur       int
 }
 
 var treeSymbolsPool = sync.Pool{
@@ -68,15 +76,18 @@ func treeSymbolsFromPool() *treeSymbols {
 func (r *treeSymbols) reset() {
 	r.symbols = nil
 	r.samples = nil
+	r.selection = nil
 	r.tree.Reset()
+	r.stack = r.stack[:0]
 	r.lines = r.lines[:0]
 	r.cur = 0
 	treeSymbolsPool.Put(r)
 }
 
-func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples) {
+func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples, selection *SelectedStackTraces) {
 	r.symbols = symbols
 	r.samples = &samples
+	r.selection = selection
 	if r.tree == nil {
 		// Branching factor.
 		r.tree = model.NewStacktraceTree(samples.Len() * 2)
@@ -84,6 +95,15 @@ func (r *treeSymbols) init(symbols *Symbols, samples schemav1.Samples) {
 }
 
 func (r *treeSymbols) InsertStacktrace(_ uint32, locations []int32) {
+	value := int64(r.samples.Values[r.cur])
+	r.cur++
+	if r.selection.hasTransform() {
+		var ok bool
+		if r.stack, ok = transformStackTrace(r.selection, r.stack, locations); !ok {
+			return
+		}
+		locations = r.stack
+	}
 	r.lines = r.lines[:0]
 	for i := 0; i < len(locations); i++ {
 		lines := r.symbols.Locations[locations[i]].Line
@@ -92,8 +112,10 @@ func (r *treeSymbols) InsertStacktrace(_ uint32, locations []int32) {
 			r.lines = append(r.lines, int32(f.Name))
 		}
 	}
-	r.tree.Insert(r.lines, int64(r.samples.Values[r.cur]))
-	r.cur++
+	if r.selection != nil && !r.selection.hasCallSitePrefix(r.lines) {
+		return
+	}
+	r.tree.Insert(r.lines, value)
 }
 
 func buildTreeFromParentPointerTrees(
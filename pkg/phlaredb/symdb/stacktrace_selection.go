@@ -56,6 +56,11 @@ type SelectedStackTraces struct {
 	// strings[functions[id].Name] path. Instead, the
 	// name can be resolved directly funcNames[id].
 	funcNames []string
+	// Stack trace transformations: focus, ignore, hide, show,
+	// and prune recursion. Nil, if not specified.
+	transform *stackTraceTransform
+	locations []locationFlags // Location ID => flags.
+	invalid   bool
 }
 
 func SelectStackTraces(symbols *Symbols, selector *typesv1.StackTraceSelector) *SelectedStackTraces {
@@ -72,15 +77,46 @@ func SelectStackTraces(symbols *Symbols, selector *typesv1.StackTraceSelector) *
 	for i, f := range symbols.Functions {
 		x.funcNames[i] = symbols.Strings[f.Name]
 	}
+	// If the selector is invalid, nothing is selected:
+	// it must be validated with ValidateStackTraceSelector.
+	var err error
+	if x.transform, err = newStackTraceTransform(selector); err != nil {
+		x.invalid = true
+	} else if x.transform != nil {
+		x.locations = x.transform.locationFlags(symbols, x.funcNames)
+	}
 	return x
 }
 
 // HasValidCallSite reports whether any stack traces match the selector.
 // An empty selector results in a valid empty selection.
 func (x *SelectedStackTraces) HasValidCallSite() bool {
+	if x.invalid {
+		return false
+	}
 	return len(x.callSiteSelector) == 0 || len(x.callSiteSelector) != 0 && len(x.callSite) != 0
 }
 
+// hasStackTraceFilter reports whether stack traces of the
+// selection are to be filtered by the call site or transformed.
+func (x *SelectedStackTraces) hasStackTraceFilter() bool {
+	return x != nil && (x.depth > 0 || x.transform != nil)
+}
+
+// hasCallSitePrefix reports whether the stack trace of function
+// names (leaf first) belongs to the call site of the selection.
+func (x *SelectedStackTraces) hasCallSitePrefix(names []int32) bool {
+	if int(x.depth) > len(names) {
+		return false
+	}
+	for i, name := range x.callSite {
+		if x.symbols.Strings[names[len(names)-1-i]] != name {
+			return false
+		}
+	}
+	return true
+}
+
 // CallSiteValues writes the call site statistics for
 // the selected stack traces and the given set of samples.
 func (x *SelectedStackTraces) CallSiteValues(values *CallSiteValues, samples schemav1.Samples) {
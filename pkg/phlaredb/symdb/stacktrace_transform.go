@@ -0,0 +1,169 @@
+package symdb
+
+import (
+	"fmt"
+	"regexp"
+
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+// stackTraceTransform represents the focus, ignore, hide, show, and
+// prune recursion options of the stack trace selector.
+type stackTraceTransform struct {
+	focus          *regexp.Regexp
+	ignore         *regexp.Regexp
+	hide           *regexp.Regexp
+	show           *regexp.Regexp
+	pruneRecursion bool
+}
+
+// ValidateStackTraceSelector reports whether the regular
+// expressions of the stack trace selector are valid.
+func ValidateStackTraceSelector(selector *typesv1.StackTraceSelector) error {
+	_, err := newStackTraceTransform(selector)
+	return err
+}
+
+// HasStackTraceTransform reports whether the stack trace selector
+// specifies any of the focus, ignore, hide, show, and prune recursion
+// options.
+func HasStackTraceTransform(selector *typesv1.StackTraceSelector) bool {
+	return selector.GetFocus() != "" ||
+		selector.GetIgnore() != "" ||
+		selector.GetHide() != "" ||
+		selector.GetShow() != "" ||
+		selector.GetPruneRecursion()
+}
+
+// newStackTraceTransform returns nil, if the selector
+// does not specify any stack trace transformations.
+func newStackTraceTransform(selector *typesv1.StackTraceSelector) (*stackTraceTransform, error) {
+	var t stackTraceTransform
+	var err error
+	if t.focus, err = compileStackTraceRegexp("focus", selector.GetFocus()); err != nil {
+		return nil, err
+	}
+	if t.ignore, err = compileStackTraceRegexp("ignore", selector.GetIgnore()); err != nil {
+		return nil, err
+	}
+	if t.hide, err = compileStackTraceRegexp("hide", selector.GetHide()); err != nil {
+		return nil, err
+	}
+	if t.show, err = compileStackTraceRegexp("show", selector.GetShow()); err != nil {
+		return nil, err
+	}
+	t.pruneRecursion = selector.GetPruneRecursion()
+	if t == (stackTraceTransform{}) {
+		return nil, nil
+	}
+	return &t, nil
+}
+
+func compileStackTraceRegexp(name, expr string) (*regexp.Regexp, error) {
+	if expr == "" {
+		return nil, nil
+	}
+	r, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s expression: %w", name, err)
+	}
+	return r, nil
+}
+
+type locationFlags uint8
+
+const (
+	// locationFocus indicates that a line of the location
+	// matches the focus expression.
+	locationFocus locationFlags = 1 << iota
+	// locationIgnore indicates that a line of the location
+	// matches the ignore expression.
+	locationIgnore
+	// locationHidden indicates that all the lines of the
+	// location are hidden, and the location is to be removed.
+	locationHidden
+)
+
+// locationFlags returns the transformation flags for each location.
+// Inlined functions are not split: a location is only removed if
+// all of its lines are hidden.
+func (t *stackTraceTransform) locationFlags(symbols *Symbols, funcNames []string) []locationFlags {
+	functions := make([]locationFlags, len(funcNames))
+	for i, name := range funcNames {
+		var f locationFlags
+		if t.focus != nil && t.focus.MatchString(name) {
+			f |= locationFocus
+		}
+		if t.ignore != nil && t.ignore.MatchString(name) {
+			f |= locationIgnore
+		}
+		if t.hide != nil && t.hide.MatchString(name) || t.show != nil && !t.show.MatchString(name) {
+			f |= locationHidden
+		}
+		functions[i] = f
+	}
+	locations := make([]locationFlags, len(symbols.Locations))
+	for i, loc := range symbols.Locations {
+		var f locationFlags
+		hidden := len(loc.Line) > 0
+		for _, line := range loc.Line {
+			x := functions[line.FunctionId]
+			f |= x &^ locationHidden
+			hidden = hidden && x&locationHidden != 0
+		}
+		if hidden {
+			f |= locationHidden
+		}
+		locations[i] = f
+	}
+	return locations
+}
+
+// hasTransform reports whether stack traces of the
+// selection are to be transformed.
+func (x *SelectedStackTraces) hasTransform() bool {
+	return x != nil && x.transform != nil
+}
+
+// transformStackTrace writes the transformed stack trace (leaf first)
+// to dst and returns it. False is returned if the stack trace is to be
+// dropped. dst may share the underlying array with the locations.
+func transformStackTrace[T int32 | uint64](x *SelectedStackTraces, dst, locations []T) ([]T, bool) {
+	dst = dst[:0]
+	var seen locationFlags
+	for _, loc := range locations {
+		seen |= x.locations[loc]
+	}
+	if seen&locationIgnore != 0 || x.transform.focus != nil && seen&locationFocus == 0 {
+		return dst, false
+	}
+	for _, loc := range locations {
+		if x.locations[loc]&locationHidden != 0 {
+			continue
+		}
+		if x.transform.pruneRecursion && len(dst) > 0 && x.sameFunctions(uint64(dst[len(dst)-1]), uint64(loc)) {
+			continue
+		}
+		dst = append(dst, loc)
+	}
+	return dst, len(dst) > 0
+}
+
+// sameFunctions reports whether the locations refer to the same
+// functions, including the inlined ones.
+func (x *SelectedStackTraces) sameFunctions(a, b uint64) bool {
+	if a == b {
+		return true
+	}
+	x1 := x.symbols.Locations[a].Line
+	x2 := x.symbols.Locations[b].Line
+	if len(x1) != len(x2) {
+		return false
+	}
+	for i := range x1 {
+		if x1[i].FunctionId != x2[i].FunctionId {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+package symdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	googlev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+)
+
+func stackTraceTransformTestProfile() *googlev1.Profile {
+	return &googlev1.Profile{
+		StringTable: []string{"", "main", "parse", "expr", "term", "gc"},
+		Function: []*googlev1.Function{
+			{Id: 1, Name: 1},
+			{Id: 2, Name: 2},
+			{Id: 3, Name: 3},
+			{Id: 4, Name: 4},
+			{Id: 5, Name: 5},
+		},
+		Mapping: []*googlev1.Mapping{{Id: 1}},
+		Location: []*googlev1.Location{
+			{Id: 1, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 1, Line: 1}}}, // main
+			{Id: 2, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 2, Line: 1}}}, // parse
+			{Id: 3, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 3, Line: 1}}}, // expr:1
+			{Id: 4, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 3, Line: 2}}}, // expr:2
+			{Id: 5, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 4, Line: 1}}}, // term
+			{Id: 6, MappingId: 1, Line: []*googlev1.Line{{FunctionId: 5, Line: 1}}}, // gc
+		},
+		Sample: []*googlev1.Sample{
+			{LocationId: []uint64{5, 4, 3, 2, 1}, Value: []int64{1}}, // main, parse, expr:1, expr:2, term
+			{LocationId: []uint64{3, 2, 1}, Value: []int64{2}},       // main, parse, expr:1
+			{LocationId: []uint64{6, 1}, Value: []int64{4}},          // main, gc
+		},
+	}
+}
+
+func Test_StackTraceTransform(t *testing.T) {
+	type testCase struct {
+		name     string
+		selector *typesv1.StackTraceSelector
+		expected map[string]int64
+	}
+
+	testCases := []testCase{
+		{
+			name:     "focus",
+			selector: &typesv1.StackTraceSelector{Focus: "^term$"},
+			expected: map[string]int64{
+				"main;parse;expr;expr;term": 1,
+			},
+		},
+		{
+			name:     "ignore",
+			selector: &typesv1.StackTraceSelector{Ignore: "gc"},
+			expected: map[string]int64{
+				"main;parse;expr;expr;term": 1,
+				"main;parse;expr":           2,
+			},
+		},
+		{
+			name:     "hide",
+			selector: &typesv1.StackTraceSelector{Hide: "^expr$"},
+			expected: map[string]int64{
+				"main;parse;term": 1,
+				"main;parse":      2,
+				"main;gc":         4,
+			},
+		},
+		{
+			name:     "show",
+			selector: &typesv1.StackTraceSelector{Show: "^(main|term)$"},
+			expected: map[string]int64{
+				"main;term": 1,
+				"main":      6,
+			},
+		},
+		{
+			name:     "prune recursion",
+			selector: &typesv1.StackTraceSelector{PruneRecursion: true},
+			expected: map[string]int64{
+				"main;parse;expr;term": 1,
+				"main;parse;expr":      2,
+				"main;gc":              4,
+			},
+		},
+		{
+			name: "call site is selected after transformation",
+			selector: &typesv1.StackTraceSelector{
+				CallSite: []*typesv1.Location{{Name: "main"}, {Name: "parse"}, {Name: "term"}},
+				Hide:     "^expr$",
+			},
+			expected: map[string]int64{
+				"main;parse;term": 1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			db := NewSymDB(DefaultConfig().WithDirectory(t.TempDir()))
+			w := db.WriteProfileSymbols(0, stackTraceTransformTestProfile())
+
+			t.Run("tree", func(t *testing.T) {
+				r := NewResolver(context.Background(), db, WithResolverStackTraceSelector(tc.selector))
+				defer r.Release()
+				r.AddSamples(0, w[0].Samples)
+				resolved, err := r.Tree()
+				require.NoError(t, err)
+				actual := make(map[string]int64)
+				resolved.IterateStacks(func(_ string, self int64, stack []string) {
+					s := make([]string, len(stack))
+					for i := range stack {
+						s[i] = stack[len(stack)-1-i]
+					}
+					actual[strings.Join(s, ";")] += self
+				})
+				require.Equal(t, tc.expected, actual)
+			})
+
+			t.Run("pprof", func(t *testing.T) {
+				r := NewResolver(context.Background(), db, WithResolverStackTraceSelector(tc.selector))
+				defer r.Release()
+				r.AddSamples(0, w[0].Samples)
+				resolved, err := r.Pprof()
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, pprofStacks(resolved))
+			})
+		})
+	}
+}
+
+func Test_StackTraceTransform_invalid(t *testing.T) {
+	selector := &typesv1.StackTraceSelector{Focus: "("}
+	require.Error(t, ValidateStackTraceSelector(selector))
+
+	db := NewSymDB(DefaultConfig().WithDirectory(t.TempDir()))
+	w := db.WriteProfileSymbols(0, stackTraceTransformTestProfile())
+	r := NewResolver(context.Background(), db, WithResolverStackTraceSelector(selector))
+	defer r.Release()
+	r.AddSamples(0, w[0].Samples)
+	_, err := r.Tree()
+	require.Error(t, err)
+	_, err = r.Pprof()
+	require.Error(t, err)
+}
+
+func pprofStacks(p *googlev1.Profile) map[string]int64 {
+	stacks := make(map[string]int64)
+	for _, s := range p.Sample {
+		var names []string
+		for i := len(s.LocationId) - 1; i >= 0; i-- {
+			loc := p.Location[s.LocationId[i]-1]
+			for j := len(loc.Line) - 1; j >= 0; j-- {
+				fn := p.Function[loc.Line[j].FunctionId-1]
+				names = append(names, p.StringTable[fn.Name])
+			}
+		}
+		stacks[strings.Join(names, ";")] += s.Value[0]
+	}
+	return stacks
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"slices"
+	"strconv"
 	"time"
 
 	"connectrpc.com/connect"
@@ -34,13 +37,16 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"gopkg.in/yaml.v3"
 
+	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
 	statusv1 "github.com/grafana/pyroscope/api/gen/proto/go/status/v1"
 	"github.com/grafana/pyroscope/pkg/adhocprofiles"
+	connectapi "github.com/grafana/pyroscope/pkg/api/connect"
 	apiversion "github.com/grafana/pyroscope/pkg/api/version"
 	"github.com/grafana/pyroscope/pkg/compactor"
 	"github.com/grafana/pyroscope/pkg/distributor"
 	"github.com/grafana/pyroscope/pkg/embedded/grafana"
 	"github.com/grafana/pyroscope/pkg/ingester"
+	phlareobj "github.com/grafana/pyroscope/pkg/objstore"
 	objstoreclient "github.com/grafana/pyroscope/pkg/objstore/client"
 	"github.com/grafana/pyroscope/pkg/objstore/providers/filesystem"
 	"github.com/grafana/pyroscope/pkg/operations"
@@ -157,11 +163,42 @@ func (f *Pyroscope) initAdHocProfiles() (services.Service, error) {
 		return nil, nil
 	}
 
-	a := adhocprofiles.NewAdHocProfiles(f.storageBucket, f.logger, f.Overrides)
+	// Uploaded profiles can be compared with the profiles in
+	// the store, which are queried via the query API.
+	queryURL := f.Cfg.AdHocProfiles.QueryURL
+	if queryURL == "" {
+		queryURL = serverURL(f.Cfg.Server)
+	}
+	querierClient := querierv1connect.NewQuerierServiceClient(
+		http.DefaultClient,
+		queryURL,
+		append(connectapi.DefaultClientOptions(), f.auth)...,
+	)
+
+	a := adhocprofiles.NewAdHocProfiles(f.Cfg.AdHocProfiles, f.storageBucket, f.logger, f.Overrides, querierClient)
 	f.API.RegisterAdHocProfiles(a)
 	return a, nil
 }
 
+// serverURL returns the URL of the HTTP server of this instance. If the
+// server listens on all interfaces, the loopback address is used.
+func serverURL(cfg server.Config) string {
+	scheme := "http"
+	if cfg.HTTPTLSConfig.TLSCertPath != "" {
+		scheme = "https"
+	}
+	host := cfg.HTTPListenAddress
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   net.JoinHostPort(host, strconv.Itoa(cfg.HTTPListenPort)),
+		Path:   cfg.PathPrefix,
+	}
+	return u.String()
+}
+
 func (f *Pyroscope) initOverrides() (serv services.Service, err error) {
 	f.Overrides, err = validation.NewOverrides(f.Cfg.LimitsConfig, f.TenantLimits)
 	// overrides don't have operational state, nor do they need to do anything more in starting/stopping phase,
@@ -389,6 +426,11 @@ func (f *Pyroscope) initStorage() (_ services.Service, err error) {
 			return nil, errors.Wrap(err, "unable to initialise bucket")
 		}
 		f.storageBucket = b
+		if f.Cfg.Storage.Cache.Enabled {
+			if f.cachingBucket, err = phlareobj.NewCachingBucket(b, f.Cfg.Storage.Cache, f.reg); err != nil {
+				return nil, errors.Wrap(err, "unable to initialise caching bucket")
+			}
+		}
 	}
 
 	if !slices.Contains(f.Cfg.Target, All) && f.storageBucket == nil {
@@ -398,6 +440,16 @@ func (f *Pyroscope) initStorage() (_ services.Service, err error) {
 	return nil, nil
 }
 
+// readBucket returns the bucket for the components that read
+// the same objects repeatedly: if the storage cache is enabled,
+// the reads are cached.
+func (f *Pyroscope) readBucket() phlareobj.Bucket {
+	if f.cachingBucket != nil {
+		return f.cachingBucket
+	}
+	return f.storageBucket
+}
+
 // TODO: This should be passed to all other services and could also be used to signal shutdown
 func (f *Pyroscope) context() context.Context {
 	phlarectx := phlarecontext.WithLogger(context.Background(), f.logger)
@@ -431,7 +483,7 @@ func (f *Pyroscope) initStoreGateway() (serv services.Service, err error) {
 		return nil, nil
 	}
 
-	svc, err := storegateway.NewStoreGateway(f.Cfg.StoreGateway, f.storageBucket, f.Overrides, f.logger, f.reg)
+	svc, err := storegateway.NewStoreGateway(f.Cfg.StoreGateway, f.readBucket(), f.Overrides, f.logger, f.reg)
 	if err != nil {
 		return nil, err
 	}
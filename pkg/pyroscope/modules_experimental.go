@@ -240,7 +240,7 @@ func (f *Pyroscope) initCompactionWorker() (svc services.Service, err error) {
 			ruler = metrics.NewStaticRulerFromOverrides(f.Overrides)
 		}
 
-		exporter, err = metrics.NewExporter(f.Cfg.CompactionWorker.MetricsExporter.RemoteWriteAddress, f.logger, f.reg)
+		exporter, err = metrics.NewExporter(f.Cfg.CompactionWorker.MetricsExporter, f.Overrides, f.logger, f.reg)
 		if err != nil {
 			return nil, err
 		}
@@ -250,14 +250,26 @@ func (f *Pyroscope) initCompactionWorker() (svc services.Service, err error) {
 		logger,
 		f.Cfg.CompactionWorker,
 		f.metastoreClient,
-		f.storageBucket,
+		f.readBucket(),
 		registerer,
 		ruler,
 		exporter,
+		f.symbolizer,
+		f.Overrides,
 	)
 	if err != nil {
 		return nil, err
 	}
+	if f.Cfg.CompactionWorker.MetricsExporter.Enabled {
+		f.API.RegisterRecordingRulesBackfill(metrics.NewBackfiller(
+			logger,
+			f.storageBucket,
+			f.metastoreClient,
+			ruler,
+			exporter,
+		))
+	}
+
 	f.compactionWorker = w
 	return w.Service(), nil
 }
@@ -329,12 +341,16 @@ func (f *Pyroscope) initQueryBackend() (services.Service, error) {
 		return nil, err
 	}
 	logger := log.With(f.logger, "component", "query-backend")
+	reportCache, err := querybackend.NewReportCache(f.Cfg.QueryBackend.ReportCache, logger, f.reg)
+	if err != nil {
+		return nil, err
+	}
 	b, err := querybackend.New(
 		f.Cfg.QueryBackend,
 		logger,
 		f.reg,
 		f.queryBackendClient,
-		querybackend.NewBlockReader(f.logger, f.storageBucket, f.reg),
+		querybackend.NewBlockReader(f.logger, f.readBucket(), reportCache, f.reg),
 	)
 	if err != nil {
 		return nil, err
@@ -389,6 +405,7 @@ func (f *Pyroscope) initSymbolizer() (services.Service, error) {
 	}
 
 	f.symbolizer = sym
+	f.API.RegisterSymbolizer(sym)
 
 	return nil, nil
 }
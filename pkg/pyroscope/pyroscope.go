@@ -40,6 +40,7 @@ import (
 	"github.com/samber/lo"
 	"google.golang.org/grpc/health"
 
+	"github.com/grafana/pyroscope/pkg/adhocprofiles"
 	"github.com/grafana/pyroscope/pkg/api"
 	apiversion "github.com/grafana/pyroscope/pkg/api/version"
 	"github.com/grafana/pyroscope/pkg/cfg"
@@ -97,6 +98,7 @@ type Config struct {
 	RuntimeConfig     runtimeconfig.Config   `yaml:"runtime_config"`
 	Compactor         compactor.Config       `yaml:"compactor"`
 	TenantSettings    settings.Config        `yaml:"tenant_settings"`
+	AdHocProfiles     adhocprofiles.Config   `yaml:"adhoc_profiles"`
 
 	Storage       StorageConfig       `yaml:"storage"`
 	SelfProfiling SelfProfilingConfig `yaml:"self_profiling,omitempty"`
@@ -128,11 +130,13 @@ func newDefaultConfig() *Config {
 }
 
 type StorageConfig struct {
-	Bucket objstoreclient.Config `yaml:",inline"`
+	Bucket objstoreclient.Config         `yaml:",inline"`
+	Cache  phlareobj.CachingBucketConfig `yaml:"cache"`
 }
 
 func (c *StorageConfig) RegisterFlags(f *flag.FlagSet) {
 	c.Bucket.RegisterFlagsWithPrefix("storage.", f)
+	c.Cache.RegisterFlagsWithPrefix("storage.cache.", f)
 }
 
 type SelfProfilingConfig struct {
@@ -200,6 +204,7 @@ func (c *Config) RegisterFlagsWithContext(f *flag.FlagSet) {
 	c.API.RegisterFlags(f)
 	c.EmbeddedGrafana.RegisterFlags(f)
 	c.TenantSettings.RegisterFlags(f)
+	c.AdHocProfiles.RegisterFlags(f)
 }
 
 // registerServerFlagsWithChangedDefaultValues registers *Config.Server flags, but overrides some defaults set by the dskit package.
@@ -253,6 +258,7 @@ func (c *Config) registerServerFlagsWithChangedDefaultValues(fs *flag.FlagSet) {
 		c.LimitsConfig.Retention.RegisterFlags(throwaway)
 		c.LimitsConfig.RecordingRules.RegisterFlags(throwaway)
 		c.LimitsConfig.Symbolizer.RegisterFlags(throwaway)
+		c.LimitsConfig.MetricsExporter.RegisterFlags(throwaway)
 		c.Symbolizer.RegisterFlags(throwaway)
 	}
 
@@ -290,6 +296,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Distributor.Validate(); err != nil {
+		return err
+	}
+
 	if err := c.StoreGateway.Validate(c.LimitsConfig); err != nil {
 		return err
 	}
@@ -306,6 +316,10 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Storage.Cache.Validate(); err != nil {
+		return err
+	}
+
 	if err := c.TenantSettings.Validate(); err != nil {
 		return err
 	}
@@ -361,6 +375,8 @@ type Pyroscope struct {
 	TenantLimits validation.TenantLimits
 
 	storageBucket phlareobj.Bucket
+	// cachingBucket caches reads of immutable objects, if enabled.
+	cachingBucket phlareobj.Bucket
 
 	grpcGatewayMux *grpcgw.ServeMux
 
@@ -498,13 +514,13 @@ func (f *Pyroscope) setupModuleManager() error {
 			SegmentWriter:       {Overrides, API, MemberlistKV, Storage, UsageReport, MetastoreClient},
 			Metastore:           {Overrides, API, MetastoreClient, Storage, PlacementManager},
 			MetastoreAdmin:      {API, MetastoreClient},
-			CompactionWorker:    {Overrides, API, Storage, MetastoreClient, RecordingRulesClient},
+			CompactionWorker:    {Overrides, API, Storage, MetastoreClient, RecordingRulesClient, Symbolizer},
 			QueryBackend:        {Overrides, API, Storage, QueryBackendClient},
 			SegmentWriterRing:   {Overrides, API, MemberlistKV},
 			SegmentWriterClient: {Overrides, API, SegmentWriterRing, PlacementAgent},
 			PlacementAgent:      {Overrides, API, Storage},
 			PlacementManager:    {Overrides, API, Storage},
-			Symbolizer:          {Overrides, Storage},
+			Symbolizer:          {Overrides, API, Storage},
 		}
 		for k, v := range v2Modules {
 			deps[k] = v
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/grafana/dskit/server"
 	"github.com/stretchr/testify/require"
 
 	statusv1 "github.com/grafana/pyroscope/api/gen/proto/go/status/v1"
@@ -80,3 +81,41 @@ func TestConfigDiff(t *testing.T) {
 		require.Equal(t, "limits:\n    max_label_name_length: 123\n", string(result.Data))
 	})
 }
+
+func TestServerURL(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		config   server.Config
+		expected string
+	}{
+		{
+			name:     "all interfaces",
+			config:   server.Config{HTTPListenPort: 4040},
+			expected: "http://127.0.0.1:4040",
+		},
+		{
+			name:     "unspecified address",
+			config:   server.Config{HTTPListenAddress: "0.0.0.0", HTTPListenPort: 4040},
+			expected: "http://127.0.0.1:4040",
+		},
+		{
+			name:     "listen address",
+			config:   server.Config{HTTPListenAddress: "10.0.0.1", HTTPListenPort: 4040},
+			expected: "http://10.0.0.1:4040",
+		},
+		{
+			name: "tls and path prefix",
+			config: server.Config{
+				HTTPListenAddress: "::1",
+				HTTPListenPort:    4040,
+				HTTPTLSConfig:     server.TLSConfig{TLSCertPath: "cert.pem"},
+				PathPrefix:        "/pyroscope",
+			},
+			expected: "https://[::1]:4040/pyroscope",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, serverURL(tc.config))
+		})
+	}
+}
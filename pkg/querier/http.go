@@ -11,19 +11,16 @@ import (
 
 	"connectrpc.com/connect"
 	"github.com/gogo/status"
-	"github.com/google/pprof/profile"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 
-	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	"github.com/grafana/pyroscope/api/gen/proto/go/querier/v1/querierv1connect"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
-	"github.com/grafana/pyroscope/pkg/frontend/dot/graph"
-	"github.com/grafana/pyroscope/pkg/frontend/dot/report"
+	"github.com/grafana/pyroscope/pkg/frontend/dot"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/og/structs/flamebearer"
 	"github.com/grafana/pyroscope/pkg/og/util/attime"
@@ -107,6 +104,11 @@ func (q *QueryHandlers) RenderDiff(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	if req.URL.Query().Get("format") == "dot" {
+		q.renderGraph(w, req, rightSelectParams, leftSelectParams)
+		return
+	}
+
 	res, err := q.client.Diff(req.Context(), connect.NewRequest(&querierv1.DiffRequest{
 		Left:  leftSelectParams,
 		Right: rightSelectParams,
@@ -143,37 +145,23 @@ func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
 			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM
 		case "avg":
 			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_AVERAGE
+		case "min":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MIN
+		case "max":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MAX
+		case "p50":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P50
+		case "p95":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P95
+		case "p99":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99
+		case "rate":
+			aggregation = typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE
 		}
 	}
 
-	format := req.URL.Query().Get("format")
-	if format == "dot" {
-		// We probably should distinguish max nodes of the source pprof
-		// profile and max nodes value for the output profile in dot format.
-		sourceProfileMaxNodes := int64(512)
-		dotProfileMaxNodes := int64(100)
-		if selectParams.MaxNodes != nil {
-			if v := *selectParams.MaxNodes; v > 0 {
-				dotProfileMaxNodes = v
-			}
-			if dotProfileMaxNodes > sourceProfileMaxNodes {
-				sourceProfileMaxNodes = dotProfileMaxNodes
-			}
-		}
-		resp, err := q.client.SelectMergeProfile(req.Context(), connect.NewRequest(&querierv1.SelectMergeProfileRequest{
-			Start:         selectParams.Start,
-			End:           selectParams.End,
-			ProfileTypeID: selectParams.ProfileTypeID,
-			LabelSelector: selectParams.LabelSelector,
-			MaxNodes:      &sourceProfileMaxNodes,
-		}))
-		if err != nil {
-			httputil.Error(w, connect.NewError(connect.CodeInternal, err))
-			return
-		}
-		if err = pprofToDotProfile(w, resp.Msg, int(dotProfileMaxNodes)); err != nil {
-			httputil.Error(w, connect.NewError(connect.CodeInternal, err))
-		}
+	if req.URL.Query().Get("format") == "dot" {
+		q.renderGraph(w, req, selectParams, nil)
 		return
 	}
 
@@ -240,19 +228,72 @@ func (q *QueryHandlers) Render(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
-func pprofToDotProfile(w io.Writer, p *profilev1.Profile, maxNodes int) error {
-	data, err := p.MarshalVT()
+// renderGraph writes the call graph of the profile in DOT format.
+// If the base is specified, the graph shows the difference between
+// the profile and the base.
+func (q *QueryHandlers) renderGraph(w http.ResponseWriter, req *http.Request, profile, base *querierv1.SelectMergeStacktracesRequest) {
+	options, err := parseGraphOptions(req)
 	if err != nil {
-		return connect.NewError(connect.CodeInternal, err)
+		httputil.Error(w, connect.NewError(connect.CodeInvalidArgument, err))
+		return
+	}
+	// We probably should distinguish max nodes of the source pprof
+	// profile and max nodes value for the output profile in dot format.
+	sourceProfileMaxNodes := int64(512)
+	options.MaxNodes = dot.DefaultMaxNodes
+	if profile.MaxNodes != nil {
+		if v := *profile.MaxNodes; v > 0 {
+			options.MaxNodes = v
+		}
+		if options.MaxNodes > sourceProfileMaxNodes {
+			sourceProfileMaxNodes = options.MaxNodes
+		}
 	}
-	pr, err := profile.ParseData(data)
+	selectMergeProfile := func(r *querierv1.SelectMergeStacktracesRequest) *querierv1.SelectMergeProfileRequest {
+		return &querierv1.SelectMergeProfileRequest{
+			Start:         r.Start,
+			End:           r.End,
+			ProfileTypeID: r.ProfileTypeID,
+			LabelSelector: r.LabelSelector,
+			MaxNodes:      &sourceProfileMaxNodes,
+		}
+	}
+	graphReq := &querierv1.SelectGraphRequest{
+		Profile: selectMergeProfile(profile),
+		Options: options,
+	}
+	if base != nil {
+		graphReq.Base = selectMergeProfile(base)
+	}
+	resp, err := q.client.SelectGraph(req.Context(), connect.NewRequest(graphReq))
 	if err != nil {
-		return connect.NewError(connect.CodeInternal, err)
+		httputil.Error(w, err)
+		return
+	}
+	w.Header().Add("Content-Type", "text/vnd.graphviz")
+	_, _ = io.WriteString(w, resp.Msg.Dot)
+}
+
+// parseGraphOptions parses the call graph options, using the
+// pprof parameter names: nodefraction, edgefraction, focus, ignore.
+func parseGraphOptions(req *http.Request) (*querierv1.GraphOptions, error) {
+	v := req.URL.Query()
+	options := &querierv1.GraphOptions{
+		Focus:  v.Get("focus"),
+		Ignore: v.Get("ignore"),
+	}
+	var err error
+	if s := v.Get("nodefraction"); s != "" {
+		if options.NodeFraction, err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("invalid nodefraction: %w", err)
+		}
+	}
+	if s := v.Get("edgefraction"); s != "" {
+		if options.EdgeFraction, err = strconv.ParseFloat(s, 64); err != nil {
+			return nil, fmt.Errorf("invalid edgefraction: %w", err)
+		}
 	}
-	rpt := report.NewDefault(pr, report.Options{NodeCount: maxNodes})
-	gr, cfg := report.GetDOT(rpt)
-	graph.ComposeDot(w, gr, &graph.DotAttributes{}, cfg)
-	return nil
+	return options, nil
 }
 
 type renderRequestFieldNames struct {
@@ -10,6 +10,7 @@ import (
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
+	querierv1 "github.com/grafana/pyroscope/api/gen/proto/go/querier/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 )
 
@@ -40,3 +41,28 @@ func Test_ParseQuery(t *testing.T) {
 
 	require.Equal(t, `{foo="bar",bar=~"buzz"}`, queryRequest.LabelSelector)
 }
+
+func Test_ParseGraphOptions(t *testing.T) {
+	q := url.Values{
+		"nodefraction": []string{"0.05"},
+		"edgefraction": []string{"0.01"},
+		"focus":        []string{"^main"},
+		"ignore":       []string{"runtime"},
+	}
+	req, err := http.NewRequest("GET", fmt.Sprintf("http://localhost/render/render?%s", q.Encode()), nil)
+	require.NoError(t, err)
+
+	options, err := parseGraphOptions(req)
+	require.NoError(t, err)
+	require.Equal(t, &querierv1.GraphOptions{
+		NodeFraction: 0.05,
+		EdgeFraction: 0.01,
+		Focus:        "^main",
+		Ignore:       "runtime",
+	}, options)
+
+	req, err = http.NewRequest("GET", "http://localhost/render/render?nodefraction=x", nil)
+	require.NoError(t, err)
+	_, err = parseGraphOptions(req)
+	require.Error(t, err)
+}
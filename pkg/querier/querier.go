@@ -33,9 +33,11 @@ import (
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	connectapi "github.com/grafana/pyroscope/pkg/api/connect"
 	"github.com/grafana/pyroscope/pkg/clientpool"
+	"github.com/grafana/pyroscope/pkg/frontend/dot"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	phlareobj "github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/phlaredb/bucketindex"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
 	"github.com/grafana/pyroscope/pkg/pprof"
 	"github.com/grafana/pyroscope/pkg/storegateway"
 	"github.com/grafana/pyroscope/pkg/util/spanlogger"
@@ -639,6 +641,56 @@ func (q *Querier) SelectMergeSpanProfile(ctx context.Context, req *connect.Reque
 	return connect.NewResponse(&resp), nil
 }
 
+func (q *Querier) SelectTopFunctions(ctx context.Context, req *connect.Request[querierv1.SelectTopFunctionsRequest]) (*connect.Response[querierv1.SelectTopFunctionsResponse], error) {
+	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectTopFunctions")
+	level.Info(spanlogger.FromContext(ctx, q.logger)).Log(
+		"start", model.Time(req.Msg.Start).Time().String(),
+		"end", model.Time(req.Msg.End).Time().String(),
+		"selector", req.Msg.LabelSelector,
+		"profile_id", req.Msg.ProfileTypeID,
+		"limit", req.Msg.GetLimit(),
+	)
+	defer func() {
+		sp.Finish()
+	}()
+
+	// The tree is not truncated: otherwise,
+	// the function values would be inaccurate.
+	t, err := q.selectTree(ctx, &querierv1.SelectMergeStacktracesRequest{
+		ProfileTypeID: req.Msg.ProfileTypeID,
+		LabelSelector: req.Msg.LabelSelector,
+		Start:         req.Msg.Start,
+		End:           req.Msg.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	m := phlaremodel.NewTopFunctionsMerger()
+	m.MergeTree(t)
+	return connect.NewResponse(&querierv1.SelectTopFunctionsResponse{
+		Functions: m.Top(int(req.Msg.GetLimit())),
+		Total:     m.Total(),
+	}), nil
+}
+
+// SelectHeatmap is not supported: heatmaps are built of the values of
+// individual profiles, which are only provided by the query backend.
+func (q *Querier) SelectHeatmap(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("heatmap queries require the query backend"))
+}
+
+// SelectExemplars and SelectProfileByID are not supported: individual
+// profiles can only be selected by ID in the query backend.
+
+func (q *Querier) SelectExemplars(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exemplar queries require the query backend"))
+}
+
+func (q *Querier) SelectProfileByID(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("exemplar queries require the query backend"))
+}
+
 func isEndpointNotExistingErr(err error) bool {
 	if err == nil {
 		return false
@@ -653,6 +705,12 @@ func isEndpointNotExistingErr(err error) bool {
 }
 
 func (q *Querier) selectTree(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest) (*phlaremodel.Tree, error) {
+	if req.StackTraceSelector != nil {
+		// Stack trace merge requests don't support the selector:
+		// the tree is built from the merged pprof profile instead.
+		return q.selectTreeFromProfile(ctx, req)
+	}
+
 	// determine the block hints
 	plan, err := q.blockSelect(ctx, model.Time(req.Start), model.Time(req.End))
 	if isEndpointNotExistingErr(err) {
@@ -709,6 +767,24 @@ func (q *Querier) selectTree(ctx context.Context, req *querierv1.SelectMergeStac
 	return storegatewayTree, nil
 }
 
+func (q *Querier) selectTreeFromProfile(ctx context.Context, req *querierv1.SelectMergeStacktracesRequest) (*phlaremodel.Tree, error) {
+	if err := symdb.ValidateStackTraceSelector(req.StackTraceSelector); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	// The tree is truncated by the caller.
+	p, err := q.selectProfile(ctx, &querierv1.SelectMergeProfileRequest{
+		ProfileTypeID:      req.ProfileTypeID,
+		LabelSelector:      req.LabelSelector,
+		Start:              req.Start,
+		End:                req.End,
+		StackTraceSelector: req.StackTraceSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return phlaremodel.NewTreeFromBackendProfile(p)
+}
+
 type storeQuery struct {
 	start, end  model.Time
 	shouldQuery bool
@@ -716,12 +792,13 @@ type storeQuery struct {
 
 func (sq storeQuery) MergeStacktracesRequest(req *querierv1.SelectMergeStacktracesRequest) *querierv1.SelectMergeStacktracesRequest {
 	return &querierv1.SelectMergeStacktracesRequest{
-		Start:         int64(sq.start),
-		End:           int64(sq.end),
-		LabelSelector: req.LabelSelector,
-		ProfileTypeID: req.ProfileTypeID,
-		MaxNodes:      req.MaxNodes,
-		Format:        req.Format,
+		Start:              int64(sq.start),
+		End:                int64(sq.end),
+		LabelSelector:      req.LabelSelector,
+		ProfileTypeID:      req.ProfileTypeID,
+		MaxNodes:           req.MaxNodes,
+		Format:             req.Format,
+		StackTraceSelector: req.StackTraceSelector,
 	}
 }
 
@@ -837,6 +914,10 @@ func splitQueryToStores(start, end model.Time, now model.Time, queryStoreAfter t
 	return queries
 }
 
+func (q *Querier) SelectGraph(ctx context.Context, req *connect.Request[querierv1.SelectGraphRequest]) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	return dot.SelectGraph(ctx, req, q.SelectMergeProfile)
+}
+
 func (q *Querier) SelectMergeProfile(ctx context.Context, req *connect.Request[querierv1.SelectMergeProfileRequest]) (*connect.Response[googlev1.Profile], error) {
 	sp, ctx := opentracing.StartSpanFromContext(ctx, "SelectMergeProfile")
 	sp.SetTag("start", model.Time(req.Msg.Start).Time().String()).
@@ -937,6 +1018,10 @@ func (q *Querier) SelectSeries(ctx context.Context, req *connect.Request[querier
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("step must be non-zero"))
 	}
 
+	if symdb.HasStackTraceTransform(req.Msg.StackTraceSelector) {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("stack trace transformations are not supported by time series queries"))
+	}
+
 	stepMs := time.Duration(req.Msg.Step * float64(time.Second)).Milliseconds()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
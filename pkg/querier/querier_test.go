@@ -360,6 +360,131 @@ func Test_SelectMergeStacktraces(t *testing.T) {
 	}
 }
 
+func Test_SelectMergeStacktraces_StackTraceSelector(t *testing.T) {
+	selector := &typesv1.StackTraceSelector{Hide: "^baz$"}
+	bidis := make(map[string]*fakeBidiClientProfiles)
+	for _, addr := range []string{"1", "2", "3"} {
+		bidis[addr] = newFakeBidiClientProfiles([]*ingestv1.ProfileSets{
+			{
+				LabelsSets: []*typesv1.Labels{
+					{Labels: []*typesv1.LabelPair{{Name: "app", Value: "foo"}}},
+				},
+				Profiles: []*ingestv1.SeriesProfile{
+					{Timestamp: 1, LabelIndex: 0},
+				},
+			},
+		})
+	}
+	querier, err := New(&NewQuerierParams{
+		Cfg: Config{
+			PoolConfig: clientpool.PoolConfig{ClientCleanupPeriod: 1 * time.Millisecond},
+		},
+		IngestersRing: testhelper.NewMockRing([]ring.InstanceDesc{
+			{Addr: "1"},
+			{Addr: "2"},
+			{Addr: "3"},
+		}, 3),
+		PoolFactory: &poolFactory{f: func(addr string) (client.PoolClient, error) {
+			// The stack trace merge does not support the selector:
+			// the tree is built from the merged pprof profile.
+			q := newFakeQuerier()
+			q.mockMergeProfile(bidis[addr], nil, false)
+			return q, nil
+		}},
+		Logger: log.NewLogfmtLogger(os.Stdout),
+	})
+	require.NoError(t, err)
+
+	res, err := querier.SelectMergeStacktraces(context.Background(), connect.NewRequest(&querierv1.SelectMergeStacktracesRequest{
+		LabelSelector:      `{app="foo"}`,
+		ProfileTypeID:      "memory:inuse_space:bytes:space:byte",
+		Start:              0,
+		End:                2,
+		StackTraceSelector: selector,
+	}))
+	require.NoError(t, err)
+	var queried int
+	for _, bidi := range bidis {
+		if bidi.request != nil {
+			queried++
+			require.Equal(t, selector, bidi.request.StackTraceSelector)
+		}
+	}
+	require.NotZero(t, queried)
+	sort.Strings(res.Msg.Flamegraph.Names)
+	require.Equal(t, []string{"bar", "foo", "total"}, res.Msg.Flamegraph.Names)
+}
+
+func Test_SelectSeries_StackTraceTransformNotSupported(t *testing.T) {
+	querier, err := New(&NewQuerierParams{
+		IngestersRing: testhelper.NewMockRing(nil, 1),
+		PoolFactory: &poolFactory{f: func(addr string) (client.PoolClient, error) {
+			return newFakeQuerier(), nil
+		}},
+		Logger: log.NewLogfmtLogger(os.Stdout),
+	})
+	require.NoError(t, err)
+
+	_, err = querier.SelectSeries(context.Background(), connect.NewRequest(&querierv1.SelectSeriesRequest{
+		LabelSelector:      `{app="foo"}`,
+		ProfileTypeID:      "memory:inuse_space:bytes:space:byte",
+		Start:              0,
+		End:                2,
+		Step:               1,
+		StackTraceSelector: &typesv1.StackTraceSelector{Focus: "foo"},
+	}))
+	require.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func Test_SelectTopFunctions(t *testing.T) {
+	now := time.Now().UnixMilli()
+	limit := int64(1)
+	req := connect.NewRequest(&querierv1.SelectTopFunctionsRequest{
+		LabelSelector: `{app="foo"}`,
+		ProfileTypeID: "memory:inuse_space:bytes:space:byte",
+		Start:         now + 0,
+		End:           now + 2,
+		Limit:         &limit,
+	})
+	newBidi := func() *fakeBidiClientStacktraces {
+		return newFakeBidiClientStacktraces([]*ingestv1.ProfileSets{
+			{
+				LabelsSets: []*typesv1.Labels{
+					{
+						Labels: []*typesv1.LabelPair{{Name: "app", Value: "foo"}},
+					},
+				},
+				Profiles: []*ingestv1.SeriesProfile{
+					{Timestamp: now + 1, LabelIndex: 0},
+				},
+			},
+		})
+	}
+	querier, err := New(&NewQuerierParams{
+		Cfg: Config{
+			PoolConfig: clientpool.PoolConfig{ClientCleanupPeriod: 1 * time.Millisecond},
+		},
+		IngestersRing: testhelper.NewMockRing([]ring.InstanceDesc{
+			{Addr: "1"},
+			{Addr: "2"},
+			{Addr: "3"},
+		}, 3),
+		PoolFactory: &poolFactory{func(addr string) (client.PoolClient, error) {
+			q := newFakeQuerier()
+			q.mockMergeStacktraces(newBidi(), []string{addr}, true)
+			return q, nil
+		}},
+		Logger: log.NewLogfmtLogger(os.Stdout),
+	})
+	require.NoError(t, err)
+	resp, err := querier.SelectTopFunctions(context.Background(), req)
+	require.NoError(t, err)
+	require.Equal(t, &querierv1.SelectTopFunctionsResponse{
+		Functions: []*typesv1.TopFunction{{Name: "foo", Self: 2, Total: 2}},
+		Total:     2,
+	}, resp.Msg)
+}
+
 func Test_SelectMergeProfiles(t *testing.T) {
 	for _, tc := range []struct {
 		blockSelect bool
@@ -844,6 +969,7 @@ type fakeBidiClientProfiles struct {
 	batches  []*ingestv1.ProfileSets
 	kept     []testProfile
 	cur      *ingestv1.ProfileSets
+	request  *ingestv1.MergeProfilesPprofRequest
 }
 
 func newFakeBidiClientProfiles(batches []*ingestv1.ProfileSets) *fakeBidiClientProfiles {
@@ -858,6 +984,7 @@ func newFakeBidiClientProfiles(batches []*ingestv1.ProfileSets) *fakeBidiClientP
 
 func (f *fakeBidiClientProfiles) Send(in *ingestv1.MergeProfilesPprofRequest) error {
 	if in.Request != nil {
+		f.request = in
 		return nil
 	}
 	for i, b := range in.Profiles {
@@ -20,17 +20,22 @@ import (
 type Config struct {
 	Address          string            `yaml:"address"`
 	GRPCClientConfig grpcclient.Config `yaml:"grpc_client_config" doc:"description=Configures the gRPC client used to communicate between the query-frontends and the query-schedulers."`
+	ReportCache      ReportCacheConfig `yaml:"report_cache"`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.Address, "query-backend.address", "localhost:9095", "")
 	cfg.GRPCClientConfig.RegisterFlagsWithPrefix("query-backend.grpc-client-config", f)
+	cfg.ReportCache.RegisterFlagsWithPrefix("query-backend.report-cache.", f)
 }
 
 func (cfg *Config) Validate() error {
 	if cfg.Address == "" {
 		return fmt.Errorf("query-backend.address is required")
 	}
+	if err := cfg.ReportCache.Validate(); err != nil {
+		return err
+	}
 	return cfg.GRPCClientConfig.Validate()
 }
 
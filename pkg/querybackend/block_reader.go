@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -20,6 +21,7 @@ import (
 
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/objstore"
 	"github.com/grafana/pyroscope/pkg/util"
@@ -46,6 +48,7 @@ import (
 type BlockReader struct {
 	log     log.Logger
 	storage objstore.Bucket
+	cache   *ReportCache
 
 	metrics *metrics
 
@@ -57,10 +60,13 @@ type BlockReader struct {
 	//    Instead, they should share the processing pipeline, if possible.
 }
 
-func NewBlockReader(logger log.Logger, storage objstore.Bucket, reg prometheus.Registerer) *BlockReader {
+// NewBlockReader creates a new block reader. The report cache is
+// optional: if it is nil, the block reports are not cached.
+func NewBlockReader(logger log.Logger, storage objstore.Bucket, cache *ReportCache, reg prometheus.Registerer) *BlockReader {
 	return &BlockReader{
 		log:     logger,
 		storage: storage,
+		cache:   cache,
 		metrics: newMetrics(reg),
 	}
 }
@@ -103,6 +109,8 @@ func (b *BlockReader) Invoke(
 			agg: agg,
 			obj: obj,
 			grp: g,
+
+			cache: b.cache,
 		}).execute))
 	}
 
@@ -113,10 +121,12 @@ func (b *BlockReader) Invoke(
 }
 
 type request struct {
-	src       *queryv1.InvokeRequest
-	matchers  []*labels.Matcher
-	startTime int64 // Unix nano.
-	endTime   int64 // Unix nano.
+	src        *queryv1.InvokeRequest
+	matchers   []*labels.Matcher
+	startTime  int64 // Unix nano.
+	endTime    int64 // Unix nano.
+	step       int64 // Milliseconds.
+	tombstones []*block.SeriesTombstone
 }
 
 func (r *request) setTraceTags(span opentracing.Span) {
@@ -155,10 +165,43 @@ func validateRequest(req *queryv1.InvokeRequest) (*request, error) {
 		matchers:  matchers,
 		startTime: model.Time(req.StartTime).UnixNano(),
 		endTime:   model.Time(req.EndTime).UnixNano(),
+		step:      QueryStep(req.Query).Milliseconds(),
+	}
+	for _, t := range req.SeriesTombstones {
+		st, err := block.NewSeriesTombstone(t)
+		if err != nil {
+			return nil, err
+		}
+		r.tombstones = append(r.tombstones, st)
 	}
 	return &r, nil
 }
 
+// QueryStep returns the step that the queries can be served with:
+// datasets downsampled to a resolution not exceeding the step can be
+// used instead of raw data. Only time series queries that sum up the
+// values are eligible, as the downsampled profiles are sums: if there
+// are queries of other types or aggregations, the step is zero.
+func QueryStep(queries []*queryv1.Query) time.Duration {
+	var step time.Duration
+	for i, q := range queries {
+		if q.QueryType != queryv1.QueryType_QUERY_TIME_SERIES || q.TimeSeries == nil {
+			return 0
+		}
+		switch q.TimeSeries.Aggregation {
+		case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM,
+			typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE:
+		default:
+			return 0
+		}
+		s := time.Duration(q.TimeSeries.Step * float64(time.Second))
+		if i == 0 || s < step {
+			step = s
+		}
+	}
+	return step
+}
+
 // While the metastore is expected to already filter datasets of other tenants, we do an additional check to avoid
 // processing blocks or datasets belonging to the wrong tenant.
 func filterNotOwnedDatasets(b *metastorev1.BlockMeta, tenantMap map[string]struct{}) ([]*metastorev1.Dataset, error) {
@@ -2,19 +2,25 @@ package querybackend
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/grafana/dskit/cache"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/suite"
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/block/metadata"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
@@ -48,7 +54,7 @@ func (s *testSuite) SetupSuite() {
 func (s *testSuite) SetupTest() {
 	s.ctx = context.Background()
 	s.logger = test.NewTestingLogger(s.T())
-	s.reader = NewBlockReader(s.logger, &objstore.ReaderAtBucket{Bucket: s.bucket}, nil)
+	s.reader = NewBlockReader(s.logger, &objstore.ReaderAtBucket{Bucket: s.bucket}, nil, nil)
 	s.meta = make([]*metastorev1.BlockMeta, len(s.blocks))
 	for i, b := range s.blocks {
 		s.meta[i] = b.CloneVT()
@@ -107,6 +113,16 @@ func (s *testSuite) sanitizeMetadata() {
 	s.Assert().NotZero(hasIndex)
 }
 
+// timeRange returns the time range covering all the blocks.
+func (s *testSuite) timeRange() (start, end int64) {
+	start, end = s.meta[0].MinTime, s.meta[0].MaxTime
+	for _, m := range s.meta {
+		start = min(start, m.MinTime)
+		end = max(end, m.MaxTime)
+	}
+	return start, end
+}
+
 func (s *testSuite) BeforeTest(_, _ string) {}
 
 func (s *testSuite) AfterTest(_, _ string) {}
@@ -191,6 +207,60 @@ func (s *testSuite) Test_QueryPprof_Metadata() {
 	s.Assert().Equal("nanoseconds", p.StringTable[p.PeriodType.Unit])
 }
 
+func (s *testSuite) Test_QueryExemplars() {
+	start, end := s.timeRange()
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		StartTime: start,
+		EndTime:   end,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_EXEMPLARS,
+			Exemplars: &queryv1.ExemplarsQuery{
+				Step:  60,
+				Limit: 2,
+			},
+		}},
+		QueryPlan:     s.plan,
+		LabelSelector: `{__profile_type__="process_cpu:cpu:nanoseconds:cpu:nanoseconds"}`,
+		Tenant:        s.tenant,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Reports, 1)
+	exemplars := resp.Reports[0].Exemplars.Exemplars
+	s.Require().NotEmpty(exemplars)
+	steps := make(map[int64]int)
+	for _, e := range exemplars {
+		steps[(e.Timestamp+59999)/60000]++
+	}
+	for _, n := range steps {
+		s.Assert().LessOrEqual(n, 2)
+	}
+
+	// The exemplar profile can be fetched by ID.
+	e := exemplars[0]
+	resp, err = s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		StartTime: start,
+		EndTime:   end,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_PPROF,
+			Pprof: &queryv1.PprofQuery{
+				ProfileIdSelector: []string{e.ProfileId},
+			},
+		}},
+		QueryPlan:     s.plan,
+		LabelSelector: phlaremodel.LabelPairsString(e.Labels),
+		Tenant:        s.tenant,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Reports, 1)
+	var p profilev1.Profile
+	s.Require().NoError(pprof.Unmarshal(resp.Reports[0].Pprof.Pprof, &p))
+	var total int64
+	for _, x := range p.Sample {
+		total += x.Value[0]
+	}
+	s.Assert().Equal(e.Value, total)
+}
+
 func (s *testSuite) Test_DatasetIndex_SeriesLabels_GroupBy() {
 	selector := `{service_repository="https://github.com/grafana/pyroscope"}`
 	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
@@ -264,6 +334,287 @@ func (s *testSuite) Test_QueryTimeSeries() {
 	s.Require().NotNil(resp.Reports[0].TimeSeries)
 }
 
+func (s *testSuite) Test_QueryTimeSeries_Aggregation() {
+	start, end := s.timeRange()
+	invoke := func(aggregation typesv1.TimeSeriesAggregationType) []*typesv1.Series {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime: start,
+			EndTime:   end,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TIME_SERIES,
+				TimeSeries: &queryv1.TimeSeriesQuery{
+					Step:        1.0,
+					Aggregation: aggregation,
+				},
+			}},
+			QueryPlan:     s.plan,
+			LabelSelector: "{}",
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0].TimeSeries.TimeSeries
+	}
+
+	sum := invoke(typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM)
+	s.Require().NotEmpty(sum)
+	// The rate is calculated from the sum by the query frontend.
+	s.Assert().Equal(sum, invoke(typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE))
+	// Quantiles can't be aggregated partially: all the points are returned.
+	var n, m int
+	for _, x := range sum {
+		n += len(x.Points)
+	}
+	for _, x := range invoke(typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99) {
+		m += len(x.Points)
+	}
+	s.Assert().GreaterOrEqual(m, n)
+}
+
+func (s *testSuite) Test_QueryTimeSeries_Downsampled() {
+	src := &objstore.ReaderAtBucket{Bucket: s.bucket}
+	dst := &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}
+	shards := make(map[uint32][]*metastorev1.BlockMeta)
+	for _, b := range s.blocks {
+		shards[b.Shard] = append(shards[b.Shard], b)
+	}
+	var compacted []*metastorev1.BlockMeta
+	for _, blocks := range shards {
+		c, err := block.Compact(s.ctx, blocks, src,
+			block.WithCompactionDestination(dst),
+			block.WithCompactionTempDir(s.T().TempDir()),
+			block.WithDownsampling(1),
+		)
+		s.Require().NoError(err)
+		compacted = append(compacted, c...)
+	}
+	var downsampled bool
+	for _, b := range compacted {
+		for _, ds := range b.Datasets {
+			downsampled = downsampled || ds.Resolution > 0
+		}
+		// The datasets are looked up in the dataset index.
+		b.Datasets = slices.DeleteFunc(b.Datasets, func(x *metastorev1.Dataset) bool {
+			return block.DatasetFormat(x.Format) != block.DatasetFormat1
+		})
+	}
+	s.Require().True(downsampled)
+
+	start, end := s.timeRange()
+	reader := NewBlockReader(s.logger, dst, nil, nil)
+	invoke := func(step time.Duration, aggregation typesv1.TimeSeriesAggregationType) map[string][][2]float64 {
+		resp, err := reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime: start,
+			EndTime:   end,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TIME_SERIES,
+				TimeSeries: &queryv1.TimeSeriesQuery{
+					GroupBy:     []string{"service_name"},
+					Step:        step.Seconds(),
+					Aggregation: aggregation,
+				},
+			}},
+			QueryPlan:     queryplan.Build(compacted, 10, 10),
+			LabelSelector: "{}",
+			Tenant:        s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		// The order of the points within a step is not defined.
+		points := make(map[string][][2]float64)
+		for _, x := range resp.Reports[0].TimeSeries.TimeSeries {
+			k := phlaremodel.LabelPairsString(x.Labels)
+			for _, p := range x.Points {
+				points[k] = append(points[k], [2]float64{float64(p.Timestamp), p.Value})
+			}
+			slices.SortFunc(points[k], func(a, b [2]float64) int {
+				if a[0] != b[0] {
+					return cmp.Compare(a[0], b[0])
+				}
+				return cmp.Compare(a[1], b[1])
+			})
+		}
+		return points
+	}
+
+	// The downsampled datasets only hold the sums of the values:
+	// quantiles are computed from the raw profiles, whatever the step.
+	p99 := typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99
+	expected := invoke(time.Second, p99)
+	s.Require().NotEmpty(expected)
+	s.Assert().Equal(expected, invoke(time.Hour, p99))
+}
+
+func (s *testSuite) Test_QueryHeatmap() {
+	start, end := s.timeRange()
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		StartTime: start,
+		EndTime:   end,
+		Query: []*queryv1.Query{
+			{
+				QueryType: queryv1.QueryType_QUERY_HEATMAP,
+				Heatmap: &queryv1.HeatmapQuery{
+					GroupBy: []string{phlaremodel.LabelNameServiceName},
+				},
+			},
+			{
+				QueryType: queryv1.QueryType_QUERY_TIME_SERIES,
+				TimeSeries: &queryv1.TimeSeriesQuery{
+					GroupBy:     []string{phlaremodel.LabelNameServiceName},
+					Step:        1.0,
+					Aggregation: typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_P99,
+				},
+			},
+		},
+		QueryPlan:     s.plan,
+		LabelSelector: "{}",
+		Tenant:        s.tenant,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Reports, 2)
+
+	var heatmap, timeSeries []*typesv1.Series
+	for _, r := range resp.Reports {
+		switch r.ReportType {
+		case queryv1.ReportType_REPORT_HEATMAP:
+			heatmap = r.Heatmap.Series
+		case queryv1.ReportType_REPORT_TIME_SERIES:
+			timeSeries = r.TimeSeries.TimeSeries
+		}
+	}
+	// Similarly to quantile time series, heatmap
+	// points are the values of individual profiles.
+	s.Require().NotEmpty(heatmap)
+	s.Assert().Equal(timeSeries, heatmap)
+}
+
+func (s *testSuite) Test_QueryHeatmap_MaxPoints() {
+	start, end := s.timeRange()
+	_, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		StartTime: start,
+		EndTime:   end,
+		Query: []*queryv1.Query{{
+			QueryType: queryv1.QueryType_QUERY_HEATMAP,
+			Heatmap:   &queryv1.HeatmapQuery{MaxPoints: 1},
+		}},
+		QueryPlan:     s.plan,
+		LabelSelector: "{}",
+		Tenant:        s.tenant,
+	})
+	s.Require().ErrorContains(err, "heatmap query selects more than 1 profiles")
+}
+
+func (s *testSuite) Test_QueryTimeSeries_SeriesTombstones() {
+	start, end := s.timeRange()
+	invoke := func(tombstones ...*metastorev1.SeriesTombstone) map[string][]*typesv1.Point {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime: start,
+			EndTime:   end,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TIME_SERIES,
+				TimeSeries: &queryv1.TimeSeriesQuery{
+					GroupBy: []string{phlaremodel.LabelNameServiceName},
+					Step:    1.0,
+				},
+			}},
+			QueryPlan:        s.plan,
+			LabelSelector:    "{}",
+			Tenant:           s.tenant,
+			SeriesTombstones: tombstones,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		series := make(map[string][]*typesv1.Point)
+		for _, x := range resp.Reports[0].TimeSeries.TimeSeries {
+			name := phlaremodel.Labels(x.Labels).Get(phlaremodel.LabelNameServiceName)
+			series[name] = x.Points
+		}
+		return series
+	}
+
+	all := invoke()
+	s.Require().NotEmpty(all)
+	var deleted string
+	for name, points := range all {
+		if len(points) > 1 {
+			deleted = name
+			break
+		}
+	}
+	s.Require().NotEmpty(deleted)
+	selector := `{service_name="` + deleted + `"}`
+
+	// The series is deleted within the query time range.
+	withTombstone := invoke(&metastorev1.SeriesTombstone{LabelSelector: selector, StartTime: start, EndTime: end})
+	s.Assert().NotContains(withTombstone, deleted)
+	s.Assert().Len(withTombstone, len(all)-1)
+
+	// The series is deleted partially.
+	points := all[deleted]
+	mid := points[len(points)/2].Timestamp
+	partial := invoke(&metastorev1.SeriesTombstone{LabelSelector: selector, StartTime: start, EndTime: mid - 1})
+	s.Require().Contains(partial, deleted)
+	s.Assert().NotEmpty(partial[deleted])
+	for _, p := range partial[deleted] {
+		s.Assert().GreaterOrEqual(p.Timestamp, mid-1)
+	}
+	s.Assert().Less(len(partial[deleted]), len(points))
+}
+
+func (s *testSuite) Test_QueryLabels_SeriesTombstones() {
+	start, end := s.timeRange()
+	invoke := func(query *queryv1.Query, tombstones ...*metastorev1.SeriesTombstone) *queryv1.Report {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime:        start,
+			EndTime:          end,
+			Query:            []*queryv1.Query{query},
+			QueryPlan:        s.plan,
+			LabelSelector:    "{}",
+			Tenant:           s.tenant,
+			SeriesTombstones: tombstones,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		return resp.Reports[0]
+	}
+	labelValues := &queryv1.Query{
+		QueryType:   queryv1.QueryType_QUERY_LABEL_VALUES,
+		LabelValues: &queryv1.LabelValuesQuery{LabelName: phlaremodel.LabelNameServiceName},
+	}
+	seriesLabels := &queryv1.Query{
+		QueryType:    queryv1.QueryType_QUERY_SERIES_LABELS,
+		SeriesLabels: &queryv1.SeriesLabelsQuery{LabelNames: []string{phlaremodel.LabelNameServiceName}},
+	}
+	labelNames := &queryv1.Query{
+		QueryType:  queryv1.QueryType_QUERY_LABEL_NAMES,
+		LabelNames: &queryv1.LabelNamesQuery{},
+	}
+
+	values := invoke(labelValues).LabelValues.LabelValues
+	s.Require().Greater(len(values), 1)
+	s.Require().Len(invoke(seriesLabels).SeriesLabels.SeriesLabels, len(values))
+	s.Require().NotEmpty(invoke(labelNames).LabelNames.LabelNames)
+	deleted := values[0]
+	selector := `{service_name="` + deleted + `"}`
+
+	// The series is deleted within the query time range.
+	tombstone := &metastorev1.SeriesTombstone{LabelSelector: selector, StartTime: start, EndTime: end}
+	s.Assert().Equal(values[1:], invoke(labelValues, tombstone).LabelValues.LabelValues)
+	series := invoke(seriesLabels, tombstone).SeriesLabels.SeriesLabels
+	s.Assert().Len(series, len(values)-1)
+	for _, x := range series {
+		s.Assert().NotEqual(deleted, phlaremodel.Labels(x.Labels).Get(phlaremodel.LabelNameServiceName))
+	}
+
+	// The series is deleted partially.
+	tombstone = &metastorev1.SeriesTombstone{LabelSelector: selector, StartTime: start, EndTime: start}
+	s.Assert().Equal(values, invoke(labelValues, tombstone).LabelValues.LabelValues)
+
+	// All the series are deleted.
+	tombstone = &metastorev1.SeriesTombstone{LabelSelector: `{service_name=~".+"}`, StartTime: start, EndTime: end}
+	s.Assert().Empty(invoke(labelNames, tombstone).LabelNames.LabelNames)
+}
+
 func (s *testSuite) Test_QueryTree_All_Tenant_Isolation() {
 	queryTenant := "some-tenant"
 
@@ -284,3 +635,219 @@ func (s *testSuite) Test_QueryTree_All_Tenant_Isolation() {
 	s.Require().NotNil(resp)
 	s.Require().Len(resp.Reports, 0)
 }
+
+func (s *testSuite) Test_QueryTopFunctions() {
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		EndTime:       time.Now().UnixMilli(),
+		LabelSelector: "{}",
+		QueryPlan:     s.plan,
+		Query: []*queryv1.Query{{
+			QueryType:    queryv1.QueryType_QUERY_TOP_FUNCTIONS,
+			TopFunctions: &queryv1.TopFunctionsQuery{Limit: 10},
+		}},
+		Tenant: s.tenant,
+	})
+
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	s.Require().Len(resp.Reports, 1)
+	r := resp.Reports[0].TopFunctions
+	s.Require().NotNil(r)
+	s.Assert().NotZero(r.Total)
+	s.Require().NotEmpty(r.Functions)
+	var self int64
+	for i, fn := range r.Functions {
+		self += fn.Self
+		s.Assert().LessOrEqual(fn.Self, fn.Total)
+		s.Assert().LessOrEqual(fn.Total, r.Total)
+		if i > 0 {
+			s.Assert().GreaterOrEqual(r.Functions[i-1].Self, fn.Self)
+		}
+	}
+	// The limit is applied by the query frontend: the backend returns
+	// all the functions, therefore, the sum of self values must match
+	// the total value.
+	s.Assert().Greater(len(r.Functions), 10)
+	s.Assert().Equal(r.Total, self)
+}
+
+func (s *testSuite) Test_QueryFunctionTimeSeries() {
+	start, end := s.timeRange()
+
+	resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+		StartTime:     start,
+		EndTime:       end,
+		LabelSelector: "{}",
+		QueryPlan:     s.plan,
+		Query: []*queryv1.Query{{
+			QueryType:    queryv1.QueryType_QUERY_TOP_FUNCTIONS,
+			TopFunctions: &queryv1.TopFunctionsQuery{},
+		}},
+		Tenant: s.tenant,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Reports, 1)
+	top := resp.Reports[0].TopFunctions.Functions
+	s.Require().Greater(len(top), 3)
+	top = top[:3]
+
+	functions := make([]string, len(top))
+	for i, fn := range top {
+		functions[i] = fn.Name
+	}
+
+	for _, self := range []bool{false, true} {
+		resp, err = s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime:     start,
+			EndTime:       end,
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_FUNCTION_TIME_SERIES,
+				FunctionTimeSeries: &queryv1.FunctionTimeSeriesQuery{
+					Step:      1,
+					Functions: functions,
+					Self:      self,
+				},
+			}},
+			Tenant: s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		r := resp.Reports[0].FunctionTimeSeries
+		s.Require().NotNil(r)
+		s.Require().Len(r.TimeSeries, len(functions))
+
+		// The sum of the series values must match
+		// the function values over the whole range.
+		values := make(map[string]int64)
+		for _, series := range r.TimeSeries {
+			name := phlaremodel.Labels(series.Labels).Get(phlaremodel.LabelNameFunction)
+			for _, p := range series.Points {
+				values[name] += int64(p.Value)
+			}
+		}
+		for _, fn := range top {
+			expected := fn.Total
+			if self {
+				expected = fn.Self
+			}
+			s.Assert().Equal(expected, values[fn.Name], fn.Name)
+		}
+	}
+}
+
+func (s *testSuite) Test_QueryDiff() {
+	start, end := s.timeRange()
+	const (
+		leftSelector  = `{}`
+		rightSelector = `{service_name="test-app",function="slow"}`
+	)
+
+	queryTree := func(selector string) *phlaremodel.Tree {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime:     start,
+			EndTime:       end,
+			LabelSelector: selector,
+			QueryPlan:     s.plan,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{},
+			}},
+			Tenant: s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		tree, err := phlaremodel.UnmarshalTree(resp.Reports[0].Tree.Tree)
+		s.Require().NoError(err)
+		return tree
+	}
+
+	queryDiff := func(maxNodes int64) (left, right *phlaremodel.Tree) {
+		resp, err := s.reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			StartTime:     start,
+			EndTime:       end,
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_DIFF,
+				Diff: &queryv1.DiffQuery{
+					MaxNodes: maxNodes,
+					Left:     &queryv1.DiffSelector{LabelSelector: leftSelector, StartTime: start, EndTime: end},
+					Right:    &queryv1.DiffSelector{LabelSelector: rightSelector, StartTime: start, EndTime: end},
+				},
+			}},
+			Tenant: s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		r := resp.Reports[0].Diff
+		s.Require().NotNil(r)
+		left, err = phlaremodel.UnmarshalTree(r.Left)
+		s.Require().NoError(err)
+		right, err = phlaremodel.UnmarshalTree(r.Right)
+		s.Require().NoError(err)
+		return left, right
+	}
+
+	expectedLeft := queryTree(leftSelector)
+	expectedRight := queryTree(rightSelector)
+	s.Require().NotZero(expectedRight.Total())
+	s.Require().Greater(expectedLeft.Total(), expectedRight.Total())
+
+	left, right := queryDiff(0)
+	s.Assert().Equal(expectedLeft.String(), left.String())
+	s.Assert().Equal(expectedRight.String(), right.String())
+
+	// The trees are truncated over the union tree: the number of
+	// distinct nodes retained in both trees is limited to max nodes.
+	left, right = queryDiff(16)
+	s.Assert().Equal(expectedLeft.Total(), left.Total())
+	s.Assert().Equal(expectedRight.Total(), right.Total())
+	union := new(phlaremodel.Tree)
+	union.Merge(left)
+	union.Merge(right)
+	var retained int
+	for _, line := range strings.Split(union.String(), "\n") {
+		if strings.Contains(line, ": self ") && !strings.Contains(line, "other: self ") {
+			retained++
+		}
+	}
+	s.Assert().Greater(retained, 1)
+	s.Assert().LessOrEqual(retained, 16)
+}
+
+func (s *testSuite) Test_ReportCache() {
+	expected, err := os.ReadFile("testdata/fixtures/tree_16.txt")
+	s.Require().NoError(err)
+
+	c := cache.NewMockCache()
+	reportCache := newReportCache(c, time.Hour, s.logger, prometheus.NewRegistry())
+	invoke := func(reader *BlockReader) {
+		resp, err := reader.Invoke(s.ctx, &queryv1.InvokeRequest{
+			EndTime:       time.Now().UnixMilli(),
+			LabelSelector: "{}",
+			QueryPlan:     s.plan,
+			Query: []*queryv1.Query{{
+				QueryType: queryv1.QueryType_QUERY_TREE,
+				Tree:      &queryv1.TreeQuery{MaxNodes: 16},
+			}},
+			Tenant: s.tenant,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(resp.Reports, 1)
+		tree, err := phlaremodel.UnmarshalTree(resp.Reports[0].Tree.Tree)
+		s.Require().NoError(err)
+		s.Assert().Equal(string(expected), tree.String())
+	}
+
+	blocks := float64(len(s.plan.Root.Blocks))
+	invoke(NewBlockReader(s.logger, &objstore.ReaderAtBucket{Bucket: s.bucket}, reportCache, nil))
+	s.Assert().Len(c.GetItems(), len(s.plan.Root.Blocks))
+	s.Assert().Equal(blocks, testutil.ToFloat64(reportCache.metrics.requests.WithLabelValues("miss")))
+	s.Assert().Zero(testutil.ToFloat64(reportCache.metrics.requests.WithLabelValues("hit")))
+
+	// The blocks are not read: the query is served from the cache entirely.
+	invoke(NewBlockReader(s.logger, &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}, reportCache, nil))
+	s.Assert().Equal(blocks, testutil.ToFloat64(reportCache.metrics.requests.WithLabelValues("hit")))
+}
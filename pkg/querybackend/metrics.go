@@ -20,3 +20,23 @@ func newMetrics(reg prometheus.Registerer) *metrics {
 	}
 	return m
 }
+
+type reportCacheMetrics struct {
+	requests *prometheus.CounterVec
+}
+
+func newReportCacheMetrics(reg prometheus.Registerer) *reportCacheMetrics {
+	m := &reportCacheMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: "pyroscope",
+				Subsystem: "query_backend",
+				Name:      "report_cache_requests_total",
+				Help:      "Total number of block reports requested from the cache, by result (hit or miss).",
+			}, []string{"result"}),
+	}
+	if reg != nil {
+		reg.MustRegister(m.requests)
+	}
+	return m
+}
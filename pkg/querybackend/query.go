@@ -15,6 +15,7 @@ import (
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	"github.com/grafana/pyroscope/pkg/block"
+	"github.com/grafana/pyroscope/pkg/block/metadata"
 	"github.com/grafana/pyroscope/pkg/util"
 )
 
@@ -82,6 +83,12 @@ type blockContext struct {
 	agg *reportAggregator
 	obj *block.Object
 	grp *errgroup.Group
+
+	// queries to be executed: the ones served
+	// from the cache are not executed.
+	queries []*queryv1.Query
+	cache   *ReportCache
+	reports *blockReports
 }
 
 func (b *blockContext) execute() error {
@@ -89,10 +96,22 @@ func (b *blockContext) execute() error {
 	span, b.ctx = opentracing.StartSpanFromContext(b.ctx, "blockContext.execute")
 	defer span.Finish()
 
+	b.queries = b.req.src.Query
+	if b.cache != nil {
+		var err error
+		if b.queries, err = b.fetchCachedReports(); err != nil {
+			return err
+		}
+		if len(b.queries) == 0 {
+			return nil
+		}
+	}
+
 	if idx := b.datasetIndex(); idx != nil {
 		if err := b.lookupDatasets(idx); err != nil {
 			if b.obj.IsNotExists(err) {
 				level.Warn(b.log).Log("msg", "object not found", "err", err)
+				b.reports.setPartial()
 				return nil
 			}
 			return fmt.Errorf("failed to lookup datasets: %w", err)
@@ -101,7 +120,7 @@ func (b *blockContext) execute() error {
 
 	for _, ds := range b.obj.Metadata().Datasets {
 		q := b.newQueryContext(ds)
-		for _, query := range b.req.src.Query {
+		for _, query := range b.queries {
 			q.grp.Go(util.RecoverPanic(func() error {
 				return q.execute(query)
 			}))
@@ -111,6 +130,7 @@ func (b *blockContext) execute() error {
 		}
 	}
 
+	b.storeReports()
 	return nil
 }
 
@@ -167,10 +187,19 @@ func (b *blockContext) lookupDatasets(ds *metastorev1.Dataset) error {
 		return err
 	}
 
-	var j int
+	// The dataset IDs refer to the raw datasets; their downsampled
+	// copies are selected instead, if the query step allows.
+	selected := make(map[int32]struct{}, len(datasetIDs))
 	for i := range md.Datasets {
 		if _, ok := datasetIDs[uint32(i)]; ok {
-			md.Datasets[j] = md.Datasets[i]
+			selected[md.Datasets[i].Name] = struct{}{}
+		}
+	}
+	resolution := metadata.ResolutionFilter(md.Datasets, b.req.step)
+	var j int
+	for _, ds := range md.Datasets {
+		if _, ok := selected[ds.Name]; ok && ds.Name != 0 && resolution(ds) {
+			md.Datasets[j] = ds
 			j++
 		}
 	}
@@ -208,6 +237,7 @@ func (q *queryContext) execute(query *queryv1.Query) error {
 	if err = q.ds.Open(q.ctx, q.sections()...); err != nil {
 		if q.obj.IsNotExists(err) {
 			level.Warn(q.log).Log("msg", "object not found", "err", err)
+			q.reports.setPartial()
 			return nil
 		}
 		return fmt.Errorf("failed to initialize query context: %w", err)
@@ -222,6 +252,7 @@ func (q *queryContext) execute(query *queryv1.Query) error {
 	}
 	if r != nil {
 		r.ReportType = QueryReportType(query.QueryType)
+		q.reports.add(query, r)
 		return q.agg.aggregateReport(r)
 	}
 
@@ -230,7 +261,7 @@ func (q *queryContext) execute(query *queryv1.Query) error {
 
 func (q *queryContext) sections() []block.Section {
 	sections := make(map[block.Section]struct{}, 3)
-	for _, qt := range q.req.src.Query {
+	for _, qt := range q.queries {
 		for _, s := range queryDependencies[qt.QueryType] {
 			sections[s] = struct{}{}
 		}
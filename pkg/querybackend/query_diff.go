@@ -0,0 +1,168 @@
+package querybackend
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/promql/parser"
+	"golang.org/x/sync/errgroup"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	v1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_DIFF,
+		queryv1.ReportType_REPORT_DIFF,
+		queryDiff,
+		newDiffAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+func queryDiff(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
+	left, err := diffSideContext(q, query.Diff.Left)
+	if err != nil {
+		return nil, fmt.Errorf("left selector: %w", err)
+	}
+	right, err := diffSideContext(q, query.Diff.Right)
+	if err != nil {
+		return nil, fmt.Errorf("right selector: %w", err)
+	}
+
+	// Both sides are resolved concurrently with the same symbols reader.
+	// Partitions are reference counted: as long as both resolvers are
+	// not released, a partition is only fetched once and shared.
+	leftResolver := symdb.NewResolver(q.ctx, q.ds.Symbols())
+	defer leftResolver.Release()
+	rightResolver := symdb.NewResolver(q.ctx, q.ds.Symbols())
+	defer rightResolver.Release()
+
+	var leftTree, rightTree *phlaremodel.Tree
+	g, ctx := errgroup.WithContext(q.ctx)
+	g.Go(func() (err error) {
+		leftTree, err = diffSideTree(ctx, left, leftResolver)
+		return err
+	})
+	g.Go(func() (err error) {
+		rightTree, err = diffSideTree(ctx, right, rightResolver)
+		return err
+	})
+	if err = g.Wait(); err != nil {
+		return nil, err
+	}
+
+	phlaremodel.TruncateTrees(leftTree, rightTree, query.Diff.MaxNodes)
+	resp := &queryv1.Report{
+		Diff: &queryv1.DiffReport{
+			Query: query.Diff.CloneVT(),
+			Left:  leftTree.Bytes(0),
+			Right: rightTree.Bytes(0),
+		},
+	}
+	return resp, nil
+}
+
+// diffSideContext returns a copy of the query context with
+// the request matchers and time range set to the selector's.
+// If the selector is not specified, nil is returned.
+func diffSideContext(q *queryContext, s *queryv1.DiffSelector) (*queryContext, error) {
+	if s == nil {
+		return nil, nil
+	}
+	matchers, err := parser.ParseMetricSelector(s.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("label selection is invalid: %w", err)
+	}
+	b := *q.blockContext
+	b.req = &request{
+		src:        q.req.src,
+		matchers:   matchers,
+		startTime:  model.Time(s.StartTime).UnixNano(),
+		endTime:    model.Time(s.EndTime).UnixNano(),
+		tombstones: q.req.tombstones,
+	}
+	c := *q
+	c.blockContext = &b
+	return &c, nil
+}
+
+func diffSideTree(ctx context.Context, q *queryContext, resolver *symdb.Resolver) (tree *phlaremodel.Tree, err error) {
+	if q == nil {
+		return new(phlaremodel.Tree), nil
+	}
+	q.ctx = ctx
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	var columns v1.SampleColumns
+	if err = columns.Resolve(q.ds.Profiles().Schema()); err != nil {
+		return nil, err
+	}
+
+	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+
+	for profiles.Next() {
+		p := profiles.At()
+		resolver.AddSamplesFromParquetRow(p.Row.Partition, p.Values[0], p.Values[1])
+	}
+	if err = profiles.Err(); err != nil {
+		return nil, err
+	}
+
+	// The tree is not truncated here: truncation must
+	// take into account the nodes of the other side.
+	return resolver.Tree()
+}
+
+type diffAggregator struct {
+	init  sync.Once
+	query *queryv1.DiffQuery
+	left  *phlaremodel.TreeMerger
+	right *phlaremodel.TreeMerger
+}
+
+func newDiffAggregator(*queryv1.InvokeRequest) aggregator { return new(diffAggregator) }
+
+func (a *diffAggregator) aggregate(report *queryv1.Report) error {
+	r := report.Diff
+	a.init.Do(func() {
+		a.left = phlaremodel.NewTreeMerger()
+		a.right = phlaremodel.NewTreeMerger()
+		a.query = r.Query.CloneVT()
+	})
+	if err := a.left.MergeTreeBytes(r.Left); err != nil {
+		return err
+	}
+	return a.right.MergeTreeBytes(r.Right)
+}
+
+func (a *diffAggregator) build() *queryv1.Report {
+	left, right := a.left.Tree(), a.right.Tree()
+	phlaremodel.TruncateTrees(left, right, a.query.GetMaxNodes())
+	return &queryv1.Report{
+		Diff: &queryv1.DiffReport{
+			Query: a.query,
+			Left:  left.Bytes(0),
+			Right: right.Bytes(0),
+		},
+	}
+}
@@ -0,0 +1,151 @@
+package querybackend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/grafana/dskit/runutil"
+	"github.com/prometheus/common/model"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/tsdb/index"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_EXEMPLARS,
+		queryv1.ReportType_REPORT_EXEMPLARS,
+		queryExemplars,
+		newExemplarsAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+		}...,
+	)
+}
+
+func queryExemplars(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	entries, err := profileEntryIterator(q)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	schema := q.ds.Profiles().Schema()
+	valueColumn, err := schemav1.ResolveColumnByPath(schema, []string{"TotalValue"})
+	if err != nil {
+		return nil, err
+	}
+	idColumn, err := schemav1.ResolveColumnByPath(schema, []string{"ID"})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := parquetquery.NewRepeatedRowIteratorBatchSize(
+		q.ctx,
+		entries,
+		q.ds.Profiles().RowGroups(),
+		bigBatchSize,
+		valueColumn.ColumnIndex,
+		idColumn.ColumnIndex,
+	)
+	defer runutil.CloseWithErrCapture(&err, rows, "failed to close column iterator")
+
+	// The profile entries only include the group-by labels.
+	labels, err := seriesLabels(q)
+	if err != nil {
+		return nil, err
+	}
+
+	m := newExemplarsMerger(query.Exemplars)
+	for rows.Next() {
+		row := rows.At()
+		id, err := uuid.FromBytes(row.Values[1][0].ByteArray())
+		if err != nil {
+			return nil, err
+		}
+		m.Add(&typesv1.ProfileExemplar{
+			ProfileId: id.String(),
+			Timestamp: int64(row.Row.Timestamp),
+			Labels:    labels[row.Row.Fingerprint],
+			Value:     row.Values[0][0].Int64(),
+		})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &queryv1.Report{
+		Exemplars: &queryv1.ExemplarsReport{
+			Query:     query.Exemplars.CloneVT(),
+			Exemplars: m.Exemplars(),
+		},
+	}
+
+	return resp, nil
+}
+
+func newExemplarsMerger(query *queryv1.ExemplarsQuery) *phlaremodel.ExemplarsMerger {
+	step := time.Duration(query.GetStep() * float64(time.Second)).Milliseconds()
+	return phlaremodel.NewExemplarsMerger(query.GetStart(), step, int(query.GetLimit()))
+}
+
+// seriesLabels returns the labels of the matching series by fingerprint.
+func seriesLabels(q *queryContext) (map[model.Fingerprint]phlaremodel.Labels, error) {
+	reader := q.ds.Index()
+	postings, err := getPostings(reader, q.req.matchers...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = postings.Close()
+	}()
+	chunks := make([]index.ChunkMeta, 1)
+	ls := make(phlaremodel.Labels, 0, 6)
+	labels := make(map[model.Fingerprint]phlaremodel.Labels)
+	for postings.Next() {
+		fp, err := reader.Series(postings.At(), &ls, &chunks)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := labels[model.Fingerprint(fp)]; !ok {
+			labels[model.Fingerprint(fp)] = ls.Clone()
+		}
+	}
+	return labels, postings.Err()
+}
+
+type exemplarsAggregator struct {
+	init      sync.Once
+	query     *queryv1.ExemplarsQuery
+	exemplars *phlaremodel.ExemplarsMerger
+}
+
+func newExemplarsAggregator(*queryv1.InvokeRequest) aggregator {
+	return new(exemplarsAggregator)
+}
+
+func (a *exemplarsAggregator) aggregate(report *queryv1.Report) error {
+	r := report.Exemplars
+	a.init.Do(func() {
+		a.query = r.Query.CloneVT()
+		a.exemplars = newExemplarsMerger(a.query)
+	})
+	a.exemplars.MergeExemplars(r.Exemplars)
+	return nil
+}
+
+func (a *exemplarsAggregator) build() *queryv1.Report {
+	return &queryv1.Report{
+		Exemplars: &queryv1.ExemplarsReport{
+			Query:     a.query,
+			Exemplars: a.exemplars.Exemplars(),
+		},
+	}
+}
@@ -0,0 +1,157 @@
+package querybackend
+
+import (
+	"sync"
+	"time"
+
+	"github.com/grafana/dskit/runutil"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_FUNCTION_TIME_SERIES,
+		queryv1.ReportType_REPORT_FUNCTION_TIME_SERIES,
+		queryFunctionTimeSeries,
+		newFunctionTimeSeriesAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+			block.SectionSymbols,
+		}...,
+	)
+}
+
+func queryFunctionTimeSeries(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	functions := query.FunctionTimeSeries.Functions
+	if len(functions) == 0 {
+		return &queryv1.Report{
+			FunctionTimeSeries: &queryv1.FunctionTimeSeriesReport{
+				Query: query.FunctionTimeSeries.CloneVT(),
+			},
+		}, nil
+	}
+
+	entries, err := profileEntryIterator(q, query.FunctionTimeSeries.GroupBy...)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	var columns schemav1.SampleColumns
+	if err = columns.Resolve(q.ds.Profiles().Schema()); err != nil {
+		return nil, err
+	}
+
+	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(),
+		columns.StacktraceID.ColumnIndex,
+		columns.Value.ColumnIndex)
+	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
+
+	// Each function is selected as a single-frame call site: values
+	// of the location are accounted irrespectively of the call chain.
+	resolvers := make([]*symdb.Resolver, len(functions))
+	builders := make([]*phlaremodel.TimeSeriesBuilder, len(functions))
+	for i, fn := range functions {
+		resolvers[i] = symdb.NewResolver(q.ctx, q.ds.Symbols(),
+			symdb.WithResolverStackTraceSelector(&typesv1.StackTraceSelector{
+				CallSite: []*typesv1.Location{{Name: fn}},
+			}))
+		defer resolvers[i].Release()
+		builders[i] = phlaremodel.NewTimeSeriesBuilder(query.FunctionTimeSeries.GroupBy...)
+	}
+
+	var v symdb.CallSiteValues
+	for profiles.Next() {
+		row := profiles.At()
+		for i, resolver := range resolvers {
+			if err = resolver.CallSiteValuesParquet(&v, row.Row.Partition, row.Values[0], row.Values[1]); err != nil {
+				return nil, err
+			}
+			value := v.LocationTotal
+			if query.FunctionTimeSeries.Self {
+				value = v.LocationFlat
+			}
+			if value == 0 {
+				continue
+			}
+			builders[i].Add(
+				row.Row.Fingerprint,
+				row.Row.Labels,
+				int64(row.Row.Timestamp),
+				float64(value),
+				schemav1.Annotations{},
+			)
+		}
+	}
+	if err = profiles.Err(); err != nil {
+		return nil, err
+	}
+
+	var series []*typesv1.Series
+	for i, fn := range functions {
+		for _, s := range builders[i].Build() {
+			s.Labels = phlaremodel.Labels(s.Labels).InsertSorted(phlaremodel.LabelNameFunction, fn)
+			series = append(series, s)
+		}
+	}
+
+	resp := &queryv1.Report{
+		FunctionTimeSeries: &queryv1.FunctionTimeSeriesReport{
+			Query:      query.FunctionTimeSeries.CloneVT(),
+			TimeSeries: series,
+		},
+	}
+
+	return resp, nil
+}
+
+type functionTimeSeriesAggregator struct {
+	init      sync.Once
+	startTime int64
+	endTime   int64
+	query     *queryv1.FunctionTimeSeriesQuery
+	series    *phlaremodel.TimeSeriesMerger
+}
+
+func newFunctionTimeSeriesAggregator(req *queryv1.InvokeRequest) aggregator {
+	return &functionTimeSeriesAggregator{
+		startTime: req.StartTime,
+		endTime:   req.EndTime,
+	}
+}
+
+func (a *functionTimeSeriesAggregator) aggregate(report *queryv1.Report) error {
+	r := report.FunctionTimeSeries
+	a.init.Do(func() {
+		a.series = phlaremodel.NewTimeSeriesMerger(true)
+		a.query = r.Query.CloneVT()
+	})
+	a.series.MergeTimeSeries(r.TimeSeries)
+	return nil
+}
+
+func (a *functionTimeSeriesAggregator) build() *queryv1.Report {
+	sum := typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM
+	stepMilli := time.Duration(a.query.GetStep() * float64(time.Second)).Milliseconds()
+	seriesIterator := phlaremodel.NewTimeSeriesMergeIterator(a.series.TimeSeries())
+	return &queryv1.Report{
+		FunctionTimeSeries: &queryv1.FunctionTimeSeriesReport{
+			Query: a.query,
+			TimeSeries: phlaremodel.RangeSeries(
+				seriesIterator,
+				a.startTime,
+				a.endTime,
+				stepMilli,
+				&sum,
+			),
+		},
+	}
+}
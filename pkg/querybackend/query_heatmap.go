@@ -0,0 +1,129 @@
+package querybackend
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/grafana/dskit/runutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+	"github.com/grafana/pyroscope/pkg/block"
+	phlaremodel "github.com/grafana/pyroscope/pkg/model"
+	parquetquery "github.com/grafana/pyroscope/pkg/phlaredb/query"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+)
+
+func init() {
+	registerQueryType(
+		queryv1.QueryType_QUERY_HEATMAP,
+		queryv1.ReportType_REPORT_HEATMAP,
+		queryHeatmap,
+		newHeatmapAggregator,
+		[]block.Section{
+			block.SectionTSDB,
+			block.SectionProfiles,
+		}...,
+	)
+}
+
+func queryHeatmap(q *queryContext, query *queryv1.Query) (r *queryv1.Report, err error) {
+	entries, err := profileEntryIterator(q, query.Heatmap.GroupBy...)
+	if err != nil {
+		return nil, err
+	}
+	defer runutil.CloseWithErrCapture(&err, entries, "failed to close profile entry iterator")
+
+	column, err := schemav1.ResolveColumnByPath(q.ds.Profiles().Schema(), strings.Split("TotalValue", "."))
+	if err != nil {
+		return nil, err
+	}
+
+	rows := parquetquery.NewRepeatedRowIteratorBatchSize(
+		q.ctx,
+		entries,
+		q.ds.Profiles().RowGroups(),
+		bigBatchSize,
+		column.ColumnIndex,
+	)
+	defer runutil.CloseWithErrCapture(&err, rows, "failed to close column iterator")
+
+	builder := phlaremodel.NewTimeSeriesBuilder(query.Heatmap.GroupBy...)
+	var points int64
+	for rows.Next() {
+		// Fail early: all the points are retained until
+		// the heatmap is built by the query frontend.
+		if points++; exceedsHeatmapPoints(query.Heatmap, points) {
+			return nil, heatmapPointsLimitError(query.Heatmap)
+		}
+		row := rows.At()
+		builder.Add(
+			row.Row.Fingerprint,
+			row.Row.Labels,
+			int64(row.Row.Timestamp),
+			float64(row.Values[0][0].Int64()),
+			schemav1.Annotations{},
+		)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	resp := &queryv1.Report{
+		Heatmap: &queryv1.HeatmapReport{
+			Query:  query.Heatmap.CloneVT(),
+			Series: builder.Build(),
+		},
+	}
+
+	return resp, nil
+}
+
+func exceedsHeatmapPoints(q *queryv1.HeatmapQuery, points int64) bool {
+	return q.MaxPoints > 0 && points > q.MaxPoints
+}
+
+func heatmapPointsLimitError(q *queryv1.HeatmapQuery) error {
+	return status.Errorf(codes.InvalidArgument,
+		"heatmap query selects more than %d profiles, narrow down the time range or the label selector", q.MaxPoints)
+}
+
+type heatmapAggregator struct {
+	init   sync.Once
+	query  *queryv1.HeatmapQuery
+	series *phlaremodel.TimeSeriesMerger
+	points int64
+}
+
+func newHeatmapAggregator(*queryv1.InvokeRequest) aggregator {
+	return &heatmapAggregator{
+		// Points of individual profiles are retained.
+		series: phlaremodel.NewTimeSeriesMerger(false),
+	}
+}
+
+func (a *heatmapAggregator) aggregate(report *queryv1.Report) error {
+	r := report.Heatmap
+	a.init.Do(func() {
+		a.query = r.Query.CloneVT()
+	})
+	// The limit applies to the query as a whole.
+	for _, s := range r.Series {
+		a.points += int64(len(s.Points))
+	}
+	if exceedsHeatmapPoints(a.query, a.points) {
+		return heatmapPointsLimitError(a.query)
+	}
+	a.series.MergeTimeSeries(r.Series)
+	return nil
+}
+
+func (a *heatmapAggregator) build() *queryv1.Report {
+	return &queryv1.Report{
+		Heatmap: &queryv1.HeatmapReport{
+			Query:  a.query,
+			Series: a.series.TimeSeries(),
+		},
+	}
+}
@@ -4,12 +4,9 @@ import (
 	"sort"
 	"sync"
 
-	"github.com/prometheus/prometheus/model/labels"
-
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/model"
-	"github.com/grafana/pyroscope/pkg/phlaredb"
 )
 
 func init() {
@@ -25,10 +22,10 @@ func init() {
 func queryLabelNames(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
 	var names []string
 	var err error
-	if len(q.req.matchers) == 0 {
+	if len(q.req.matchers) == 0 && len(q.req.tombstones) == 0 {
 		names, err = q.ds.Index().LabelNames()
 	} else {
-		names, err = labelNamesForMatchers(q.ds.Index(), q.req.matchers)
+		names, err = labelNamesForMatchers(q)
 	}
 	if err != nil {
 		return nil, err
@@ -42,11 +39,13 @@ func queryLabelNames(q *queryContext, query *queryv1.Query) (*queryv1.Report, er
 	return resp, nil
 }
 
-func labelNamesForMatchers(reader phlaredb.IndexReader, matchers []*labels.Matcher) ([]string, error) {
-	postings, err := phlaredb.PostingsForMatchers(reader, nil, matchers...)
+func labelNamesForMatchers(q *queryContext) ([]string, error) {
+	reader := q.ds.Index()
+	postings, err := getPostings(reader, q.req.matchers...)
 	if err != nil {
 		return nil, err
 	}
+	postings = withoutDeletedSeries(q, postings)
 	l := make(map[string]struct{})
 	for postings.Next() {
 		var n []string
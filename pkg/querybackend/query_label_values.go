@@ -5,13 +5,11 @@ import (
 	"sort"
 	"sync"
 
-	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/model"
-	"github.com/grafana/pyroscope/pkg/phlaredb"
 )
 
 func init() {
@@ -27,10 +25,10 @@ func init() {
 func queryLabelValues(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
 	var values []string
 	var err error
-	if len(q.req.matchers) == 0 {
+	if len(q.req.matchers) == 0 && len(q.req.tombstones) == 0 {
 		values, err = q.ds.Index().LabelValues(query.LabelValues.LabelName)
 	} else {
-		values, err = labelValuesForMatchers(q.ds.Index(), query.LabelValues.LabelName, q.req.matchers)
+		values, err = labelValuesForMatchers(q, query.LabelValues.LabelName)
 	}
 	if err != nil {
 		return nil, err
@@ -44,11 +42,13 @@ func queryLabelValues(q *queryContext, query *queryv1.Query) (*queryv1.Report, e
 	return resp, nil
 }
 
-func labelValuesForMatchers(reader phlaredb.IndexReader, name string, matchers []*labels.Matcher) ([]string, error) {
-	postings, err := phlaredb.PostingsForMatchers(reader, nil, matchers...)
+func labelValuesForMatchers(q *queryContext, name string) ([]string, error) {
+	reader := q.ds.Index()
+	postings, err := getPostings(reader, q.req.matchers...)
 	if err != nil {
 		return nil, err
 	}
+	postings = withoutDeletedSeries(q, postings)
 	l := make(map[string]struct{})
 	for postings.Next() {
 		var v string
@@ -30,7 +30,7 @@ func init() {
 }
 
 func queryPprof(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
-	entries, err := profileEntryIterator(q)
+	entries, err := profileEntryIteratorWithIDs(q, query.Pprof.ProfileIdSelector)
 	if err != nil {
 		return nil, err
 	}
@@ -1,10 +1,15 @@
 package querybackend
 
 import (
+	"fmt"
+
+	"github.com/google/uuid"
 	"github.com/parquet-go/parquet-go"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
 
+	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/iter"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
 	"github.com/grafana/pyroscope/pkg/phlaredb"
@@ -23,15 +28,40 @@ type ProfileEntry struct {
 	Fingerprint model.Fingerprint
 	Labels      phlaremodel.Labels
 	Partition   uint64
+
+	// The profile matches a series tombstone,
+	// or does not match the profile ID selector.
+	skip bool
 }
 
 func (e ProfileEntry) RowNumber() int64 { return e.RowNum }
 
 func profileEntryIterator(q *queryContext, groupBy ...string) (iter.Iterator[ProfileEntry], error) {
+	return profileEntryIteratorWithIDs(q, nil, groupBy...)
+}
+
+// profileEntryIteratorWithIDs only selects the profiles with the
+// given IDs. If no IDs are specified, the filter is not applied.
+func profileEntryIteratorWithIDs(q *queryContext, ids []string, groupBy ...string) (iter.Iterator[ProfileEntry], error) {
+	var selected map[string]struct{}
+	if len(ids) > 0 {
+		selected = make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			u, err := uuid.Parse(id)
+			if err != nil {
+				return nil, fmt.Errorf("invalid profile id %q: %w", id, err)
+			}
+			selected[string(u[:])] = struct{}{}
+		}
+	}
 	series, err := getSeries(q.ds.Index(), q.req.matchers, groupBy...)
 	if err != nil {
 		return nil, err
 	}
+	deleted, err := deletedSeries(q, series)
+	if err != nil {
+		return nil, err
+	}
 	results := parquetquery.NewBinaryJoinIterator(0,
 		q.ds.Profiles().Column(q.ctx, "SeriesIndex", parquetquery.NewMapPredicate(series)),
 		q.ds.Profiles().Column(q.ctx, "TimeNanos", parquetquery.NewIntBetweenPredicate(q.req.startTime, q.req.endTime)),
@@ -39,29 +69,178 @@ func profileEntryIterator(q *queryContext, groupBy ...string) (iter.Iterator[Pro
 	results = parquetquery.NewBinaryJoinIterator(0, results,
 		q.ds.Profiles().Column(q.ctx, "StacktracePartition", nil),
 	)
+	if selected != nil {
+		// The profile ID is not used as a predicate: the join iterator
+		// does not support sparse matches of nested joins.
+		results = parquetquery.NewBinaryJoinIterator(0, results,
+			q.ds.Profiles().Column(q.ctx, "ID", nil),
+		)
+	}
 
-	buf := make([][]parquet.Value, 3)
+	buf := make([][]parquet.Value, 4)
 	entries := iter.NewAsyncBatchIterator[*parquetquery.IteratorResult, ProfileEntry](
 		results, bigBatchSize,
 		func(r *parquetquery.IteratorResult) ProfileEntry {
 			buf = r.Columns(buf,
 				schemav1.SeriesIndexColumnName,
 				schemav1.TimeNanosColumnName,
-				schemav1.StacktracePartitionColumnName)
+				schemav1.StacktracePartitionColumnName,
+				"ID")
 			x := series[buf[0][0].Uint32()]
-			return ProfileEntry{
+			e := ProfileEntry{
 				RowNum:      r.RowNumber[0],
 				Timestamp:   model.TimeFromUnixNano(buf[1][0].Int64()),
 				Fingerprint: x.fingerprint,
 				Labels:      x.labels,
 				Partition:   buf[2][0].Uint64(),
 			}
+			if selected != nil {
+				_, ok := selected[string(buf[3][0].ByteArray())]
+				e.skip = !ok
+			}
+			// The series labels have been matched already.
+			for _, t := range deleted[buf[0][0].Uint32()] {
+				if ts := buf[1][0].Int64(); t.Covers(ts, ts) {
+					e.skip = true
+					break
+				}
+			}
+			return e
 		},
 		func([]ProfileEntry) {},
 	)
+	if len(deleted) > 0 || selected != nil {
+		return &profileEntryFilter{Iterator: entries}, nil
+	}
 	return entries, nil
 }
 
+// deletedSeries excludes the series deleted within the query time range,
+// and returns the tombstones of the series deleted partially, by series
+// index. The series labels may only include the groupBy labels, therefore
+// the tombstones are matched against the series with all the labels.
+func deletedSeries(q *queryContext, selected map[uint32]series) (map[uint32][]*block.SeriesTombstone, error) {
+	if len(q.req.tombstones) == 0 {
+		return nil, nil
+	}
+	reader := q.ds.Index()
+	postings, err := getPostings(reader, q.req.matchers...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = postings.Close()
+	}()
+	partial := make(map[uint32][]*block.SeriesTombstone)
+	chunks := make([]index.ChunkMeta, 1)
+	ls := make(phlaremodel.Labels, 0, 6)
+	for postings.Next() {
+		if _, err = reader.Series(postings.At(), &ls, &chunks); err != nil {
+			return nil, err
+		}
+		i := chunks[0].SeriesIndex
+		if _, ok := selected[i]; !ok {
+			continue
+		}
+		for _, t := range q.req.tombstones {
+			if !t.MatchesLabels(ls) {
+				continue
+			}
+			if t.Covers(q.req.startTime, q.req.endTime) {
+				delete(selected, i)
+				delete(partial, i)
+				break
+			}
+			partial[i] = append(partial[i], t)
+		}
+	}
+	return partial, postings.Err()
+}
+
+// withoutDeletedSeries skips the series deleted within the query time
+// range. Series deleted partially are retained, as some of their profiles
+// are still selected. It is used by the queries that only read the index.
+func withoutDeletedSeries(q *queryContext, postings index.Postings) index.Postings {
+	var tombstones []*block.SeriesTombstone
+	for _, t := range q.req.tombstones {
+		if t.Covers(q.req.startTime, q.req.endTime) {
+			tombstones = append(tombstones, t)
+		}
+	}
+	if len(tombstones) == 0 {
+		return postings
+	}
+	return &deletedSeriesFilter{
+		Postings:   postings,
+		reader:     q.ds.Index(),
+		tombstones: tombstones,
+		labels:     make(phlaremodel.Labels, 0, 6),
+		chunks:     make([]index.ChunkMeta, 1),
+	}
+}
+
+type deletedSeriesFilter struct {
+	index.Postings
+	reader     phlaredb.IndexReader
+	tombstones []*block.SeriesTombstone
+	labels     phlaremodel.Labels
+	chunks     []index.ChunkMeta
+	err        error
+}
+
+func (f *deletedSeriesFilter) Next() bool {
+	for f.Postings.Next() {
+		if !f.deleted() {
+			return f.err == nil
+		}
+	}
+	return false
+}
+
+func (f *deletedSeriesFilter) Seek(v storage.SeriesRef) bool {
+	if !f.Postings.Seek(v) {
+		return false
+	}
+	if !f.deleted() {
+		return f.err == nil
+	}
+	return f.Next()
+}
+
+func (f *deletedSeriesFilter) deleted() bool {
+	if _, f.err = f.reader.Series(f.At(), &f.labels, &f.chunks); f.err != nil {
+		return false
+	}
+	for _, t := range f.tombstones {
+		if t.MatchesLabels(f.labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *deletedSeriesFilter) Err() error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.Postings.Err()
+}
+
+// profileEntryFilter skips profiles of the deleted series,
+// and profiles not matching the profile ID selector.
+type profileEntryFilter struct {
+	iter.Iterator[ProfileEntry]
+}
+
+func (f *profileEntryFilter) Next() bool {
+	for f.Iterator.Next() {
+		if !f.At().skip {
+			return true
+		}
+	}
+	return false
+}
+
 type series struct {
 	fingerprint model.Fingerprint
 	labels      phlaremodel.Labels
@@ -5,14 +5,12 @@ import (
 	"slices"
 	"sync"
 
-	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/storage"
 
 	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	phlaremodel "github.com/grafana/pyroscope/pkg/model"
-	"github.com/grafana/pyroscope/pkg/phlaredb"
 )
 
 func init() {
@@ -26,7 +24,7 @@ func init() {
 }
 
 func querySeriesLabels(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
-	series, err := getSeriesLabels(q.ds.Index(), q.req.matchers, query.SeriesLabels.LabelNames...)
+	series, err := getSeriesLabels(q, query.SeriesLabels.LabelNames...)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +66,8 @@ func (a *seriesLabelsAggregator) build() *queryv1.Report {
 	}
 }
 
-func getSeriesLabels(reader phlaredb.IndexReader, matchers []*labels.Matcher, by ...string) ([]*typesv1.Labels, error) {
+func getSeriesLabels(q *queryContext, by ...string) ([]*typesv1.Labels, error) {
+	reader := q.ds.Index()
 	names, err := reader.LabelNames()
 	if err != nil {
 		return nil, err
@@ -87,10 +86,11 @@ func getSeriesLabels(reader phlaredb.IndexReader, matchers []*labels.Matcher, by
 		}
 	}
 
-	postings, err := getPostings(reader, matchers...)
+	postings, err := getPostings(reader, q.req.matchers...)
 	if err != nil {
 		return nil, err
 	}
+	postings = withoutDeletedSeries(q, postings)
 
 	visited := make(map[uint64]struct{})
 	sets := make([]*typesv1.Labels, 0, 32)
@@ -111,7 +111,8 @@ func newTimeSeriesAggregator(req *queryv1.InvokeRequest) aggregator {
 func (a *timeSeriesAggregator) aggregate(report *queryv1.Report) error {
 	r := report.TimeSeries
 	a.init.Do(func() {
-		a.series = phlaremodel.NewTimeSeriesMerger(true)
+		aggregation := r.Query.GetAggregation()
+		a.series = phlaremodel.NewTimeSeriesMerger(phlaremodel.IsSumAggregation(&aggregation))
 		a.query = r.Query.CloneVT()
 	})
 	a.series.MergeTimeSeries(r.TimeSeries)
@@ -119,10 +120,17 @@ func (a *timeSeriesAggregator) aggregate(report *queryv1.Report) error {
 }
 
 func (a *timeSeriesAggregator) build() *queryv1.Report {
-	// TODO(kolesnikovae): Average aggregation should be implemented in
-	//  the way that it can be distributed (count + sum), and should be done
-	//  at "aggregate" call.
-	sum := typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM
+	aggregation, ok := partialTimeSeriesAggregation(a.query.GetAggregation())
+	if !ok {
+		// The points can't be aggregated partially: the
+		// query frontend aggregates the points in steps.
+		return &queryv1.Report{
+			TimeSeries: &queryv1.TimeSeriesReport{
+				Query:      a.query,
+				TimeSeries: a.series.TimeSeries(),
+			},
+		}
+	}
 	stepMilli := time.Duration(a.query.GetStep() * float64(time.Second)).Milliseconds()
 	seriesIterator := phlaremodel.NewTimeSeriesMergeIterator(a.series.TimeSeries())
 	return &queryv1.Report{
@@ -133,8 +141,24 @@ func (a *timeSeriesAggregator) build() *queryv1.Report {
 				a.startTime,
 				a.endTime,
 				stepMilli,
-				&sum,
+				&aggregation,
 			),
 		},
 	}
 }
+
+// partialTimeSeriesAggregation returns the aggregation that can be applied
+// to partial results without affecting the final result: the rate is
+// calculated from the sum in the query frontend, for example. If the
+// points can't be aggregated partially (e.g., quantiles), false is returned.
+func partialTimeSeriesAggregation(t typesv1.TimeSeriesAggregationType) (typesv1.TimeSeriesAggregationType, bool) {
+	switch t {
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM,
+		typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_RATE:
+		return typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_SUM, true
+	case typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MIN,
+		typesv1.TimeSeriesAggregationType_TIME_SERIES_AGGREGATION_TYPE_MAX:
+		return t, true
+	}
+	return t, false
+}
@@ -55,8 +55,12 @@ func queryTree(q *queryContext, query *queryv1.Query) (*queryv1.Report, error) {
 	profiles := parquetquery.NewRepeatedRowIterator(q.ctx, entries, q.ds.Profiles().RowGroups(), indices...)
 	defer runutil.CloseWithErrCapture(&err, profiles, "failed to close profile stream")
 
-	resolver := symdb.NewResolver(q.ctx, q.ds.Symbols(),
-		symdb.WithResolverMaxNodes(query.Tree.GetMaxNodes()))
+	resolverOptions := []symdb.ResolverOption{symdb.WithResolverMaxNodes(query.Tree.GetMaxNodes())}
+	if query.Tree.StackTraceSelector != nil {
+		resolverOptions = append(resolverOptions, symdb.WithResolverStackTraceSelector(query.Tree.StackTraceSelector))
+	}
+
+	resolver := symdb.NewResolver(q.ctx, q.ds.Symbols(), resolverOptions...)
 	defer resolver.Release()
 
 	if len(spanSelector) > 0 {
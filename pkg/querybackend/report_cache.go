@@ -0,0 +1,311 @@
+package querybackend
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dgraph-io/ristretto/v2"
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/cache"
+	"github.com/prometheus/client_golang/prometheus"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+)
+
+const (
+	ReportCacheBackendInMemory  = "inmemory"
+	ReportCacheBackendMemcached = cache.BackendMemcached
+
+	reportCacheName      = "query-backend-report-cache"
+	reportCacheKeyPrefix = "qb-report:"
+)
+
+// ReportCacheConfig configures the cache of the query reports of
+// individual blocks. Blocks are immutable, therefore the reports
+// can be reused by subsequent queries.
+type ReportCacheConfig struct {
+	Backend   string                      `yaml:"backend"`
+	TTL       time.Duration               `yaml:"ttl"`
+	InMemory  InMemoryCacheConfig         `yaml:"inmemory"`
+	Memcached cache.MemcachedClientConfig `yaml:"memcached"`
+}
+
+type InMemoryCacheConfig struct {
+	MaxSizeBytes int64 `yaml:"max_size_bytes"`
+	MaxItems     int64 `yaml:"max_items"`
+	MaxItemSize  int   `yaml:"max_item_size"`
+}
+
+func (cfg *ReportCacheConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&cfg.Backend, prefix+"backend", "", fmt.Sprintf("Backend of the block report cache. Supported values: %s, %s. If empty, the cache is disabled.", ReportCacheBackendInMemory, ReportCacheBackendMemcached))
+	f.DurationVar(&cfg.TTL, prefix+"ttl", time.Hour, "How long the block reports are kept in the cache.")
+	f.Int64Var(&cfg.InMemory.MaxSizeBytes, prefix+"inmemory.max-size-bytes", 256<<20, "Maximum size of the in-memory cache, in bytes.")
+	f.Int64Var(&cfg.InMemory.MaxItems, prefix+"inmemory.max-items", 64<<10, "Expected maximum number of items in the in-memory cache.")
+	f.IntVar(&cfg.InMemory.MaxItemSize, prefix+"inmemory.max-item-size", 1<<20, "Maximum size of an item stored in the in-memory cache, in bytes. Bigger items are not stored. If set to 0, no maximum size is enforced.")
+	cfg.Memcached.RegisterFlagsWithPrefix(prefix+"memcached.", f)
+}
+
+func (cfg *ReportCacheConfig) Validate() error {
+	switch cfg.Backend {
+	case "":
+		return nil
+	case ReportCacheBackendInMemory:
+		if cfg.InMemory.MaxSizeBytes <= 0 || cfg.InMemory.MaxItems <= 0 {
+			return fmt.Errorf("in-memory report cache size must be positive")
+		}
+	case ReportCacheBackendMemcached:
+		return cfg.Memcached.Validate()
+	default:
+		return fmt.Errorf("unsupported report cache backend: %s", cfg.Backend)
+	}
+	return nil
+}
+
+// ReportCache stores the query reports of individual blocks.
+type ReportCache struct {
+	logger  log.Logger
+	cache   cache.Cache
+	ttl     time.Duration
+	metrics *reportCacheMetrics
+}
+
+// NewReportCache creates the block report cache.
+// If the cache is disabled, nil is returned.
+func NewReportCache(cfg ReportCacheConfig, logger log.Logger, reg prometheus.Registerer) (*ReportCache, error) {
+	var c cache.Cache
+	switch cfg.Backend {
+	case "":
+		return nil, nil
+	case ReportCacheBackendInMemory:
+		var err error
+		if c, err = newInMemoryCache(cfg.InMemory); err != nil {
+			return nil, err
+		}
+	default:
+		var err error
+		if c, err = cache.CreateClient(reportCacheName, cache.BackendConfig{
+			Backend:   cfg.Backend,
+			Memcached: cfg.Memcached,
+		}, logger, reg); err != nil {
+			return nil, err
+		}
+	}
+	return newReportCache(c, cfg.TTL, logger, reg), nil
+}
+
+func newReportCache(c cache.Cache, ttl time.Duration, logger log.Logger, reg prometheus.Registerer) *ReportCache {
+	return &ReportCache{
+		logger:  logger,
+		cache:   c,
+		ttl:     ttl,
+		metrics: newReportCacheMetrics(reg),
+	}
+}
+
+// fetch returns the cached reports of the queries. Queries that
+// can't be served from the cache are absent in the result.
+func (c *ReportCache) fetch(ctx context.Context, keys map[*queryv1.Query]string) map[*queryv1.Query][]*queryv1.Report {
+	lookup := make([]string, 0, len(keys))
+	for _, k := range keys {
+		lookup = append(lookup, k)
+	}
+	found := c.cache.Fetch(ctx, lookup)
+	reports := make(map[*queryv1.Query][]*queryv1.Report, len(found))
+	for q, k := range keys {
+		b, ok := found[k]
+		if ok {
+			var resp queryv1.InvokeResponse
+			if err := resp.UnmarshalVT(b); err != nil {
+				level.Warn(c.logger).Log("msg", "failed to decode cached report", "key", k, "err", err)
+				ok = false
+			} else {
+				reports[q] = resp.Reports
+			}
+		}
+		if ok {
+			c.metrics.requests.WithLabelValues("hit").Inc()
+		} else {
+			c.metrics.requests.WithLabelValues("miss").Inc()
+		}
+	}
+	return reports
+}
+
+func (c *ReportCache) store(keys map[*queryv1.Query]string, reports map[*queryv1.Query][]*queryv1.Report) {
+	data := make(map[string][]byte, len(keys))
+	for q, k := range keys {
+		b, err := (&queryv1.InvokeResponse{Reports: reports[q]}).MarshalVT()
+		if err != nil {
+			level.Warn(c.logger).Log("msg", "failed to encode report", "key", k, "err", err)
+			continue
+		}
+		data[k] = b
+	}
+	c.cache.StoreAsync(data, c.ttl)
+}
+
+// reportCacheKey returns the cache key of the query report of the block.
+// The key is built of the block ID and the query, normalized to the block:
+// the time range is replaced with the block time range, therefore the
+// report can be reused as long as the block is entirely covered by the
+// query time range. Otherwise, the query is not eligible for caching.
+func reportCacheKey(req *queryv1.InvokeRequest, md *metastorev1.BlockMeta, query *queryv1.Query) (string, bool) {
+	// The block time range is in milliseconds, while profile timestamps
+	// are in nanoseconds: the end of the range is exclusive to ensure
+	// that the last millisecond of the block is covered entirely.
+	if req.StartTime > md.MinTime || req.EndTime <= md.MaxTime {
+		return "", false
+	}
+	normalized := &queryv1.InvokeRequest{
+		Tenant:           slices.Sorted(slices.Values(req.Tenant)),
+		StartTime:        md.MinTime,
+		EndTime:          md.MaxTime,
+		LabelSelector:    req.LabelSelector,
+		Query:            []*queryv1.Query{query},
+		SeriesTombstones: req.SeriesTombstones,
+	}
+	if query.QueryType == queryv1.QueryType_QUERY_PPROF {
+		// The profile timestamp is set to the query end time.
+		normalized.EndTime = req.EndTime
+	}
+	b, err := normalized.MarshalVT()
+	if err != nil {
+		return "", false
+	}
+	h := sha256.New()
+	_, _ = h.Write(b)
+	// The datasets to be queried are selected by the metastore.
+	for _, ds := range md.Datasets {
+		_, _ = fmt.Fprintf(h, "%d:%s:%s;", ds.Format, md.StringTable[ds.Tenant], md.StringTable[ds.Name])
+	}
+	return reportCacheKeyPrefix + md.Id + ":" + hex.EncodeToString(h.Sum(nil)), true
+}
+
+// blockReports collects the reports of the block queries to be cached.
+type blockReports struct {
+	keys map[*queryv1.Query]string
+
+	mu      sync.Mutex
+	reports map[*queryv1.Query][]*queryv1.Report
+	// The block or some of its datasets were not found:
+	// the reports are incomplete and must not be cached.
+	partial bool
+}
+
+func (r *blockReports) add(query *queryv1.Query, report *queryv1.Report) {
+	if r == nil {
+		return
+	}
+	if _, ok := r.keys[query]; !ok {
+		return
+	}
+	// The report is cloned as the aggregator may modify it.
+	c := report.CloneVT()
+	r.mu.Lock()
+	r.reports[query] = append(r.reports[query], c)
+	r.mu.Unlock()
+}
+
+func (r *blockReports) setPartial() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	r.partial = true
+	r.mu.Unlock()
+}
+
+// fetchCachedReports aggregates the cached reports of the block
+// queries and returns the queries that have to be executed.
+func (b *blockContext) fetchCachedReports() ([]*queryv1.Query, error) {
+	keys := make(map[*queryv1.Query]string, len(b.req.src.Query))
+	for _, query := range b.req.src.Query {
+		if k, ok := reportCacheKey(b.req.src, b.obj.Metadata(), query); ok {
+			keys[query] = k
+		}
+	}
+	if len(keys) == 0 {
+		return b.req.src.Query, nil
+	}
+	cached := b.cache.fetch(b.ctx, keys)
+	queries := make([]*queryv1.Query, 0, len(b.req.src.Query))
+	for _, query := range b.req.src.Query {
+		reports, ok := cached[query]
+		if !ok {
+			queries = append(queries, query)
+			continue
+		}
+		delete(keys, query)
+		for _, r := range reports {
+			if err := b.agg.aggregateReport(r); err != nil {
+				return nil, err
+			}
+		}
+	}
+	b.reports = &blockReports{
+		keys:    keys,
+		reports: make(map[*queryv1.Query][]*queryv1.Report, len(keys)),
+	}
+	return queries, nil
+}
+
+// storeReports stores the reports of the executed
+// queries in the cache, if the results are complete.
+func (b *blockContext) storeReports() {
+	if b.reports == nil || b.reports.partial || len(b.reports.keys) == 0 {
+		return
+	}
+	b.cache.store(b.reports.keys, b.reports.reports)
+}
+
+// inMemoryCache is an in-process cache.Cache backed by ristretto.
+type inMemoryCache struct {
+	cache       *ristretto.Cache[string, []byte]
+	maxItemSize int
+}
+
+func newInMemoryCache(cfg InMemoryCacheConfig) (*inMemoryCache, error) {
+	c, err := ristretto.NewCache(&ristretto.Config[string, []byte]{
+		NumCounters: cfg.MaxItems * 10,
+		MaxCost:     cfg.MaxSizeBytes,
+		BufferItems: 64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-memory cache: %w", err)
+	}
+	return &inMemoryCache{cache: c, maxItemSize: cfg.MaxItemSize}, nil
+}
+
+func (c *inMemoryCache) StoreAsync(data map[string][]byte, ttl time.Duration) {
+	for k, v := range data {
+		if c.maxItemSize > 0 && len(v) > c.maxItemSize {
+			continue
+		}
+		c.cache.SetWithTTL(k, v, int64(len(v)), ttl)
+	}
+}
+
+func (c *inMemoryCache) Fetch(_ context.Context, keys []string, _ ...cache.Option) map[string][]byte {
+	found := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		if v, ok := c.cache.Get(k); ok {
+			found[k] = v
+		}
+	}
+	return found
+}
+
+func (c *inMemoryCache) Delete(_ context.Context, key string) error {
+	c.cache.Del(key)
+	return nil
+}
+
+func (c *inMemoryCache) Name() string { return reportCacheName }
@@ -0,0 +1,81 @@
+package querybackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	queryv1 "github.com/grafana/pyroscope/api/gen/proto/go/query/v1"
+)
+
+func Test_reportCacheKey(t *testing.T) {
+	md := &metastorev1.BlockMeta{
+		Id:          "01HZ0000000000000000000000",
+		MinTime:     1000,
+		MaxTime:     2000,
+		StringTable: []string{"", "tenant-a", "service-a"},
+		Datasets:    []*metastorev1.Dataset{{Tenant: 1, Name: 2}},
+	}
+	tree := &queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_TREE,
+		Tree:      &queryv1.TreeQuery{MaxNodes: 16},
+	}
+	request := func(start, end int64, query *queryv1.Query) *queryv1.InvokeRequest {
+		return &queryv1.InvokeRequest{
+			Tenant:        []string{"tenant-a"},
+			StartTime:     start,
+			EndTime:       end,
+			LabelSelector: `{service_name="service-a"}`,
+			Query:         []*queryv1.Query{query},
+		}
+	}
+
+	k1, ok := reportCacheKey(request(0, 3000, tree), md, tree)
+	require.True(t, ok)
+	k2, ok := reportCacheKey(request(1000, 5000, tree), md, tree)
+	require.True(t, ok)
+	assert.Equal(t, k1, k2, "the key must not depend on the query time range")
+
+	_, ok = reportCacheKey(request(1500, 3000, tree), md, tree)
+	assert.False(t, ok, "the block is not covered by the query")
+	_, ok = reportCacheKey(request(0, 2000, tree), md, tree)
+	assert.False(t, ok, "the block is not covered by the query")
+
+	other := tree.CloneVT()
+	other.Tree.MaxNodes = 32
+	k3, ok := reportCacheKey(request(0, 3000, other), md, other)
+	require.True(t, ok)
+	assert.NotEqual(t, k1, k3)
+
+	pprof := &queryv1.Query{
+		QueryType: queryv1.QueryType_QUERY_PPROF,
+		Pprof:     &queryv1.PprofQuery{MaxNodes: 16},
+	}
+	k4, ok := reportCacheKey(request(0, 3000, pprof), md, pprof)
+	require.True(t, ok)
+	k5, ok := reportCacheKey(request(0, 4000, pprof), md, pprof)
+	require.True(t, ok)
+	assert.NotEqual(t, k4, k5, "the pprof key depends on the query end time")
+}
+
+func Test_inMemoryCache(t *testing.T) {
+	c, err := newInMemoryCache(InMemoryCacheConfig{
+		MaxSizeBytes: 1 << 20,
+		MaxItems:     100,
+		MaxItemSize:  4,
+	})
+	require.NoError(t, err)
+
+	c.StoreAsync(map[string][]byte{
+		"a": []byte("foo"),
+		"b": []byte("too large"),
+	}, 0)
+	c.cache.Wait()
+
+	assert.Equal(t, map[string][]byte{"a": []byte("foo")}, c.Fetch(context.Background(), []string{"a", "b", "c"}))
+	require.NoError(t, c.Delete(context.Background(), "a"))
+	assert.Empty(t, c.Fetch(context.Background(), []string{"a"}))
+}
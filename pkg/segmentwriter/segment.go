@@ -26,6 +26,7 @@ import (
 
 	profilev1 "github.com/grafana/pyroscope/api/gen/proto/go/google/v1"
 	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	segmentwriterv1 "github.com/grafana/pyroscope/api/gen/proto/go/segmentwriter/v1"
 	typesv1 "github.com/grafana/pyroscope/api/gen/proto/go/types/v1"
 	"github.com/grafana/pyroscope/pkg/block"
 	"github.com/grafana/pyroscope/pkg/block/metadata"
@@ -44,6 +45,8 @@ type segmentsWriter struct {
 	logger    log.Logger
 	bucket    objstore.Bucket
 	metastore metastorev1.IndexServiceClient
+	wal       *wal
+	walRetry  sync.WaitGroup
 
 	shards     map[shardKey]*shard
 	shardsLock sync.RWMutex
@@ -172,6 +175,7 @@ func (sw *segmentsWriter) ingest(shard shardKey, fn func(head segmentIngest)) (a
 func (sw *segmentsWriter) stop() {
 	sw.logger.Log("msg", "stopping segments writer")
 	sw.cancel()
+	sw.walRetry.Wait()
 	sw.shardsLock.Lock()
 	defer sw.shardsLock.Unlock()
 	for _, s := range sw.shards {
@@ -210,6 +214,9 @@ func (sw *segmentsWriter) newSegment(sh *shard, sk shardKey, sl log.Logger) *seg
 		sshard:   sshard,
 		doneChan: make(chan struct{}),
 	}
+	if sw.wal != nil {
+		s.wal = sw.wal.segment(sk, id)
+	}
 	return s
 }
 
@@ -231,6 +238,12 @@ func (s *segment) flush(ctx context.Context) (err error) {
 		close(s.doneChan)
 		s.sw.metrics.flushSegmentDuration.WithLabelValues(s.sshard).Observe(time.Since(t1).Seconds())
 	}()
+	if s.wal != nil {
+		syncErr := s.wal.sync()
+		defer func() {
+			err = s.releaseWAL(err, syncErr)
+		}()
+	}
 
 	stream := s.flushHeads(ctx)
 	s.debuginfo.movedHeads = len(stream.heads)
@@ -253,6 +266,24 @@ func (s *segment) flush(ctx context.Context) (err error) {
 	return nil
 }
 
+// releaseWAL removes the segment WAL file if the segment has been
+// flushed successfully. Otherwise, if all the segment profiles have
+// been recorded, the file is retained to be replayed later, and the
+// flush error is not propagated to the clients.
+func (s *segment) releaseWAL(err, syncErr error) error {
+	if err == nil {
+		s.wal.remove()
+		return nil
+	}
+	if syncErr != nil || !s.wal.durable() {
+		return err
+	}
+	level.Warn(s.logger).Log("msg", "segment flush failed; retaining WAL file", "path", s.wal.path, "err", err)
+	s.sw.metrics.walRetainedSegments.Inc()
+	s.sw.wal.retain(s.wal.walFile())
+	return nil
+}
+
 func (s *segment) flushBlock(stream flushStream) ([]byte, *metastorev1.BlockMeta, error) {
 	start := time.Now()
 	hostname, _ := os.Hostname()
@@ -487,6 +518,8 @@ type segment struct {
 	flushErr      error
 	flushErrMutex sync.Mutex
 
+	wal *walSegment
+
 	debuginfo struct {
 		movedHeads         int
 		waitInflight       time.Duration
@@ -500,6 +533,7 @@ type segment struct {
 }
 
 type segmentIngest interface {
+	record(req *segmentwriterv1.PushRequest)
 	ingest(tenantID string, p *profilev1.Profile, id uuid.UUID, labels []*typesv1.LabelPair, annotations []*typesv1.ProfileAnnotation)
 }
 
@@ -519,6 +553,13 @@ func (s *segment) waitFlushed(ctx context.Context) error {
 	}
 }
 
+// record appends the push request to the segment WAL, if enabled.
+func (s *segment) record(req *segmentwriterv1.PushRequest) {
+	if s.wal != nil {
+		s.wal.append(req)
+	}
+}
+
 func (s *segment) ingest(tenantID string, p *profilev1.Profile, id uuid.UUID, labels []*typesv1.LabelPair, annotations []*typesv1.ProfileAnnotation) {
 	// TODO(kolesnikovae): Refactor: profile split should be moved inside the
 	//   dataset.Ingest: we want to do it together with / instead of creation
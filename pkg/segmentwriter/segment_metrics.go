@@ -20,6 +20,14 @@ type segmentMetrics struct {
 	flushHeadsDuration          *prometheus.HistogramVec
 	flushServiceHeadDuration    *prometheus.HistogramVec
 	flushServiceHeadError       *prometheus.CounterVec
+
+	walAppendedRecords  prometheus.Counter
+	walAppendErrors     *prometheus.CounterVec
+	walSizeBytes        prometheus.Gauge
+	walRetainedSegments prometheus.Counter
+	walReplayedRecords  prometheus.Counter
+	walDroppedRecords   prometheus.Counter
+	walDroppedFiles     *prometheus.CounterVec
 }
 
 var (
@@ -130,6 +138,49 @@ func newSegmentMetrics(reg prometheus.Registerer) *segmentMetrics {
 				Name:      "segment_head_size_bytes",
 				Buckets:   prometheus.ExponentialBucketsRange(10*1024, 100*1024*1024, 30),
 			}, []string{"shard", "tenant"}),
+
+		walAppendedRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_appended_records_total",
+			Help:      "Number of push requests recorded in the WAL.",
+		}),
+		walAppendErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_append_errors_total",
+			Help:      "Number of push requests that could not be recorded in the WAL.",
+		}, []string{"reason"}),
+		walSizeBytes: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_size_bytes",
+			Help:      "Total size of the WAL files.",
+		}),
+		walRetainedSegments: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_retained_segments_total",
+			Help:      "Number of segments that failed to flush and were retained in the WAL.",
+		}),
+		walReplayedRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_replayed_records_total",
+			Help:      "Number of push requests replayed from the WAL.",
+		}),
+		walDroppedRecords: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_dropped_records_total",
+			Help:      "Number of WAL records that could not be replayed.",
+		}),
+		walDroppedFiles: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "pyroscope",
+			Subsystem: "segment_writer",
+			Name:      "wal_dropped_files_total",
+			Help:      "Number of WAL files dropped entirely or partially.",
+		}, []string{"reason"}),
 	}
 
 	if reg != nil {
@@ -146,6 +197,13 @@ func newSegmentMetrics(reg prometheus.Registerer) *segmentMetrics {
 		reg.MustRegister(m.flushServiceHeadError)
 		reg.MustRegister(m.flushSegmentDuration)
 		reg.MustRegister(m.headSizeBytes)
+		reg.MustRegister(m.walAppendedRecords)
+		reg.MustRegister(m.walAppendErrors)
+		reg.MustRegister(m.walSizeBytes)
+		reg.MustRegister(m.walRetainedSegments)
+		reg.MustRegister(m.walReplayedRecords)
+		reg.MustRegister(m.walDroppedRecords)
+		reg.MustRegister(m.walDroppedFiles)
 	}
 	return m
 }
@@ -513,8 +513,13 @@ type sw struct {
 }
 
 func newTestSegmentWriter(t *testing.T, cfg Config) sw {
+	return newTestSegmentWriterWithBucket(t, cfg, memory.NewInMemBucket())
+}
+
+// newTestSegmentWriterWithBucket creates a segment writer with the
+// given bucket, which may be shared with writers created earlier.
+func newTestSegmentWriterWithBucket(t *testing.T, cfg Config, bucket *memory.InMemBucket) sw {
 	l := test.NewTestingLogger(t)
-	bucket := memory.NewInMemBucket()
 	client := mockmetastorev1.NewMockIndexServiceClient(t)
 	res := newSegmentWriter(
 		l,
@@ -545,7 +550,7 @@ func defaultTestConfig() Config {
 func (sw *sw) createBlocksFromMetas(blocks []*metastorev1.BlockMeta) tenantClients {
 	dir := sw.t.TempDir()
 	for _, meta := range blocks {
-		blobReader, err := sw.bucket.Get(context.Background(), block.ObjectPath(meta))
+		blobReader, err := sw.segmentsWriter.bucket.Get(context.Background(), block.ObjectPath(meta))
 		require.NoError(sw.t, err)
 		blob, err := io.ReadAll(blobReader)
 		require.NoError(sw.t, err)
@@ -54,6 +54,7 @@ type Config struct {
 	UploadHedgeRateBurst  uint                  `yaml:"upload-hedge_rate_burst,omitempty" category:"advanced"`
 	MetadataDLQEnabled    bool                  `yaml:"metadata_dlq_enabled,omitempty" category:"advanced"`
 	MetadataUpdateTimeout time.Duration         `yaml:"metadata_update_timeout,omitempty" category:"advanced"`
+	WAL                   WALConfig             `yaml:"wal"`
 }
 
 func (cfg *Config) Validate() error {
@@ -61,6 +62,9 @@ func (cfg *Config) Validate() error {
 	if err := cfg.LifecyclerConfig.Validate(); err != nil {
 		return err
 	}
+	if err := cfg.WAL.Validate(); err != nil {
+		return err
+	}
 	return cfg.GRPCClientConfig.Validate()
 }
 
@@ -79,6 +83,7 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.UintVar(&cfg.UploadHedgeRateBurst, prefix+".upload-hedge-rate-burst", defaultHedgedRequestBurst, "Maximum number of hedged requests in a burst.")
 	f.BoolVar(&cfg.MetadataDLQEnabled, prefix+".metadata-dlq-enabled", true, "Enables dead letter queue (DLQ) for metadata. If the metadata update fails, it will be stored and updated asynchronously.")
 	f.DurationVar(&cfg.MetadataUpdateTimeout, prefix+".metadata-update-timeout", 2*time.Second, "Timeout for metadata update requests.")
+	cfg.WAL.RegisterFlagsWithPrefix(prefix+".wal.", f)
 }
 
 type Limits interface {
@@ -159,6 +164,11 @@ func (i *SegmentWriterService) starting(ctx context.Context) error {
 	if err := services.StartManagerAndAwaitHealthy(ctx, i.subservices); err != nil {
 		return err
 	}
+	// Profiles retained in the WAL are replayed before
+	// the instance starts accepting new requests.
+	if err := i.segmentWriter.openWAL(ctx); err != nil {
+		return err
+	}
 	// The instance is ready to handle incoming requests.
 	// We do not have to wait for the lifecycler: its readiness check
 	// is only used to limit the number of instances that can be coming
@@ -210,6 +220,7 @@ func (i *SegmentWriterService) Push(ctx context.Context, req *segmentwriterv1.Pu
 	}
 
 	wait := i.segmentWriter.ingest(shardKey(req.Shard), func(segment segmentIngest) {
+		segment.record(req)
 		segment.ingest(req.TenantId, p.Profile, id, req.Labels, req.Annotations)
 	})
 
@@ -0,0 +1,452 @@
+package segmentwriter
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/google/uuid"
+	"github.com/oklog/ulid"
+
+	segmentwriterv1 "github.com/grafana/pyroscope/api/gen/proto/go/segmentwriter/v1"
+	"github.com/grafana/pyroscope/pkg/pprof"
+)
+
+// The write-ahead log (WAL) records the push requests of a segment in a
+// local file before they are ingested. The file is removed once the
+// segment is flushed to the object storage and its metadata is stored.
+// If the segment cannot be flushed, the file is retained and the push is
+// acknowledged: the retained files are replayed periodically, and on
+// startup, if the writer has been stopped before.
+//
+// WAL files are located at <dir>/<shard>/<segment-id>.wal, and consist
+// of records: the payload size (4 bytes), the payload CRC32C (4 bytes),
+// and the payload: the push request.
+//
+// Note that the WAL provides at-least-once delivery guarantees: if the
+// writer crashes before the segment is flushed, profiles of the pushes
+// that have not been acknowledged are replayed as well, while the client
+// may retry them.
+
+type WALConfig struct {
+	Dir           string        `yaml:"dir" category:"advanced"`
+	MaxSizeBytes  int64         `yaml:"max_size_bytes" category:"advanced"`
+	MaxAge        time.Duration `yaml:"max_age" category:"advanced"`
+	RetryInterval time.Duration `yaml:"retry_interval" category:"advanced"`
+}
+
+func (cfg *WALConfig) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
+	f.StringVar(&cfg.Dir, prefix+"dir", "", "Directory of the segment writer write-ahead log. The WAL is disabled if empty.")
+	f.Int64Var(&cfg.MaxSizeBytes, prefix+"max-size-bytes", 1<<30, "Maximum total size of the WAL files. Pushes are not recorded in the WAL if the limit is exceeded.")
+	f.DurationVar(&cfg.MaxAge, prefix+"max-age", time.Hour, "Maximum age of the WAL files to be replayed on startup. Older files are deleted.")
+	f.DurationVar(&cfg.RetryInterval, prefix+"retry-interval", time.Minute, "Interval at which the WAL files of the segments that failed to flush are replayed.")
+}
+
+func (cfg *WALConfig) Validate() error {
+	if cfg.Dir == "" {
+		return nil
+	}
+	if cfg.MaxSizeBytes <= 0 {
+		return errors.New("WAL max size must be greater than 0")
+	}
+	if cfg.MaxAge <= 0 {
+		return errors.New("WAL max age must be greater than 0")
+	}
+	if cfg.RetryInterval <= 0 {
+		return errors.New("WAL retry interval must be greater than 0")
+	}
+	return nil
+}
+
+const (
+	walFileExt          = ".wal"
+	walRecordHeaderSize = 8
+
+	walDropReasonAge     = "age"
+	walDropReasonCorrupt = "corrupt"
+	walErrReasonFull     = "full"
+	walErrReasonIO       = "io"
+)
+
+var (
+	errWALFull = errors.New("WAL size limit exceeded")
+	walCRC     = crc32.MakeTable(crc32.Castagnoli)
+)
+
+type wal struct {
+	config  WALConfig
+	logger  log.Logger
+	metrics *segmentMetrics
+	size    atomic.Int64
+
+	mu sync.Mutex
+	// Files of the segments that failed to flush.
+	retained []walFile
+}
+
+func newWAL(config WALConfig, logger log.Logger, metrics *segmentMetrics) (*wal, error) {
+	w := &wal{
+		config:  config,
+		logger:  log.With(logger, "component", "wal"),
+		metrics: metrics,
+	}
+	if err := os.MkdirAll(config.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create WAL directory: %w", err)
+	}
+	files, err := w.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files {
+		w.addSize(f.size)
+	}
+	return w, nil
+}
+
+func (w *wal) addSize(n int64) {
+	w.metrics.walSizeBytes.Set(float64(w.size.Add(n)))
+}
+
+func (w *wal) segment(shard shardKey, id ulid.ULID) *walSegment {
+	return &walSegment{
+		wal:  w,
+		id:   id,
+		path: filepath.Join(w.config.Dir, strconv.FormatUint(uint64(shard), 10), id.String()+walFileExt),
+	}
+}
+
+type walFile struct {
+	path string
+	id   ulid.ULID
+	size int64
+}
+
+// list returns the WAL files ordered by the segment ID.
+func (w *wal) list() ([]walFile, error) {
+	var files []walFile
+	err := filepath.WalkDir(w.config.Dir, func(path string, e fs.DirEntry, err error) error {
+		if err != nil || e.IsDir() || filepath.Ext(path) != walFileExt {
+			return err
+		}
+		id, err := ulid.Parse(strings.TrimSuffix(e.Name(), walFileExt))
+		if err != nil {
+			level.Warn(w.logger).Log("msg", "ignoring unexpected file in WAL directory", "path", path)
+			return nil
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, walFile{path: path, id: id, size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list WAL files: %w", err)
+	}
+	slices.SortFunc(files, func(a, b walFile) int { return a.id.Compare(b.id) })
+	return files, nil
+}
+
+func (w *wal) remove(f walFile) {
+	if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+		level.Warn(w.logger).Log("msg", "failed to remove WAL file", "path", f.path, "err", err)
+		return
+	}
+	w.addSize(-f.size)
+}
+
+func (w *wal) expired(f walFile) bool {
+	return time.Since(ulid.Time(f.id.Time())) > w.config.MaxAge
+}
+
+// retain schedules the file to be replayed.
+func (w *wal) retain(f walFile) {
+	w.mu.Lock()
+	w.retained = append(w.retained, f)
+	w.mu.Unlock()
+}
+
+func (w *wal) takeRetained() []walFile {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	files := w.retained
+	w.retained = nil
+	return files
+}
+
+// walSegment is the WAL file of a segment. The file is created
+// when the first record is appended.
+type walSegment struct {
+	wal  *wal
+	id   ulid.ULID
+	path string
+
+	mu      sync.Mutex
+	file    *os.File
+	buf     *bufio.Writer
+	size    int64
+	records int
+	// The first append error: if any of the segment profiles
+	// has not been recorded, the segment is not durable.
+	err error
+}
+
+func (s *walSegment) append(req *segmentwriterv1.PushRequest) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	if s.err = s.appendRecord(req); s.err != nil {
+		reason := walErrReasonIO
+		if errors.Is(s.err, errWALFull) {
+			reason = walErrReasonFull
+		}
+		s.wal.metrics.walAppendErrors.WithLabelValues(reason).Inc()
+		level.Error(s.wal.logger).Log("msg", "failed to append WAL record", "path", s.path, "err", s.err)
+		return
+	}
+	s.records++
+	s.wal.metrics.walAppendedRecords.Inc()
+}
+
+func (s *walSegment) appendRecord(req *segmentwriterv1.PushRequest) error {
+	payload, err := req.MarshalVT()
+	if err != nil {
+		return err
+	}
+	n := int64(walRecordHeaderSize + len(payload))
+	if s.wal.size.Load()+n > s.wal.config.MaxSizeBytes {
+		return errWALFull
+	}
+	if s.file == nil {
+		if err = os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+			return err
+		}
+		if s.file, err = os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644); err != nil {
+			return err
+		}
+		s.buf = bufio.NewWriterSize(s.file, 256<<10)
+	}
+	var hdr [walRecordHeaderSize]byte
+	binary.LittleEndian.PutUint32(hdr[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(hdr[4:8], crc32.Checksum(payload, walCRC))
+	if _, err = s.buf.Write(hdr[:]); err != nil {
+		return err
+	}
+	if _, err = s.buf.Write(payload); err != nil {
+		return err
+	}
+	s.size += n
+	s.wal.addSize(n)
+	return nil
+}
+
+// sync writes the records to the disk and closes the file.
+// No records can be appended after the call.
+func (s *walSegment) sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return s.err
+	}
+	err := s.buf.Flush()
+	if err == nil {
+		err = s.file.Sync()
+	}
+	if closeErr := s.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil && s.err == nil {
+		s.err = err
+		s.wal.metrics.walAppendErrors.WithLabelValues(walErrReasonIO).Inc()
+	}
+	return s.err
+}
+
+// durable reports whether all the segment profiles have been recorded.
+func (s *walSegment) durable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err == nil && s.records > 0
+}
+
+func (s *walSegment) walFile() walFile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return walFile{path: s.path, id: s.id, size: s.size}
+}
+
+func (s *walSegment) remove() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file != nil {
+		s.wal.remove(walFile{path: s.path, size: s.size})
+	}
+}
+
+// openWAL opens the WAL, if enabled, and ingests the profiles retained
+// from the previous run. A WAL file is only removed once its profiles
+// have been flushed or recorded in the WAL files of the new segments.
+func (sw *segmentsWriter) openWAL(ctx context.Context) error {
+	if sw.config.WAL.Dir == "" {
+		return nil
+	}
+	w, err := newWAL(sw.config.WAL, sw.logger, sw.metrics)
+	if err != nil {
+		return err
+	}
+	files, err := w.list()
+	if err != nil {
+		return err
+	}
+	// Segments created from now on record profiles in the WAL.
+	sw.wal = w
+	for _, f := range files {
+		if w.expired(f) {
+			level.Warn(w.logger).Log("msg", "dropping expired WAL file", "path", f.path)
+			sw.metrics.walDroppedFiles.WithLabelValues(walDropReasonAge).Inc()
+			w.remove(f)
+			continue
+		}
+		if err = sw.replayWALFile(ctx, f); err != nil {
+			if ctx.Err() != nil {
+				return err
+			}
+			level.Error(w.logger).Log("msg", "failed to replay WAL file", "path", f.path, "err", err)
+			w.retain(f)
+			continue
+		}
+		w.remove(f)
+	}
+	sw.walRetry.Add(1)
+	go func() {
+		defer sw.walRetry.Done()
+		sw.retryWAL(sw.ctx)
+	}()
+	return nil
+}
+
+// retryWAL periodically replays the WAL files retained after failed
+// flushes, until the writer is stopped. The files that are left are
+// replayed on the next startup.
+func (sw *segmentsWriter) retryWAL(ctx context.Context) {
+	ticker := time.NewTicker(sw.config.WAL.RetryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, f := range sw.wal.takeRetained() {
+			if err := sw.replayWALFile(ctx, f); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				level.Error(sw.wal.logger).Log("msg", "failed to replay WAL file", "path", f.path, "err", err)
+				sw.wal.retain(f)
+				continue
+			}
+			sw.wal.remove(f)
+		}
+	}
+}
+
+func (sw *segmentsWriter) replayWALFile(ctx context.Context, f walFile) error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	r := bufio.NewReader(file)
+	waiters := make(map[segmentWaitFlushed]struct{})
+	var records, dropped int
+	var dropErr error
+	for ctx.Err() == nil {
+		req, readErr := readWALRecord(r)
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			// A partially written record is expected if the writer
+			// has crashed: the preceding records are still valid.
+			level.Warn(sw.wal.logger).Log("msg", "WAL file is corrupted", "path", f.path, "records", records, "err", readErr)
+			sw.metrics.walDroppedFiles.WithLabelValues(walDropReasonCorrupt).Inc()
+			break
+		}
+		var id uuid.UUID
+		if err = id.UnmarshalBinary(req.ProfileId); err != nil {
+			dropped++
+			dropErr = fmt.Errorf("invalid profile ID: %w", err)
+			continue
+		}
+		p, err := pprof.RawFromBytes(req.Profile)
+		if err != nil {
+			dropped++
+			dropErr = fmt.Errorf("invalid profile: %w", err)
+			continue
+		}
+		wait := sw.ingest(shardKey(req.Shard), func(s segmentIngest) {
+			s.record(req)
+			s.ingest(req.TenantId, p.Profile, id, req.Labels, req.Annotations)
+		})
+		waiters[wait] = struct{}{}
+		records++
+	}
+
+	for w := range waiters {
+		if err = w.waitFlushed(ctx); err != nil {
+			return err
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	if dropped > 0 {
+		level.Warn(sw.wal.logger).Log("msg", "dropped invalid WAL records", "path", f.path, "dropped", dropped, "err", dropErr)
+		sw.metrics.walDroppedRecords.Add(float64(dropped))
+	}
+	sw.metrics.walReplayedRecords.Add(float64(records))
+	level.Info(sw.wal.logger).Log("msg", "replayed WAL file", "path", f.path, "records", records)
+	return nil
+}
+
+func readWALRecord(r io.Reader) (*segmentwriterv1.PushRequest, error) {
+	var hdr [walRecordHeaderSize]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("truncated record header: %w", err)
+		}
+		return nil, err
+	}
+	payload := make([]byte, binary.LittleEndian.Uint32(hdr[0:4]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("truncated record: %w", err)
+	}
+	if crc32.Checksum(payload, walCRC) != binary.LittleEndian.Uint32(hdr[4:8]) {
+		return nil, errors.New("record checksum mismatch")
+	}
+	var req segmentwriterv1.PushRequest
+	if err := req.UnmarshalVT(payload); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
@@ -0,0 +1,279 @@
+package segmentwriter
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	metastorev1 "github.com/grafana/pyroscope/api/gen/proto/go/metastore/v1"
+	segmentwriterv1 "github.com/grafana/pyroscope/api/gen/proto/go/segmentwriter/v1"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+	"github.com/grafana/pyroscope/pkg/test"
+)
+
+func walTestConfig(dir string) Config {
+	cfg := defaultTestConfig()
+	cfg.MetadataDLQEnabled = false
+	cfg.WAL = WALConfig{
+		Dir:           dir,
+		MaxSizeBytes:  1 << 20,
+		MaxAge:        time.Hour,
+		RetryInterval: time.Hour,
+	}
+	return cfg
+}
+
+func pushRequest(t *testing.T, in input) *segmentwriterv1.PushRequest {
+	profile, err := in.profile.MarshalVT()
+	require.NoError(t, err)
+	id, err := in.profile.UUID.MarshalBinary()
+	require.NoError(t, err)
+	return &segmentwriterv1.PushRequest{
+		TenantId:    in.tenant,
+		Labels:      in.profile.Labels,
+		Profile:     profile,
+		ProfileId:   id,
+		Shard:       in.shard,
+		Annotations: in.profile.Annotations,
+	}
+}
+
+// push mimics SegmentWriterService.Push.
+func (sw *sw) push(chunk inputChunk) []error {
+	errs := make([]error, len(chunk))
+	for i, in := range chunk {
+		req := pushRequest(sw.t, in)
+		awaiter := sw.ingest(shardKey(in.shard), func(head segmentIngest) {
+			head.record(req)
+			p := in.profile.CloneVT()
+			head.ingest(in.tenant, p, in.profile.UUID, in.profile.Labels, in.profile.Annotations)
+		})
+		errs[i] = awaiter.waitFlushed(context.Background())
+	}
+	return errs
+}
+
+func (sw *sw) collectBlocks() func() []*metastorev1.BlockMeta {
+	var mu sync.Mutex
+	var blocks []*metastorev1.BlockMeta
+	sw.client.On("AddBlock", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			mu.Lock()
+			blocks = append(blocks, args.Get(1).(*metastorev1.AddBlockRequest).Block)
+			mu.Unlock()
+		}).Return(new(metastorev1.AddBlockResponse), nil)
+	return func() []*metastorev1.BlockMeta {
+		mu.Lock()
+		defer mu.Unlock()
+		return blocks
+	}
+}
+
+func walFiles(t *testing.T, dir string) []string {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, e os.DirEntry, err error) error {
+		if err == nil && !e.IsDir() {
+			files = append(files, path)
+		}
+		return err
+	})
+	require.NoError(t, err)
+	return files
+}
+
+func TestWAL_RemovedAfterFlush(t *testing.T) {
+	walDir := t.TempDir()
+	sw := newTestSegmentWriter(t, walTestConfig(walDir))
+	require.NoError(t, sw.openWAL(context.Background()))
+	defer sw.stop()
+	sw.client.On("AddBlock", mock.Anything, mock.Anything, mock.Anything).
+		Return(new(metastorev1.AddBlockResponse), nil)
+
+	for _, err := range sw.push(staticTestData()[0]) {
+		require.NoError(t, err)
+	}
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(len(staticTestData()[0])), testutil.ToFloat64(sw.metrics.walAppendedRecords))
+	assert.Equal(t, float64(0), testutil.ToFloat64(sw.metrics.walSizeBytes))
+}
+
+func TestWAL_RetainAndReplay(t *testing.T) {
+	walDir := t.TempDir()
+	bucket := memory.NewInMemBucket()
+	chunk := staticTestData()[0]
+
+	// The metastore is not available: the segments
+	// are retained in the WAL, and pushes succeed.
+	sw1 := newTestSegmentWriterWithBucket(t, walTestConfig(walDir), bucket)
+	require.NoError(t, sw1.openWAL(context.Background()))
+	sw1.client.On("AddBlock", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("metastore unavailable"))
+	for _, err := range sw1.push(chunk) {
+		require.NoError(t, err)
+	}
+	sw1.stop()
+	require.NotEmpty(t, walFiles(t, walDir))
+	assert.Greater(t, testutil.ToFloat64(sw1.metrics.walRetainedSegments), float64(0))
+
+	// The profiles are replayed on startup.
+	sw2 := newTestSegmentWriterWithBucket(t, walTestConfig(walDir), bucket)
+	defer sw2.stop()
+	blocks := sw2.collectBlocks()
+	require.NoError(t, sw2.openWAL(context.Background()))
+
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(len(chunk)), testutil.ToFloat64(sw2.metrics.walReplayedRecords))
+	clients := sw2.createBlocksFromMetas(blocks())
+	sw2.queryInputs(clients, groupInputs(t, chunk))
+}
+
+func TestWAL_RetainAndRetry(t *testing.T) {
+	walDir := t.TempDir()
+	cfg := walTestConfig(walDir)
+	cfg.WAL.RetryInterval = 50 * time.Millisecond
+	chunk := staticTestData()[0]
+
+	sw := newTestSegmentWriter(t, cfg)
+	defer sw.stop()
+	var available atomic.Bool
+	var mu sync.Mutex
+	var blocks []*metastorev1.BlockMeta
+	sw.client.On("AddBlock", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, req *metastorev1.AddBlockRequest, _ ...grpc.CallOption) (*metastorev1.AddBlockResponse, error) {
+			if !available.Load() {
+				return nil, errors.New("metastore unavailable")
+			}
+			mu.Lock()
+			blocks = append(blocks, req.Block)
+			mu.Unlock()
+			return new(metastorev1.AddBlockResponse), nil
+		})
+	require.NoError(t, sw.openWAL(context.Background()))
+
+	// The metastore is not available: the segments
+	// are retained in the WAL, and pushes succeed.
+	for _, err := range sw.push(chunk) {
+		require.NoError(t, err)
+	}
+	require.NotEmpty(t, walFiles(t, walDir))
+
+	// The retained segments are replayed without a restart.
+	available.Store(true)
+	require.Eventually(t, func() bool {
+		return len(walFiles(t, walDir)) == 0
+	}, 10*time.Second, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, testutil.ToFloat64(sw.metrics.walReplayedRecords), float64(len(chunk)))
+	mu.Lock()
+	defer mu.Unlock()
+	clients := sw.createBlocksFromMetas(blocks)
+	sw.queryInputs(clients, groupInputs(t, chunk))
+}
+
+func TestWAL_ReplayInvalidRecords(t *testing.T) {
+	walDir := t.TempDir()
+	cfg := walTestConfig(walDir)
+	chunk := staticTestData()[0]
+
+	w, err := newWAL(cfg.WAL, test.NewTestingLogger(t), newSegmentMetrics(nil))
+	require.NoError(t, err)
+	s := w.segment(1, ulid.MustNew(ulid.Now(), nil))
+	for i, in := range chunk {
+		req := pushRequest(t, in)
+		if i == 0 {
+			req.ProfileId = []byte("invalid")
+		}
+		s.append(req)
+	}
+	require.NoError(t, s.sync())
+
+	sw := newTestSegmentWriter(t, cfg)
+	defer sw.stop()
+	blocks := sw.collectBlocks()
+	require.NoError(t, sw.openWAL(context.Background()))
+
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(len(chunk)-1), testutil.ToFloat64(sw.metrics.walReplayedRecords))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sw.metrics.walDroppedRecords))
+	clients := sw.createBlocksFromMetas(blocks())
+	sw.queryInputs(clients, groupInputs(t, chunk[1:]))
+}
+
+func TestWAL_ReplayTruncated(t *testing.T) {
+	walDir := t.TempDir()
+	cfg := walTestConfig(walDir)
+	chunk := staticTestData()[0]
+
+	// Simulate a crash: the last record is partially written.
+	w, err := newWAL(cfg.WAL, test.NewTestingLogger(t), newSegmentMetrics(nil))
+	require.NoError(t, err)
+	s := w.segment(1, ulid.MustNew(ulid.Now(), nil))
+	for _, in := range chunk {
+		s.append(pushRequest(t, in))
+	}
+	require.NoError(t, s.sync())
+	require.NoError(t, os.Truncate(s.path, s.size-1))
+
+	sw := newTestSegmentWriter(t, cfg)
+	defer sw.stop()
+	blocks := sw.collectBlocks()
+	require.NoError(t, sw.openWAL(context.Background()))
+
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(len(chunk)-1), testutil.ToFloat64(sw.metrics.walReplayedRecords))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sw.metrics.walDroppedFiles.WithLabelValues(walDropReasonCorrupt)))
+	clients := sw.createBlocksFromMetas(blocks())
+	sw.queryInputs(clients, groupInputs(t, chunk[:len(chunk)-1]))
+}
+
+func TestWAL_ExpiredFilesDropped(t *testing.T) {
+	walDir := t.TempDir()
+	cfg := walTestConfig(walDir)
+
+	w, err := newWAL(cfg.WAL, test.NewTestingLogger(t), newSegmentMetrics(nil))
+	require.NoError(t, err)
+	s := w.segment(1, ulid.MustNew(ulid.Timestamp(time.Now().Add(-2*cfg.WAL.MaxAge)), nil))
+	s.append(pushRequest(t, staticTestData()[0][0]))
+	require.NoError(t, s.sync())
+
+	sw := newTestSegmentWriter(t, cfg)
+	defer sw.stop()
+	require.NoError(t, sw.openWAL(context.Background()))
+
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(0), testutil.ToFloat64(sw.metrics.walReplayedRecords))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sw.metrics.walDroppedFiles.WithLabelValues(walDropReasonAge)))
+}
+
+func TestWAL_SizeLimit(t *testing.T) {
+	walDir := t.TempDir()
+	cfg := walTestConfig(walDir)
+	cfg.WAL.MaxSizeBytes = 1
+
+	// The profiles cannot be recorded: the flush error
+	// is propagated to the clients, and nothing is retained.
+	sw := newTestSegmentWriter(t, cfg)
+	require.NoError(t, sw.openWAL(context.Background()))
+	defer sw.stop()
+	sw.client.On("AddBlock", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, errors.New("metastore unavailable"))
+
+	chunk := staticTestData()[0][:1]
+	for _, err := range sw.push(chunk) {
+		require.Error(t, err)
+	}
+	assert.Empty(t, walFiles(t, walDir))
+	assert.Equal(t, float64(1), testutil.ToFloat64(sw.metrics.walAppendErrors.WithLabelValues(walErrReasonFull)))
+	assert.Equal(t, float64(0), testutil.ToFloat64(sw.metrics.walRetainedSegments))
+}
@@ -235,6 +235,10 @@ func validateUpsert(req *settingsv1.UpsertRecordingRuleRequest) error {
 		}
 	}
 
+	if err := model.ValidateStacktraceFilter(req.StacktraceFilter); err != nil {
+		errs = append(errs, fmt.Errorf("stacktrace_filter is invalid: %v", err))
+	}
+
 	if req.Generation < 0 {
 		errs = append(errs, fmt.Errorf("generation must be positive"))
 	}
@@ -203,6 +203,48 @@ func Test_validateUpsert(t *testing.T) {
 			},
 			WantErr: "generation must be positive",
 		},
+		{
+			Name: "valid_stacktrace_filter",
+			Req: &settingsv1.UpsertRecordingRuleRequest{
+				MetricName: "my_metric",
+				StacktraceFilter: &settingsv1.StacktraceFilter{
+					CallSite: &settingsv1.StacktraceFilterCallSite{
+						StackTraceSelector: &typesv1.StackTraceSelector{
+							CallSite: []*typesv1.Location{{Name: "main"}, {Name: "handler"}},
+						},
+						MetricType: settingsv1.MetricType_SELF,
+					},
+				},
+			},
+			WantErr: "",
+		},
+		{
+			Name: "invalid_stacktrace_filter_regex",
+			Req: &settingsv1.UpsertRecordingRuleRequest{
+				MetricName: "my_metric",
+				StacktraceFilter: &settingsv1.StacktraceFilter{
+					FunctionNameRegex: &settingsv1.StacktraceFilterFunctionNameRegex{
+						FunctionNameRegex: "runtime.(",
+					},
+				},
+			},
+			WantErr: "stacktrace_filter is invalid: function name regex is invalid: error parsing regexp: missing closing ): `^(?:runtime.()$`",
+		},
+		{
+			Name: "invalid_stacktrace_filter_multiple",
+			Req: &settingsv1.UpsertRecordingRuleRequest{
+				MetricName: "my_metric",
+				StacktraceFilter: &settingsv1.StacktraceFilter{
+					FunctionName: &settingsv1.StacktraceFilterFunctionName{
+						FunctionName: "runtime.gcBgMarkWorker",
+					},
+					FunctionNameRegex: &settingsv1.StacktraceFilterFunctionNameRegex{
+						FunctionNameRegex: "runtime.gc.*",
+					},
+				},
+			},
+			WantErr: "stacktrace_filter is invalid: only one of function name, function name regex, and call site can be set",
+		},
 		{
 			Name: "multiple_errors",
 			Req: &settingsv1.UpsertRecordingRuleRequest{
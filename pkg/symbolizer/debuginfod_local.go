@@ -0,0 +1,73 @@
+package symbolizer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalDebuginfodClient implements the DebuginfodClient interface by
+// resolving build IDs against a local directory of debug files, laid
+// out the same way as /usr/lib/debug: .build-id/xx/yyyy.debug, where
+// xx is the first byte of the build ID, and yyyy is the rest of it.
+//
+// It is useful in environments that have no access to a debuginfod
+// server: the directory can be mounted from a volume populated by CI.
+type LocalDebuginfodClient struct {
+	dir string
+}
+
+// NewLocalDebuginfodClient creates a client resolving build IDs against the directory.
+func NewLocalDebuginfodClient(dir string) *LocalDebuginfodClient {
+	return &LocalDebuginfodClient{dir: dir}
+}
+
+// FetchDebuginfo opens the debug file for a specific build ID.
+func (c *LocalDebuginfodClient) FetchDebuginfo(_ context.Context, buildID string) (io.ReadCloser, error) {
+	path, err := c.path(buildID)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, buildIDNotFoundError{buildID: buildID}
+		}
+		return nil, fmt.Errorf("open debug file: %w", err)
+	}
+	return f, nil
+}
+
+func (c *LocalDebuginfodClient) path(buildID string) (string, error) {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return "", err
+	}
+	if len(sanitizedBuildID) < 3 {
+		return "", invalidBuildIDError{buildID: buildID}
+	}
+	id := strings.ToLower(sanitizedBuildID)
+	return filepath.Join(c.dir, ".build-id", id[:2], id[2:]+".debug"), nil
+}
+
+// chainDebuginfodClient queries the clients in order, until
+// one of them finds the debug info of the build ID.
+type chainDebuginfodClient []DebuginfodClient
+
+func (c chainDebuginfodClient) FetchDebuginfo(ctx context.Context, buildID string) (io.ReadCloser, error) {
+	err := error(buildIDNotFoundError{buildID: buildID})
+	for _, client := range c {
+		var r io.ReadCloser
+		if r, err = client.FetchDebuginfo(ctx, buildID); err == nil {
+			return r, nil
+		}
+		if !isBuildIDNotFoundError(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
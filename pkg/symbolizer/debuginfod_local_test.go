@@ -0,0 +1,72 @@
+package symbolizer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/test/mocks/mocksymbolizer"
+)
+
+const testBuildID = "2fa2055ef20fabc972d5751147e093275514b142"
+
+func TestLocalDebuginfodClient(t *testing.T) {
+	dir := t.TempDir()
+	data, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	path := filepath.Join(dir, ".build-id", testBuildID[:2], testBuildID[2:]+".debug")
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+
+	client := NewLocalDebuginfodClient(dir)
+	ctx := context.Background()
+
+	r, err := client.FetchDebuginfo(ctx, testBuildID)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, data, b)
+
+	// Build IDs are case-insensitive.
+	r, err = client.FetchDebuginfo(ctx, "2FA2055EF20FABC972D5751147E093275514B142")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	_, err = client.FetchDebuginfo(ctx, "0123456789")
+	require.True(t, isBuildIDNotFoundError(err))
+
+	for _, buildID := range []string{"", "ab", "../../etc/passwd"} {
+		_, err = client.FetchDebuginfo(ctx, buildID)
+		require.True(t, isInvalidBuildIDError(err), buildID)
+	}
+}
+
+func TestChainDebuginfodClient(t *testing.T) {
+	ctx := context.Background()
+	first := mocksymbolizer.NewMockDebuginfodClient(t)
+	second := mocksymbolizer.NewMockDebuginfodClient(t)
+	client := chainDebuginfodClient{first, second}
+
+	first.On("FetchDebuginfo", mock.Anything, "found").Return(nil, buildIDNotFoundError{buildID: "found"}).Once()
+	second.On("FetchDebuginfo", mock.Anything, "found").Return(openTestFile(t), nil).Once()
+	r, err := client.FetchDebuginfo(ctx, "found")
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	first.On("FetchDebuginfo", mock.Anything, "missing").Return(nil, buildIDNotFoundError{buildID: "missing"}).Once()
+	second.On("FetchDebuginfo", mock.Anything, "missing").Return(nil, httpStatusError{statusCode: 404}).Once()
+	_, err = client.FetchDebuginfo(ctx, "missing")
+	require.True(t, isBuildIDNotFoundError(err))
+
+	// Other errors are not masked.
+	first.On("FetchDebuginfo", mock.Anything, "failed").Return(nil, errors.New("permission denied")).Once()
+	_, err = client.FetchDebuginfo(ctx, "failed")
+	require.EqualError(t, err, "permission denied")
+}
@@ -3,6 +3,7 @@ package symbolizer
 import (
 	"errors"
 	"fmt"
+	"net/http"
 )
 
 type invalidBuildIDError struct {
@@ -21,6 +22,16 @@ func (e buildIDNotFoundError) Error() string {
 	return fmt.Sprintf("build ID not found: %s", e.buildID)
 }
 
+type invalidDebugInfoError struct {
+	err error
+}
+
+func (e invalidDebugInfoError) Error() string {
+	return fmt.Sprintf("invalid debug info: %v", e.err)
+}
+
+func (e invalidDebugInfoError) Unwrap() error { return e.err }
+
 type httpStatusError struct {
 	statusCode int
 	body       string
@@ -40,6 +51,20 @@ func isInvalidBuildIDError(err error) bool {
 	return ok
 }
 
+func isInvalidDebugInfoError(err error) bool {
+	var invalidDebugInfoError invalidDebugInfoError
+	return errors.As(err, &invalidDebugInfoError)
+}
+
+func isBuildIDNotFoundError(err error) bool {
+	var buildIDNotFoundError buildIDNotFoundError
+	if errors.As(err, &buildIDNotFoundError) {
+		return true
+	}
+	statusCode, ok := isHTTPStatusError(err)
+	return ok && statusCode == http.StatusNotFound
+}
+
 func isHTTPStatusError(err error) (int, bool) {
 	var httpErr httpStatusError
 	ok := errors.As(err, &httpErr)
@@ -8,7 +8,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"net/http"
 	"path/filepath"
 	"time"
 
@@ -27,10 +26,12 @@ type DebuginfodClient interface {
 
 type Config struct {
 	DebuginfodURL string `yaml:"debuginfod_url"`
+	DebugInfoDir  string `yaml:"debug_info_dir"`
 }
 
 func (cfg *Config) RegisterFlags(f *flag.FlagSet) {
 	f.StringVar(&cfg.DebuginfodURL, "symbolizer.debuginfod-url", "https://debuginfod.elfutils.org", "URL of the debuginfod server")
+	f.StringVar(&cfg.DebugInfoDir, "symbolizer.debug-info-dir", "", "Local directory of debug files in the .build-id/xx/yyyy.debug layout. If set, the directory is looked up before the debuginfod server.")
 }
 
 type Symbolizer struct {
@@ -43,9 +44,21 @@ type Symbolizer struct {
 func New(logger log.Logger, cfg Config, reg prometheus.Registerer, bucket objstore.Bucket) (*Symbolizer, error) {
 	metrics := newMetrics(reg)
 
-	client, err := NewDebuginfodClient(logger, cfg.DebuginfodURL, metrics)
-	if err != nil {
-		return nil, err
+	var clients chainDebuginfodClient
+	if cfg.DebugInfoDir != "" {
+		clients = append(clients, NewLocalDebuginfodClient(cfg.DebugInfoDir))
+	}
+	if cfg.DebuginfodURL != "" {
+		httpClient, err := NewDebuginfodClient(logger, cfg.DebuginfodURL, metrics)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, httpClient)
+	}
+
+	var client DebuginfodClient = clients
+	if len(clients) == 1 {
+		client = clients[0]
 	}
 
 	return &Symbolizer{
@@ -231,14 +244,17 @@ func (s *Symbolizer) updateAllSymbolsInProfile(
 				maxFuncID++
 				funcID = maxFuncID
 				profile.Function = append(profile.Function, &googlev1.Function{
-					Id:   funcID,
-					Name: nameIdx,
+					Id:         funcID,
+					Name:       nameIdx,
+					SystemName: nameIdx,
+					Filename:   filenameIdx,
 				})
 				funcMap[key] = funcID
 			}
 
 			profile.Location[locIdx].Line[j] = &googlev1.Line{
 				FunctionId: funcID,
+				Line:       int64(line.LineNumber),
 			}
 		}
 
@@ -247,31 +263,35 @@ func (s *Symbolizer) updateAllSymbolsInProfile(
 }
 
 func (s *Symbolizer) symbolize(ctx context.Context, req *request) {
-	var table *lidia.Table
-	var err error
-
-	lidiaBytes, err := s.getLidiaBytes(ctx, req.buildID)
+	table, err := s.openTable(ctx, req.buildID)
 	if err != nil {
-		level.Warn(s.logger).Log("msg", "Failed to get debug info", "buildID", req.buildID, "err", err)
 		for _, loc := range req.locations {
 			loc.lines = s.createNotFoundSymbols(req.binaryName, loc)
 		}
 		return
 	}
+	defer table.Close()
+
+	s.symbolizeWithTable(table, req)
+}
+
+// openTable opens the lidia table of the build ID. The caller is
+// responsible for closing the table.
+func (s *Symbolizer) openTable(ctx context.Context, buildID string) (*lidia.Table, error) {
+	lidiaBytes, err := s.getLidiaBytes(ctx, buildID)
+	if err != nil {
+		level.Warn(s.logger).Log("msg", "Failed to get debug info", "buildID", buildID, "err", err)
+		return nil, err
+	}
 
 	lidiaReader := NewReaderAtCloser(lidiaBytes)
-	table, err = lidia.OpenReader(lidiaReader, lidia.WithCRC())
+	table, err := lidia.OpenReader(lidiaReader, lidia.WithCRC())
 	if err != nil {
 		s.metrics.debugSymbolResolutionErrors.WithLabelValues("lidia_error").Inc()
 		level.Warn(s.logger).Log("msg", "Failed to open Lidia file", "err", err)
-		for _, loc := range req.locations {
-			loc.lines = s.createNotFoundSymbols(req.binaryName, loc)
-		}
-		return
+		return nil, err
 	}
-	defer table.Close()
-
-	s.symbolizeWithTable(table, req)
+	return table, nil
 }
 
 func (s *Symbolizer) symbolizeWithTable(table *lidia.Table, req *request) {
@@ -298,13 +318,10 @@ func (s *Symbolizer) symbolizeWithTable(table *lidia.Table, req *request) {
 	}
 }
 
+// getLidiaBytes looks up the debug info in the object store first:
+// it may have been uploaded after debuginfod reported the build ID as
+// not found, which the debuginfod client caches.
 func (s *Symbolizer) getLidiaBytes(ctx context.Context, buildID string) ([]byte, error) {
-	if client, ok := s.client.(*DebuginfodHTTPClient); ok {
-		if found, _ := client.notFoundCache.Get(buildID); found {
-			return nil, buildIDNotFoundError{buildID: buildID}
-		}
-	}
-
 	lidiaBytes, err := s.fetchLidiaFromObjectStore(ctx, buildID)
 	if err == nil {
 		return lidiaBytes, nil
@@ -366,10 +383,7 @@ func (s *Symbolizer) fetchLidiaFromDebuginfod(ctx context.Context, buildID strin
 func (s *Symbolizer) fetchFromDebuginfod(ctx context.Context, buildID string) (io.ReadCloser, error) {
 	debugReader, err := s.client.FetchDebuginfo(ctx, buildID)
 	if err != nil {
-		var bnfErr buildIDNotFoundError
-		statusCode, isHTTPError := isHTTPStatusError(err)
-
-		if errors.As(err, &bnfErr) || (isHTTPError && statusCode == http.StatusNotFound) {
+		if isBuildIDNotFoundError(err) {
 			return nil, buildIDNotFoundError{buildID: buildID}
 		}
 
@@ -380,25 +394,35 @@ func (s *Symbolizer) fetchFromDebuginfod(ctx context.Context, buildID string) (i
 }
 
 func (s *Symbolizer) processELFData(data []byte) (lidiaData []byte, err error) {
+	elfFile, err := s.openELF(data)
+	if err != nil {
+		return nil, err
+	}
+	defer elfFile.Close()
+	return s.createLidia(elfFile, len(data))
+}
+
+func (s *Symbolizer) openELF(data []byte) (*elf.File, error) {
 	decompressedData, err := detectCompression(data)
 	if err != nil {
 		s.metrics.debugSymbolResolutionErrors.WithLabelValues("compression_error").Inc()
 		return nil, fmt.Errorf("detect compression: %w", err)
 	}
 
-	reader := bytes.NewReader(decompressedData)
-
-	elfFile, err := elf.NewFile(reader)
+	elfFile, err := elf.NewFile(bytes.NewReader(decompressedData))
 	if err != nil {
 		s.metrics.debugSymbolResolutionErrors.WithLabelValues("elf_parsing_error").Inc()
 		return nil, fmt.Errorf("parse ELF file: %w", err)
 	}
-	defer elfFile.Close()
+	return elfFile, nil
+}
 
-	initialSize := len(data) * 2 // A simple heuristic: twice the compressed size
+func (s *Symbolizer) createLidia(elfFile *elf.File, size int) ([]byte, error) {
+	initialSize := size * 2 // A simple heuristic: twice the compressed size
 	memBuffer := newMemoryBuffer(initialSize)
 
-	err = lidia.CreateLidiaFromELF(elfFile, memBuffer, lidia.WithCRC(), lidia.WithFiles(), lidia.WithLines())
+	err := lidia.CreateLidiaFromELF(elfFile, memBuffer,
+		lidia.WithCRC(), lidia.WithFiles(), lidia.WithLines(), lidia.WithLogger(level.Warn(s.logger)))
 	if err != nil {
 		return nil, fmt.Errorf("create lidia file: %w", err)
 	}
@@ -24,12 +24,16 @@ import (
 
 // TestSymbolizePprof tests symbolization using testdata/symbols.debug which contains:
 //
-// 0x1500 -> (contains both functions)
-//   - main (/usr/src/stress-1.0.7-1/src/stress.c:87)
-//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:77)
+// 0x1500 -> main (/usr/src/stress-1.0.7-1/src/stress.c:116)
+// 0x3b60 -> atoll_b (/usr/src/stress-1.0.7-1/src/stress.c:632)
 //
-// 0x3c5a -> atoll_b (/usr/src/stress-1.0.7-1/src/stress.c:632)
-// 0x2745 -> main (/usr/src/stress-1.0.7-1/src/stress.c:87)
+// 0x3c5a -> (fprintf inlined into atoll_b)
+//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:79)
+//   - atoll_b (/usr/src/stress-1.0.7-1/src/stress.c:665)
+//
+// 0x2745 -> (fprintf inlined into main)
+//   - fprintf (/usr/include/x86_64-linux-gnu/bits/stdio2.h:79)
+//   - main (/usr/src/stress-1.0.7-1/src/stress.c:442)
 func TestSymbolizePprof(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -145,6 +149,46 @@ func TestSymbolizePprof(t *testing.T) {
 				assertLocationHasFunction(t, p, p.Location[2], "main", "main")
 			},
 		},
+		{
+			name: "inline frames",
+			profile: &googlev1.Profile{
+				Mapping: []*googlev1.Mapping{{
+					BuildId:     1,
+					MemoryStart: 0x0,
+					MemoryLimit: 0x1000000,
+					FileOffset:  0x0,
+				}},
+				Location: []*googlev1.Location{
+					{Id: 1, MappingId: 1, Address: 0x3c5a},
+				},
+				StringTable: []string{"", "build-id"},
+			},
+			setupMock: func(mockClient *mocksymbolizer.MockDebuginfodClient, mockBucket *mockobjstore.MockBucket) {
+				mockClient.On("FetchDebuginfo", mock.Anything, "build-id").Return(openTestFile(t), nil).Once()
+				mockBucket.On("Get", mock.Anything, "build-id").Return(nil, fmt.Errorf("not found")).Once()
+				mockBucket.On("Upload", mock.Anything, "build-id", mock.Anything).Return(nil).Once()
+			},
+			validate: func(t *testing.T, p *googlev1.Profile) {
+				require.True(t, p.Mapping[0].HasFunctions)
+
+				// The innermost frame goes first.
+				lines := p.Location[0].Line
+				require.Len(t, lines, 2)
+				for i, expected := range []struct {
+					name string
+					file string
+					line int64
+				}{
+					{"fprintf", "/usr/include/x86_64-linux-gnu/bits/stdio2.h", 79},
+					{"atoll_b", "/usr/src/stress-1.0.7-1/src/stress.c", 665},
+				} {
+					fn := p.Function[lines[i].FunctionId-1]
+					require.Equal(t, expected.name, p.StringTable[fn.Name])
+					require.Equal(t, expected.file, p.StringTable[fn.Filename])
+					require.Equal(t, expected.line, lines[i].Line)
+				}
+			},
+		},
 		{
 			name: "preserve existing symbols when HasFunctions=false",
 			// This tests a defensive check against data inconsistency where a mapping has
@@ -0,0 +1,138 @@
+package symbolizer
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-kit/log/level"
+
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+// SymbolizeSymbols resolves the locations of mappings that have no
+// functions in place: the location lines are populated, and the new
+// functions and strings are appended to the symbols.
+//
+// Mappings which debug info cannot be found are left intact, so that
+// they can be symbolized later. The function reports whether all the
+// mappings have been symbolized.
+func (s *Symbolizer) SymbolizeSymbols(ctx context.Context, symbols *symdb.Symbols) (bool, error) {
+	locationsByMapping := make(map[uint32][]*location)
+	locationIndices := make(map[uint32][]int)
+	for i, loc := range symbols.Locations {
+		if len(loc.Line) > 0 || int(loc.MappingId) >= len(symbols.Mappings) {
+			continue
+		}
+		if symbols.Mappings[loc.MappingId].HasFunctions {
+			continue
+		}
+		locationsByMapping[loc.MappingId] = append(locationsByMapping[loc.MappingId], &location{address: loc.Address})
+		locationIndices[loc.MappingId] = append(locationIndices[loc.MappingId], i)
+	}
+	if len(locationsByMapping) == 0 {
+		return true, nil
+	}
+
+	w := newSymbolsWriter(symbols)
+	complete := true
+	mappings := make([]uint32, 0, len(locationsByMapping))
+	for m := range locationsByMapping {
+		mappings = append(mappings, m)
+	}
+	slices.Sort(mappings)
+
+	for _, mappingID := range mappings {
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		mapping := &symbols.Mappings[mappingID]
+		buildID, err := sanitizeBuildID(symbols.Strings[mapping.BuildId])
+		if err != nil || buildID == "" {
+			complete = false
+			continue
+		}
+		table, err := s.openTable(ctx, buildID)
+		if err != nil {
+			complete = false
+			continue
+		}
+		req := request{
+			buildID:    buildID,
+			binaryName: filepath.Base(symbols.Strings[mapping.Filename]),
+			locations:  locationsByMapping[mappingID],
+		}
+		s.symbolizeWithTable(table, &req)
+		table.Close()
+
+		for i, loc := range req.locations {
+			lines := make([]schemav1.InMemoryLine, len(loc.lines))
+			for j, frame := range loc.lines {
+				lines[j] = schemav1.InMemoryLine{
+					FunctionId: w.function(frame.FunctionName, frame.FilePath),
+					Line:       int32(frame.LineNumber),
+				}
+			}
+			symbols.Locations[locationIndices[mappingID][i]].Line = lines
+		}
+		mapping.HasFunctions = true
+		mapping.HasFilenames = true
+		mapping.HasLineNumbers = true
+		level.Debug(s.logger).Log("msg", "symbolized mapping", "buildID", buildID, "locations", len(req.locations))
+	}
+
+	return complete, nil
+}
+
+type symbolsWriter struct {
+	symbols   *symdb.Symbols
+	strings   map[string]uint32
+	functions map[[2]uint32]uint32
+}
+
+func newSymbolsWriter(symbols *symdb.Symbols) *symbolsWriter {
+	w := &symbolsWriter{
+		symbols:   symbols,
+		strings:   make(map[string]uint32, len(symbols.Strings)),
+		functions: make(map[[2]uint32]uint32, len(symbols.Functions)),
+	}
+	for i, str := range symbols.Strings {
+		if _, ok := w.strings[str]; !ok {
+			w.strings[str] = uint32(i)
+		}
+	}
+	for i, fn := range symbols.Functions {
+		k := [2]uint32{fn.Name, fn.Filename}
+		if _, ok := w.functions[k]; !ok {
+			w.functions[k] = uint32(i)
+		}
+	}
+	return w
+}
+
+func (w *symbolsWriter) string(s string) uint32 {
+	i, ok := w.strings[s]
+	if !ok {
+		i = uint32(len(w.symbols.Strings))
+		w.symbols.Strings = append(w.symbols.Strings, s)
+		w.strings[s] = i
+	}
+	return i
+}
+
+func (w *symbolsWriter) function(name, filename string) uint32 {
+	k := [2]uint32{w.string(name), w.string(filename)}
+	i, ok := w.functions[k]
+	if !ok {
+		i = uint32(len(w.symbols.Functions))
+		w.symbols.Functions = append(w.symbols.Functions, schemav1.InMemoryFunction{
+			Id:         uint64(i),
+			Name:       k[0],
+			SystemName: k[0],
+			Filename:   k[1],
+		})
+		w.functions[k] = i
+	}
+	return i
+}
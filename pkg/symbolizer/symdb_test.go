@@ -0,0 +1,88 @@
+package symbolizer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+	schemav1 "github.com/grafana/pyroscope/pkg/phlaredb/schemas/v1"
+	"github.com/grafana/pyroscope/pkg/phlaredb/symdb"
+)
+
+func TestSymbolizer_SymbolizeSymbols(t *testing.T) {
+	ctx := context.Background()
+	s := &Symbolizer{
+		logger:  log.NewNopLogger(),
+		client:  NewLocalDebuginfodClient(t.TempDir()),
+		bucket:  &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()},
+		metrics: newMetrics(prometheus.NewRegistry()),
+	}
+	elfData, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	require.NoError(t, s.Upload(ctx, testBuildID, elfData))
+
+	newSymbols := func() *symdb.Symbols {
+		return &symdb.Symbols{
+			Strings: []string{"", "main", "main.go", testBuildID, "/usr/bin/stress", "missing-build-id"},
+			Functions: []schemav1.InMemoryFunction{
+				{Name: 1, SystemName: 1, Filename: 2},
+			},
+			Mappings: []schemav1.InMemoryMapping{
+				{HasFunctions: true},
+				{Id: 1, BuildId: 3, Filename: 4},
+				{Id: 2, BuildId: 5, Filename: 4},
+			},
+			Locations: []schemav1.InMemoryLocation{
+				{Line: []schemav1.InMemoryLine{{FunctionId: 0, Line: 10}}},
+				{Id: 1, MappingId: 1, Address: 0x3c5a},
+				{Id: 2, MappingId: 1, Address: 0x2745},
+				{Id: 3, MappingId: 2, Address: 0x1000},
+			},
+		}
+	}
+
+	symbols := newSymbols()
+	complete, err := s.SymbolizeSymbols(ctx, symbols)
+	require.NoError(t, err)
+	// The debug info of the last mapping is missing.
+	require.False(t, complete)
+
+	lines := func(loc int) []string {
+		var s []string
+		for _, line := range symbols.Locations[loc].Line {
+			fn := symbols.Functions[line.FunctionId]
+			s = append(s, fmt.Sprintf("%s %s:%d", symbols.Strings[fn.Name], symbols.Strings[fn.Filename], line.Line))
+		}
+		return s
+	}
+	require.Equal(t, []string{
+		"fprintf /usr/include/x86_64-linux-gnu/bits/stdio2.h:79",
+		"atoll_b /usr/src/stress-1.0.7-1/src/stress.c:665",
+	}, lines(1))
+	require.Equal(t, []string{
+		"fprintf /usr/include/x86_64-linux-gnu/bits/stdio2.h:79",
+		"main /usr/src/stress-1.0.7-1/src/stress.c:442",
+	}, lines(2))
+
+	// Existing symbols are not modified.
+	expected := newSymbols()
+	require.Equal(t, expected.Locations[0], symbols.Locations[0])
+	require.Equal(t, expected.Functions, symbols.Functions[:len(expected.Functions)])
+	require.Equal(t, expected.Strings, symbols.Strings[:len(expected.Strings)])
+	require.True(t, symbols.Mappings[1].HasFunctions)
+	require.Empty(t, symbols.Locations[3].Line)
+	require.False(t, symbols.Mappings[2].HasFunctions)
+
+	// Symbolized locations are skipped.
+	symbols.Locations = symbols.Locations[:3]
+	complete, err = s.SymbolizeSymbols(ctx, symbols)
+	require.NoError(t, err)
+	require.True(t, complete)
+}
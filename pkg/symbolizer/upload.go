@@ -0,0 +1,120 @@
+package symbolizer
+
+import (
+	"bytes"
+	"context"
+	"debug/elf"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-kit/log/level"
+	"github.com/gorilla/mux"
+
+	"github.com/grafana/pyroscope/lidia"
+	httputil "github.com/grafana/pyroscope/pkg/util/http"
+)
+
+// maxUploadSize limits the size of uploaded debug files.
+const maxUploadSize = 1 << 30
+
+// Upload stores the debug info of the build ID in the object store,
+// where it is looked up before querying debuginfod. The data is either
+// an ELF file with debug info, which is converted to a lidia table, or
+// a pre-built lidia table. If the ELF file has a build ID note, it must
+// match the build ID.
+func (s *Symbolizer) Upload(ctx context.Context, buildID string, data []byte) error {
+	sanitizedBuildID, err := sanitizeBuildID(buildID)
+	if err != nil {
+		return err
+	}
+	if sanitizedBuildID == "" {
+		return invalidBuildIDError{buildID: buildID}
+	}
+
+	lidiaData, err := s.lidiaFromUpload(sanitizedBuildID, data)
+	if err != nil {
+		return invalidDebugInfoError{err: err}
+	}
+	if err = s.bucket.Upload(ctx, sanitizedBuildID, bytes.NewReader(lidiaData)); err != nil {
+		return fmt.Errorf("store debug info: %w", err)
+	}
+	level.Info(s.logger).Log("msg", "debug info uploaded", "buildID", sanitizedBuildID, "size", len(lidiaData))
+	return nil
+}
+
+func (s *Symbolizer) lidiaFromUpload(buildID string, data []byte) ([]byte, error) {
+	table, lidiaErr := lidia.OpenReader(NewReaderAtCloser(data), lidia.WithCRC())
+	if lidiaErr == nil {
+		defer table.Close()
+		if err := table.CheckCRC(); err != nil {
+			return nil, fmt.Errorf("invalid lidia file: %w", err)
+		}
+		return data, nil
+	}
+
+	elfFile, err := s.openELF(data)
+	if err != nil {
+		return nil, fmt.Errorf("neither a lidia nor an ELF file: %w", errors.Join(lidiaErr, err))
+	}
+	defer elfFile.Close()
+	if id, err := ELFBuildID(elfFile); err != nil {
+		return nil, err
+	} else if id != "" && id != buildID {
+		return nil, fmt.Errorf("build ID mismatch: ELF file has build ID %s", id)
+	}
+	return s.createLidia(elfFile, len(data))
+}
+
+// ELFBuildID returns the GNU build ID of the ELF file,
+// or an empty string if the file has no build ID note.
+func ELFBuildID(f *elf.File) (string, error) {
+	section := f.Section(".note.gnu.build-id")
+	if section == nil {
+		return "", nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return "", fmt.Errorf("read build ID note: %w", err)
+	}
+	// Note header: name size, descriptor size, type.
+	// The name is padded to 4 bytes.
+	if len(data) < 12 {
+		return "", errors.New("invalid build ID note")
+	}
+	nameSize := f.ByteOrder.Uint32(data[0:4])
+	descSize := f.ByteOrder.Uint32(data[4:8])
+	descOffset := 12 + (uint64(nameSize)+3)&^3
+	if descOffset+uint64(descSize) > uint64(len(data)) {
+		return "", errors.New("invalid build ID note")
+	}
+	return hex.EncodeToString(data[descOffset : descOffset+uint64(descSize)]), nil
+}
+
+// UploadHandler handles debug info uploads: the request body is the
+// content of the debug file, the build ID is taken from the path.
+func (s *Symbolizer) UploadHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buildID := mux.Vars(r)["build_id"]
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxUploadSize))
+		if err != nil {
+			httputil.ErrorWithStatus(w, fmt.Errorf("read request body: %w", err), http.StatusBadRequest)
+			return
+		}
+		if len(data) == 0 {
+			httputil.ErrorWithStatus(w, errors.New("request body is empty"), http.StatusBadRequest)
+			return
+		}
+		if err = s.Upload(r.Context(), buildID, data); err != nil {
+			if isInvalidBuildIDError(err) || isInvalidDebugInfoError(err) {
+				httputil.ErrorWithStatus(w, err, http.StatusBadRequest)
+				return
+			}
+			httputil.Error(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+}
@@ -0,0 +1,135 @@
+package symbolizer
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/pyroscope/lidia"
+	"github.com/grafana/pyroscope/pkg/objstore"
+	"github.com/grafana/pyroscope/pkg/objstore/providers/memory"
+)
+
+func TestSymbolizer_Upload(t *testing.T) {
+	ctx := context.Background()
+	bucket := &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}
+	s := &Symbolizer{
+		logger:  log.NewNopLogger(),
+		bucket:  bucket,
+		metrics: newMetrics(prometheus.NewRegistry()),
+	}
+	elfData, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+
+	// ELF files are converted to lidia tables.
+	require.NoError(t, s.Upload(ctx, testBuildID, elfData))
+	lidiaData, err := s.fetchLidiaFromObjectStore(ctx, testBuildID)
+	require.NoError(t, err)
+	table, err := lidia.OpenReader(NewReaderAtCloser(lidiaData), lidia.WithCRC())
+	require.NoError(t, err)
+	frames, err := table.Lookup(nil, 0x3b60)
+	require.NoError(t, err)
+	require.Equal(t, "atoll_b", frames[0].FunctionName)
+	table.Close()
+
+	// Lidia files are stored as is.
+	require.NoError(t, s.Upload(ctx, "lidia-build-id", lidiaData))
+	stored, err := s.fetchLidiaFromObjectStore(ctx, "lidia-build-id")
+	require.NoError(t, err)
+	require.Equal(t, lidiaData, stored)
+
+	err = s.Upload(ctx, "0123456789", elfData)
+	require.ErrorContains(t, err, "build ID mismatch")
+	require.True(t, isInvalidDebugInfoError(err))
+
+	err = s.Upload(ctx, "invalid-data", []byte("not a debug file"))
+	require.True(t, isInvalidDebugInfoError(err))
+
+	err = s.Upload(ctx, "../build-id", elfData)
+	require.True(t, isInvalidBuildIDError(err))
+}
+
+func TestSymbolizer_UploadAfterNotFound(t *testing.T) {
+	ctx := context.Background()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	client, err := NewDebuginfodClientWithConfig(log.NewNopLogger(), DebuginfodClientConfig{
+		BaseURL:               server.URL,
+		NotFoundCacheMaxItems: 100,
+		NotFoundCacheTTL:      time.Hour,
+	}, newMetrics(nil))
+	require.NoError(t, err)
+	s := &Symbolizer{
+		logger:  log.NewNopLogger(),
+		client:  client,
+		bucket:  &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()},
+		metrics: newMetrics(prometheus.NewRegistry()),
+	}
+
+	_, err = s.openTable(ctx, testBuildID)
+	require.True(t, isBuildIDNotFoundError(err))
+	client.notFoundCache.Wait()
+	_, err = s.openTable(ctx, testBuildID)
+	require.True(t, isBuildIDNotFoundError(err))
+	require.Equal(t, 1, requests)
+
+	elfData, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+	require.NoError(t, s.Upload(ctx, testBuildID, elfData))
+
+	table, err := s.openTable(ctx, testBuildID)
+	require.NoError(t, err)
+	defer table.Close()
+	frames, err := table.Lookup(nil, 0x3b60)
+	require.NoError(t, err)
+	require.Equal(t, "atoll_b", frames[0].FunctionName)
+	require.Equal(t, 1, requests)
+}
+
+func TestSymbolizer_UploadHandler(t *testing.T) {
+	bucket := &objstore.ReaderAtBucket{Bucket: memory.NewInMemBucket()}
+	s := &Symbolizer{
+		logger:  log.NewNopLogger(),
+		bucket:  bucket,
+		metrics: newMetrics(prometheus.NewRegistry()),
+	}
+	router := mux.NewRouter()
+	router.Handle("/symbolizer/debuginfo/{build_id}", s.UploadHandler())
+	elfData, err := os.ReadFile("testdata/symbols.debug")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		buildID string
+		body    []byte
+		status  int
+	}{
+		{buildID: testBuildID, body: elfData, status: http.StatusOK},
+		{buildID: "0123456789", body: elfData, status: http.StatusBadRequest},
+		{buildID: testBuildID, body: nil, status: http.StatusBadRequest},
+	} {
+		req := httptest.NewRequest(http.MethodPut, "/symbolizer/debuginfo/"+tc.buildID, bytes.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		require.Equal(t, tc.status, rec.Code, rec.Body.String())
+	}
+
+	r, err := bucket.Get(context.Background(), testBuildID)
+	require.NoError(t, err)
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NotEmpty(t, b)
+}
@@ -23,6 +23,67 @@ func (_m *MockTombstones) EXPECT() *MockTombstones_Expecter {
 	return &MockTombstones_Expecter{mock: &_m.Mock}
 }
 
+// ListSeriesTombstones provides a mock function with given fields: tenants
+func (_m *MockTombstones) ListSeriesTombstones(tenants ...string) []*metastorev1.Tombstones {
+	_va := make([]interface{}, len(tenants))
+	for _i := range tenants {
+		_va[_i] = tenants[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSeriesTombstones")
+	}
+
+	var r0 []*metastorev1.Tombstones
+	if rf, ok := ret.Get(0).(func(...string) []*metastorev1.Tombstones); ok {
+		r0 = rf(tenants...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*metastorev1.Tombstones)
+		}
+	}
+
+	return r0
+}
+
+// MockTombstones_ListSeriesTombstones_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSeriesTombstones'
+type MockTombstones_ListSeriesTombstones_Call struct {
+	*mock.Call
+}
+
+// ListSeriesTombstones is a helper method to define mock.On call
+//   - tenants ...string
+func (_e *MockTombstones_Expecter) ListSeriesTombstones(tenants ...interface{}) *MockTombstones_ListSeriesTombstones_Call {
+	return &MockTombstones_ListSeriesTombstones_Call{Call: _e.mock.On("ListSeriesTombstones",
+		append([]interface{}{}, tenants...)...)}
+}
+
+func (_c *MockTombstones_ListSeriesTombstones_Call) Run(run func(tenants ...string)) *MockTombstones_ListSeriesTombstones_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		run(variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockTombstones_ListSeriesTombstones_Call) Return(_a0 []*metastorev1.Tombstones) *MockTombstones_ListSeriesTombstones_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTombstones_ListSeriesTombstones_Call) RunAndReturn(run func(...string) []*metastorev1.Tombstones) *MockTombstones_ListSeriesTombstones_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListTombstones provides a mock function with given fields: before
 func (_m *MockTombstones) ListTombstones(before time.Time) iter.Iterator[*metastorev1.Tombstones] {
 	ret := _m.Called(before)
@@ -113,6 +113,52 @@ func (_c *MockLimits_MaxFlameGraphNodesMax_Call) RunAndReturn(run func(string) i
 	return _c
 }
 
+// MaxHeatmapPoints provides a mock function with given fields: _a0
+func (_m *MockLimits) MaxHeatmapPoints(_a0 string) int {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxHeatmapPoints")
+	}
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
+
+// MockLimits_MaxHeatmapPoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaxHeatmapPoints'
+type MockLimits_MaxHeatmapPoints_Call struct {
+	*mock.Call
+}
+
+// MaxHeatmapPoints is a helper method to define mock.On call
+//   - _a0 string
+func (_e *MockLimits_Expecter) MaxHeatmapPoints(_a0 interface{}) *MockLimits_MaxHeatmapPoints_Call {
+	return &MockLimits_MaxHeatmapPoints_Call{Call: _e.mock.On("MaxHeatmapPoints", _a0)}
+}
+
+func (_c *MockLimits_MaxHeatmapPoints_Call) Run(run func(_a0 string)) *MockLimits_MaxHeatmapPoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockLimits_MaxHeatmapPoints_Call) Return(_a0 int) *MockLimits_MaxHeatmapPoints_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockLimits_MaxHeatmapPoints_Call) RunAndReturn(run func(string) int) *MockLimits_MaxHeatmapPoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MaxQueryLength provides a mock function with given fields: tenantID
 func (_m *MockLimits) MaxQueryLength(tenantID string) time.Duration {
 	ret := _m.Called(tenantID)
@@ -22,6 +22,65 @@ func (_m *MockTenantServiceServer) EXPECT() *MockTenantServiceServer_Expecter {
 	return &MockTenantServiceServer_Expecter{mock: &_m.Mock}
 }
 
+// DeleteSeries provides a mock function with given fields: _a0, _a1
+func (_m *MockTenantServiceServer) DeleteSeries(_a0 context.Context, _a1 *metastorev1.DeleteSeriesRequest) (*metastorev1.DeleteSeriesResponse, error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSeries")
+	}
+
+	var r0 *metastorev1.DeleteSeriesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *metastorev1.DeleteSeriesRequest) (*metastorev1.DeleteSeriesResponse, error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *metastorev1.DeleteSeriesRequest) *metastorev1.DeleteSeriesResponse); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*metastorev1.DeleteSeriesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *metastorev1.DeleteSeriesRequest) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockTenantServiceServer_DeleteSeries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSeries'
+type MockTenantServiceServer_DeleteSeries_Call struct {
+	*mock.Call
+}
+
+// DeleteSeries is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *metastorev1.DeleteSeriesRequest
+func (_e *MockTenantServiceServer_Expecter) DeleteSeries(_a0 interface{}, _a1 interface{}) *MockTenantServiceServer_DeleteSeries_Call {
+	return &MockTenantServiceServer_DeleteSeries_Call{Call: _e.mock.On("DeleteSeries", _a0, _a1)}
+}
+
+func (_c *MockTenantServiceServer_DeleteSeries_Call) Run(run func(_a0 context.Context, _a1 *metastorev1.DeleteSeriesRequest)) *MockTenantServiceServer_DeleteSeries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*metastorev1.DeleteSeriesRequest))
+	})
+	return _c
+}
+
+func (_c *MockTenantServiceServer_DeleteSeries_Call) Return(_a0 *metastorev1.DeleteSeriesResponse, _a1 error) *MockTenantServiceServer_DeleteSeries_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockTenantServiceServer_DeleteSeries_Call) RunAndReturn(run func(context.Context, *metastorev1.DeleteSeriesRequest) (*metastorev1.DeleteSeriesResponse, error)) *MockTenantServiceServer_DeleteSeries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteTenant provides a mock function with given fields: _a0, _a1
 func (_m *MockTenantServiceServer) DeleteTenant(_a0 context.Context, _a1 *metastorev1.DeleteTenantRequest) (*metastorev1.DeleteTenantResponse, error) {
 	ret := _m.Called(_a0, _a1)
@@ -383,6 +383,183 @@ func (_c *MockQuerierServiceClient_ProfileTypes_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// SelectExemplars provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectExemplars(_a0 context.Context, _a1 *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectExemplars")
+	}
+
+	var r0 *connect.Response[querierv1.SelectExemplarsResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) *connect.Response[querierv1.SelectExemplarsResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectExemplarsResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectExemplars_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectExemplars'
+type MockQuerierServiceClient_SelectExemplars_Call struct {
+	*mock.Call
+}
+
+// SelectExemplars is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectExemplarsRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectExemplars(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectExemplars_Call {
+	return &MockQuerierServiceClient_SelectExemplars_Call{Call: _e.mock.On("SelectExemplars", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectExemplarsRequest])) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectExemplarsRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) Return(_a0 *connect.Response[querierv1.SelectExemplarsResponse], _a1 error) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectExemplars_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectExemplarsRequest]) (*connect.Response[querierv1.SelectExemplarsResponse], error)) *MockQuerierServiceClient_SelectExemplars_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectGraph provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectGraph(_a0 context.Context, _a1 *connect.Request[querierv1.SelectGraphRequest]) (*connect.Response[querierv1.SelectGraphResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectGraph")
+	}
+
+	var r0 *connect.Response[querierv1.SelectGraphResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectGraphRequest]) (*connect.Response[querierv1.SelectGraphResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectGraphRequest]) *connect.Response[querierv1.SelectGraphResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectGraphResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectGraphRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectGraph_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectGraph'
+type MockQuerierServiceClient_SelectGraph_Call struct {
+	*mock.Call
+}
+
+// SelectGraph is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectGraphRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectGraph(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectGraph_Call {
+	return &MockQuerierServiceClient_SelectGraph_Call{Call: _e.mock.On("SelectGraph", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectGraph_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectGraphRequest])) *MockQuerierServiceClient_SelectGraph_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectGraphRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectGraph_Call) Return(_a0 *connect.Response[querierv1.SelectGraphResponse], _a1 error) *MockQuerierServiceClient_SelectGraph_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectGraph_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectGraphRequest]) (*connect.Response[querierv1.SelectGraphResponse], error)) *MockQuerierServiceClient_SelectGraph_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SelectHeatmap provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectHeatmap(_a0 context.Context, _a1 *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectHeatmap")
+	}
+
+	var r0 *connect.Response[querierv1.SelectHeatmapResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) *connect.Response[querierv1.SelectHeatmapResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectHeatmapResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectHeatmap_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectHeatmap'
+type MockQuerierServiceClient_SelectHeatmap_Call struct {
+	*mock.Call
+}
+
+// SelectHeatmap is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectHeatmapRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectHeatmap(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectHeatmap_Call {
+	return &MockQuerierServiceClient_SelectHeatmap_Call{Call: _e.mock.On("SelectHeatmap", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectHeatmapRequest])) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectHeatmapRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) Return(_a0 *connect.Response[querierv1.SelectHeatmapResponse], _a1 error) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectHeatmap_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectHeatmapRequest]) (*connect.Response[querierv1.SelectHeatmapResponse], error)) *MockQuerierServiceClient_SelectHeatmap_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SelectMergeProfile provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) SelectMergeProfile(_a0 context.Context, _a1 *connect.Request[querierv1.SelectMergeProfileRequest]) (*connect.Response[googlev1.Profile], error) {
 	ret := _m.Called(_a0, _a1)
@@ -560,6 +737,65 @@ func (_c *MockQuerierServiceClient_SelectMergeStacktraces_Call) RunAndReturn(run
 	return _c
 }
 
+// SelectProfileByID provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectProfileByID(_a0 context.Context, _a1 *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectProfileByID")
+	}
+
+	var r0 *connect.Response[googlev1.Profile]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) *connect.Response[googlev1.Profile]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[googlev1.Profile])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectProfileByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectProfileByID'
+type MockQuerierServiceClient_SelectProfileByID_Call struct {
+	*mock.Call
+}
+
+// SelectProfileByID is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectProfileByIDRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectProfileByID(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectProfileByID_Call {
+	return &MockQuerierServiceClient_SelectProfileByID_Call{Call: _e.mock.On("SelectProfileByID", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectProfileByIDRequest])) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectProfileByIDRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) Return(_a0 *connect.Response[googlev1.Profile], _a1 error) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectProfileByID_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectProfileByIDRequest]) (*connect.Response[googlev1.Profile], error)) *MockQuerierServiceClient_SelectProfileByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SelectSeries provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) SelectSeries(_a0 context.Context, _a1 *connect.Request[querierv1.SelectSeriesRequest]) (*connect.Response[querierv1.SelectSeriesResponse], error) {
 	ret := _m.Called(_a0, _a1)
@@ -619,6 +855,65 @@ func (_c *MockQuerierServiceClient_SelectSeries_Call) RunAndReturn(run func(cont
 	return _c
 }
 
+// SelectTopFunctions provides a mock function with given fields: _a0, _a1
+func (_m *MockQuerierServiceClient) SelectTopFunctions(_a0 context.Context, _a1 *connect.Request[querierv1.SelectTopFunctionsRequest]) (*connect.Response[querierv1.SelectTopFunctionsResponse], error) {
+	ret := _m.Called(_a0, _a1)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SelectTopFunctions")
+	}
+
+	var r0 *connect.Response[querierv1.SelectTopFunctionsResponse]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectTopFunctionsRequest]) (*connect.Response[querierv1.SelectTopFunctionsResponse], error)); ok {
+		return rf(_a0, _a1)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *connect.Request[querierv1.SelectTopFunctionsRequest]) *connect.Response[querierv1.SelectTopFunctionsResponse]); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*connect.Response[querierv1.SelectTopFunctionsResponse])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *connect.Request[querierv1.SelectTopFunctionsRequest]) error); ok {
+		r1 = rf(_a0, _a1)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockQuerierServiceClient_SelectTopFunctions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SelectTopFunctions'
+type MockQuerierServiceClient_SelectTopFunctions_Call struct {
+	*mock.Call
+}
+
+// SelectTopFunctions is a helper method to define mock.On call
+//   - _a0 context.Context
+//   - _a1 *connect.Request[querierv1.SelectTopFunctionsRequest]
+func (_e *MockQuerierServiceClient_Expecter) SelectTopFunctions(_a0 interface{}, _a1 interface{}) *MockQuerierServiceClient_SelectTopFunctions_Call {
+	return &MockQuerierServiceClient_SelectTopFunctions_Call{Call: _e.mock.On("SelectTopFunctions", _a0, _a1)}
+}
+
+func (_c *MockQuerierServiceClient_SelectTopFunctions_Call) Run(run func(_a0 context.Context, _a1 *connect.Request[querierv1.SelectTopFunctionsRequest])) *MockQuerierServiceClient_SelectTopFunctions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*connect.Request[querierv1.SelectTopFunctionsRequest]))
+	})
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectTopFunctions_Call) Return(_a0 *connect.Response[querierv1.SelectTopFunctionsResponse], _a1 error) *MockQuerierServiceClient_SelectTopFunctions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockQuerierServiceClient_SelectTopFunctions_Call) RunAndReturn(run func(context.Context, *connect.Request[querierv1.SelectTopFunctionsRequest]) (*connect.Response[querierv1.SelectTopFunctionsResponse], error)) *MockQuerierServiceClient_SelectTopFunctions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Series provides a mock function with given fields: _a0, _a1
 func (_m *MockQuerierServiceClient) Series(_a0 context.Context, _a1 *connect.Request[querierv1.SeriesRequest]) (*connect.Response[querierv1.SeriesResponse], error) {
 	ret := _m.Called(_a0, _a1)
@@ -87,12 +87,18 @@ type Limits struct {
 	MaxFlameGraphNodesDefault int `yaml:"max_flamegraph_nodes_default" json:"max_flamegraph_nodes_default"`
 	MaxFlameGraphNodesMax     int `yaml:"max_flamegraph_nodes_max" json:"max_flamegraph_nodes_max"`
 
+	// Heatmap enforced limits.
+	MaxHeatmapPoints int `yaml:"max_heatmap_points" json:"max_heatmap_points"`
+
 	// Store-gateway.
 	StoreGatewayTenantShardSize int `yaml:"store_gateway_tenant_shard_size" json:"store_gateway_tenant_shard_size"`
 
 	// Query frontend.
 	QuerySplitDuration model.Duration `yaml:"split_queries_by_interval" json:"split_queries_by_interval"`
 
+	// Ad-hoc profiles.
+	AdHocProfilesRetentionPeriod model.Duration `yaml:"adhoc_profiles_retention_period" json:"adhoc_profiles_retention_period"`
+
 	// Compactor.
 	CompactorBlocksRetentionPeriod     model.Duration `yaml:"compactor_blocks_retention_period" json:"compactor_blocks_retention_period"`
 	CompactorSplitAndMergeShards       int            `yaml:"compactor_split_and_merge_shards" json:"compactor_split_and_merge_shards"`
@@ -132,6 +138,9 @@ type Limits struct {
 
 	// Symbolizer.
 	Symbolizer Symbolizer `yaml:"symbolizer" json:"symbolizer" category:"experimental" doc:"hidden"`
+
+	// MetricsExporter.
+	MetricsExporter MetricsExporter `yaml:"metrics_exporter" json:"metrics_exporter" category:"experimental" doc:"hidden"`
 }
 
 // LimitError are errors that do not comply with the limits specified.
@@ -181,10 +190,13 @@ func (l *Limits) RegisterFlags(f *flag.FlagSet) {
 
 	f.IntVar(&l.MaxFlameGraphNodesDefault, "querier.max-flamegraph-nodes-default", 8<<10, "Maximum number of flame graph nodes by default. 0 to disable.")
 	f.IntVar(&l.MaxFlameGraphNodesMax, "querier.max-flamegraph-nodes-max", 0, "Maximum number of flame graph nodes allowed. 0 to disable.")
+	f.IntVar(&l.MaxHeatmapPoints, "querier.max-heatmap-points", 1_000_000, "Maximum number of profiles a heatmap query may select. 0 to disable.")
 
 	f.Var(&l.DistributorAggregationWindow, "distributor.aggregation-window", "Duration of the distributor aggregation window. Requires aggregation period to be specified. 0 to disable.")
 	f.Var(&l.DistributorAggregationPeriod, "distributor.aggregation-period", "Duration of the distributor aggregation period. Requires aggregation window to be specified. 0 to disable.")
 
+	f.Var(&l.AdHocProfilesRetentionPeriod, "adhoc-profiles.retention-period", "Delete uploaded ad-hoc profiles older than the specified retention period. 0 to disable.")
+
 	f.Var(&l.CompactorBlocksRetentionPeriod, "compactor.blocks-retention-period", "Delete blocks containing samples older than the specified retention period. 0 to disable.")
 	f.IntVar(&l.CompactorSplitAndMergeShards, "compactor.split-and-merge-shards", 0, "The number of shards to use when splitting blocks. 0 to disable splitting.")
 	f.IntVar(&l.CompactorSplitAndMergeStageSize, "compactor.split-and-merge-stage-size", 0, "Number of stages split shards will be written to. Number of output split shards is controlled by -compactor.split-and-merge-shards.")
@@ -244,6 +256,10 @@ func (l *Limits) Validate() error {
 		}
 	}
 
+	if err := l.Retention.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -420,6 +436,11 @@ func (o *Overrides) MaxFlameGraphNodesMax(tenantID string) int {
 	return o.getOverridesForTenant(tenantID).MaxFlameGraphNodesMax
 }
 
+// MaxHeatmapPoints returns the max number of profiles a heatmap query may select.
+func (o *Overrides) MaxHeatmapPoints(tenantID string) int {
+	return o.getOverridesForTenant(tenantID).MaxHeatmapPoints
+}
+
 // StoreGatewayTenantShardSize returns the store-gateway shard size for a given user.
 func (o *Overrides) StoreGatewayTenantShardSize(userID string) int {
 	return o.getOverridesForTenant(userID).StoreGatewayTenantShardSize
@@ -435,6 +456,11 @@ func (o *Overrides) CompactorTenantShardSize(userID string) int {
 	return o.getOverridesForTenant(userID).CompactorTenantShardSize
 }
 
+// AdHocProfilesRetentionPeriod returns the retention period of uploaded ad-hoc profiles for a given tenant.
+func (o *Overrides) AdHocProfilesRetentionPeriod(tenantID string) time.Duration {
+	return time.Duration(o.getOverridesForTenant(tenantID).AdHocProfilesRetentionPeriod)
+}
+
 // CompactorBlocksRetentionPeriod returns the retention period for a given user.
 func (o *Overrides) CompactorBlocksRetentionPeriod(userID string) time.Duration {
 	return time.Duration(o.getOverridesForTenant(userID).CompactorBlocksRetentionPeriod)
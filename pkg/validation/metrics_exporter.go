@@ -0,0 +1,19 @@
+package validation
+
+import (
+	"flag"
+)
+
+type MetricsExporter struct {
+	// Sink is the name of the metrics exporter sink the recorded series
+	// of the tenant are sent to. If empty, the default sink is used.
+	Sink string `yaml:"sink" json:"sink" category:"experimental" doc:"hidden"`
+}
+
+func (m *MetricsExporter) RegisterFlags(f *flag.FlagSet) {
+	f.StringVar(&m.Sink, "metrics-exporter.sink", "", "The name of the metrics exporter sink to send the recorded series to. If empty, the default sink is used.")
+}
+
+func (o *Overrides) MetricsExporterSink(tenantID string) string {
+	return o.getOverridesForTenant(tenantID).MetricsExporter.Sink
+}
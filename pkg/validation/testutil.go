@@ -17,6 +17,7 @@ type MockLimits struct {
 
 	MaxFlameGraphNodesDefaultValue int
 	MaxFlameGraphNodesMaxValue     int
+	MaxHeatmapPointsValue          int
 
 	DistributorAggregationWindowValue time.Duration
 	DistributorAggregationPeriodValue time.Duration
@@ -35,6 +36,8 @@ type MockLimits struct {
 	SymbolizerEnabledValue bool
 
 	IngestionBodyLimitBytesValue int64
+
+	AdHocProfilesRetentionPeriodValue time.Duration
 }
 
 func (m MockLimits) QuerySplitDuration(string) time.Duration        { return m.QuerySplitDurationValue }
@@ -48,6 +51,7 @@ func (m MockLimits) QueryAnalysisSeriesEnabled(tenantID string) bool {
 
 func (m MockLimits) MaxFlameGraphNodesDefault(string) int { return m.MaxFlameGraphNodesDefaultValue }
 func (m MockLimits) MaxFlameGraphNodesMax(string) int     { return m.MaxFlameGraphNodesMaxValue }
+func (m MockLimits) MaxHeatmapPoints(string) int          { return m.MaxHeatmapPointsValue }
 
 func (m MockLimits) MaxLabelNameLength(userID string) int     { return m.MaxLabelNameLengthValue }
 func (m MockLimits) MaxLabelValueLength(userID string) int    { return m.MaxLabelValueLengthValue }
@@ -93,3 +97,7 @@ func (m MockLimits) SymbolizerEnabled(s string) bool { return m.SymbolizerEnable
 func (m MockLimits) IngestionBodyLimitBytes(tenantID string) int64 {
 	return m.IngestionBodyLimitBytesValue
 }
+
+func (m MockLimits) AdHocProfilesRetentionPeriod(tenantID string) time.Duration {
+	return m.AdHocProfilesRetentionPeriodValue
+}
@@ -59,6 +59,9 @@ const (
 
 	IngestLimitReached     Reason = "ingest_limit_reached"
 	SkippedBySamplingRules Reason = "dropped_by_sampling_rules"
+	// SkippedByAdaptiveSampling profiles were dropped to keep the
+	// profile rate of the service within the target.
+	SkippedByAdaptiveSampling Reason = "dropped_by_adaptive_sampling"
 
 	BodySizeLimit Reason = "body_size_limit_exceeded"
 